@@ -0,0 +1,197 @@
+package envconfig
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// redacted replaces values of secret fields in audit entries
+const redacted = "******"
+
+// AuditEntry records a Load, or a reload performed by Watch
+type AuditEntry struct {
+	Time time.Time `json:"time"`
+	// SchemaHash identifies the set of variables the configuration is loaded
+	// from, it changes when the configuration struct does.
+	SchemaHash string `json:"schemaHash"`
+	// Changes are the values which changed since the previous load of the same
+	// schema, sorted by path. On first load, every value set is a change.
+	Changes []AuditChange `json:"changes"`
+	// Error is the error which failed the load, if any
+	Error string `json:"error,omitempty"`
+}
+
+// AuditChange describes the change of a value. Values of fields tagged with
+// `secret:"true"` are redacted.
+type AuditChange struct {
+	// Path is the dot separated path of the value, eg: Database.Host
+	Path     string `json:"path"`
+	Variable string `json:"variable"`
+	// Source is the name of the source which served the new value, it is
+	// empty if the value was unset.
+	Source string `json:"source"`
+	Old    string `json:"old"`
+	New    string `json:"new"`
+}
+
+// auditor records loads, comparing them with the previous load of each schema
+type auditor struct {
+	mu       sync.Mutex
+	fns      []func(AuditEntry)
+	previous map[string]map[string]ReportEntry
+}
+
+// WithAudit makes the loader write an entry to w for each load, as a line of
+// JSON.
+func WithAudit(w io.Writer) Option {
+	var mu sync.Mutex
+
+	return WithAuditFunc(func(entry AuditEntry) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		_ = json.NewEncoder(w).Encode(entry)
+	})
+}
+
+// WithAuditFunc makes the loader call fn with an entry for each load,
+// successful or not.
+func WithAuditFunc(fn func(AuditEntry)) Option {
+	return func(e *envConfig) {
+		if e.audit == nil {
+			e.audit = &auditor{previous: map[string]map[string]ReportEntry{}}
+		}
+
+		e.audit.fns = append(e.audit.fns, fn)
+	}
+}
+
+// notifyAudit records the load described by state, which failed with err if
+// not nil.
+func (e *envConfig) notifyAudit(configVal reflect.Value, state *loadState, err error) {
+	if e.audit == nil {
+		return
+	}
+
+	entry := AuditEntry{
+		Time:       time.Now(),
+		SchemaHash: e.schemaHash(configVal),
+		Changes:    []AuditChange{},
+	}
+
+	if err != nil {
+		entry.Error = e.formatErrors(err).Error()
+	} else {
+		entry.Changes = e.auditChanges(configVal.Type(), entry.SchemaHash, e.report(configVal, state))
+	}
+
+	for _, fn := range e.audit.fns {
+		fn(entry)
+	}
+}
+
+// auditChanges compares r with the previous report of the same schema, then
+// stores it as the new previous report.
+func (e *envConfig) auditChanges(configType reflect.Type, schemaHash string, r *Report) []AuditChange {
+	current := make(map[string]ReportEntry, len(r.Entries))
+
+	for _, entry := range r.Entries {
+		current[entry.Path] = entry
+	}
+
+	e.audit.mu.Lock()
+	previous := e.audit.previous[schemaHash]
+	e.audit.previous[schemaHash] = current
+	e.audit.mu.Unlock()
+
+	res := []AuditChange{}
+
+	for p, entry := range current {
+		old, ok := previous[p]
+
+		if ok && old.RawValue == entry.RawValue {
+			continue
+		}
+
+		res = append(res, AuditChange{Path: p, Variable: entry.Variable, Source: entry.Source, Old: old.RawValue, New: entry.RawValue})
+	}
+
+	for p, old := range previous {
+		if _, ok := current[p]; !ok {
+			res = append(res, AuditChange{Path: p, Variable: old.Variable, Old: old.RawValue})
+		}
+	}
+
+	for i, c := range res {
+		if isSecretPath(configType, strings.Split(c.Path, ".")) {
+			res[i].Old, res[i].New = redact(c.Old), redact(c.New)
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Path < res[j].Path
+	})
+
+	return res
+}
+
+func redact(value string) string {
+	if value == "" {
+		return ""
+	}
+
+	return redacted
+}
+
+// schemaHash hashes the variables the configuration is loaded from
+func (e *envConfig) schemaHash(configVal reflect.Value) string {
+	h := sha256.New()
+
+	fmt.Fprintln(h, configVal.Type())
+
+	if vars, err := e.Describe(configVal.Addr().Interface()); err == nil {
+		for _, v := range vars {
+			fmt.Fprintln(h, v.Name, v.Type)
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// isSecretPath reports if the value at given path of valType belongs to a
+// field tagged with `secret:"true"`. Keys of maps, slices and arrays are
+// skipped.
+func isSecretPath(valType reflect.Type, valuePath path) bool {
+	for _, key := range valuePath {
+		valType = indirectedType(valType)
+
+		switch valType.Kind() {
+		case reflect.Struct:
+			field, ok := valType.FieldByName(key)
+
+			if !ok {
+				return false
+			}
+
+			if field.Tag.Get(secretTag) == "true" {
+				return true
+			}
+
+			valType = field.Type
+		case reflect.Map, reflect.Slice, reflect.Array:
+			valType = valType.Elem()
+		default:
+			return false
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,101 @@
+package envconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+type auditedConfig struct {
+	Host     string
+	Port     int
+	Password string `secret:"true"`
+}
+
+func TestLoadWithAudit(t *testing.T) {
+	var buf bytes.Buffer
+
+	source := mapSource{
+		"APP_HOST":     "localhost",
+		"APP_PORT":     "80",
+		"APP_PASSWORD": "hunter2",
+	}
+
+	loader := New("APP", "_", WithSource(source, WithName("file")), WithAudit(&buf))
+
+	steps := []struct {
+		Label  string
+		Values mapSource
+	}{
+		{"FirstLoad", nil},
+		{"Reload", mapSource{"APP_HOST": "localhost", "APP_PASSWORD": "hunter3"}},
+		{"Failure", mapSource{"APP_PORT": "foo"}},
+	}
+
+	for _, step := range steps {
+		if step.Values != nil {
+			for key := range source {
+				delete(source, key)
+			}
+
+			for key, value := range step.Values {
+				source[key] = value
+			}
+		}
+
+		var result auditedConfig
+
+		_ = loader.Load(&result)
+	}
+
+	dec := json.NewDecoder(&buf)
+
+	var entries []AuditEntry
+
+	for dec.More() {
+		var entry AuditEntry
+
+		if err := dec.Decode(&entry); err != nil {
+			t.Logf("Expected JSON lines, got [%v]", err)
+			t.FailNow()
+		}
+
+		entries = append(entries, entry)
+	}
+
+	if len(entries) != len(steps) {
+		t.Logf("Expected %d entries, got %v", len(steps), entries)
+		t.FailNow()
+	}
+
+	expectations := [][]AuditChange{
+		{
+			{Path: "Host", Variable: "APP_HOST", Source: "file", New: "localhost"},
+			{Path: "Password", Variable: "APP_PASSWORD", Source: "file", New: "******"},
+			{Path: "Port", Variable: "APP_PORT", Source: "file", New: "80"},
+		},
+		{
+			{Path: "Password", Variable: "APP_PASSWORD", Source: "file", Old: "******", New: "******"},
+			{Path: "Port", Variable: "APP_PORT", Old: "80"},
+		},
+		{},
+	}
+
+	for i, entry := range entries {
+		if !reflect.DeepEqual(entry.Changes, expectations[i]) {
+			t.Logf("%s: expected changes %v, got %v", steps[i].Label, expectations[i], entry.Changes)
+			t.Fail()
+		}
+
+		if entry.SchemaHash != entries[0].SchemaHash || entry.Time.IsZero() {
+			t.Logf("%s: expected a time and a stable schema hash, got %v", steps[i].Label, entry)
+			t.Fail()
+		}
+	}
+
+	if entries[2].Error == "" {
+		t.Log("Expected failed load to be recorded with its error")
+		t.Fail()
+	}
+}
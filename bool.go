@@ -0,0 +1,29 @@
+package envconfig
+
+import (
+	"reflect"
+	"strconv"
+
+	"github.com/jlevesy/envconfig/setter"
+)
+
+var boolType = reflect.TypeOf(true)
+
+// WithRelaxedBools accepts yes/no, on/off and enabled/disabled, case
+// insensitively, for bool fields and requiredIf conditions, in addition to the
+// values accepted by strconv.ParseBool. See setter.RelaxedBool.
+func WithRelaxedBools() Option {
+	return func(e *envConfig) {
+		e.relaxedBools = true
+		e.RegisterSetter(boolType, setter.RelaxedBool())
+	}
+}
+
+// parseBool parses given bool value, honouring WithRelaxedBools
+func (e *envConfig) parseBool(value string) (bool, error) {
+	if e.relaxedBools {
+		return setter.ParseRelaxedBool(value)
+	}
+
+	return strconv.ParseBool(value)
+}
@@ -0,0 +1,55 @@
+package envconfig
+
+import "testing"
+
+type boolConfig struct {
+	Debug bool
+	Token string `requiredIf:"APP_AUTH_ENABLED"`
+}
+
+func TestLoadWithRelaxedBools(t *testing.T) {
+	testCases := []struct {
+		Label        string
+		Env          mapSource
+		Options      []Option
+		Expectation  bool
+		ExpectsError bool
+	}{
+		{"WithStrictValue", mapSource{"APP_DEBUG": "true"}, nil, true, false},
+		{"WithRelaxedValueWithoutOption", mapSource{"APP_DEBUG": "yes"}, nil, false, true},
+		{"WithYes", mapSource{"APP_DEBUG": "yes"}, []Option{WithRelaxedBools()}, true, false},
+		{"WithOff", mapSource{"APP_DEBUG": "OFF"}, []Option{WithRelaxedBools()}, false, false},
+		{"WithEnabled", mapSource{"APP_DEBUG": "Enabled"}, []Option{WithRelaxedBools()}, true, false},
+		{"WithStrictValueAndOption", mapSource{"APP_DEBUG": "1"}, []Option{WithRelaxedBools()}, true, false},
+		{"WithInvalidValue", mapSource{"APP_DEBUG": "maybe"}, []Option{WithRelaxedBools()}, false, true},
+		{"WithRelaxedCondition", mapSource{"APP_AUTH_ENABLED": "off"}, []Option{WithRelaxedBools()}, false, false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			var result boolConfig
+
+			opts := append(testCase.Options, WithSource(testCase.Env))
+			err := New("APP", "_", opts...).Load(&result)
+
+			if testCase.ExpectsError {
+				if err == nil {
+					t.Log("Expected an error, got nothing")
+					t.Fail()
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Logf("Wasn't expecting an error, got [%v]", err)
+				t.FailNow()
+			}
+
+			if result.Debug != testCase.Expectation {
+				t.Logf("Expected %t, got %t", testCase.Expectation, result.Debug)
+				t.Fail()
+			}
+		})
+	}
+}
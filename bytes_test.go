@@ -0,0 +1,113 @@
+package envconfig
+
+import (
+	"reflect"
+	"testing"
+)
+
+type bytesConfig struct {
+	Key    []byte  `envconfig:"encoding=base64"`
+	Salt   *[]byte `envconfig:"encoding=hex"`
+	Secret []byte  `envconfig:"encoding=base64,default=c2VjcmV0"`
+}
+
+type invalidEncodingConfig struct {
+	Key []byte `envconfig:"encoding=base32"`
+}
+
+type encodingTagConfig struct {
+	Key []byte `encoding:"base64"`
+}
+
+func TestLoadBytes(t *testing.T) {
+	salt := []byte{0xca, 0xfe}
+
+	testCases := []struct {
+		Label       string
+		Env         mapSource
+		Config      interface{}
+		Expectation interface{}
+		Error       ErrorCode
+	}{
+		{
+			"WithValues",
+			mapSource{"APP_KEY": "aGVsbG8=", "APP_SALT": "cafe"},
+			&bytesConfig{},
+			&bytesConfig{Key: []byte("hello"), Salt: &salt, Secret: []byte("secret")},
+			"",
+		},
+		{"WithInvalidBase64", mapSource{"APP_KEY": "not base64"}, &bytesConfig{}, nil, CodeParseFailure},
+		{"WithInvalidHex", mapSource{"APP_SALT": "xyz"}, &bytesConfig{}, nil, CodeParseFailure},
+		{"WithUnknownEncoding", mapSource{"APP_KEY": "foo"}, &invalidEncodingConfig{}, nil, CodeInvalidTag},
+		{"WithEncodingTag", mapSource{"APP_KEY": "aGVsbG8="}, &encodingTagConfig{}, nil, CodeInvalidTag},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			err := New("APP", "_", WithSource(testCase.Env)).Load(testCase.Config)
+
+			if testCase.Error != "" {
+				if code := CodeOf(err); code != testCase.Error {
+					t.Logf("Expected code %s, got [%v]", testCase.Error, err)
+					t.Fail()
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Logf("Wasn't expecting an error, got [%v]", err)
+				t.FailNow()
+			}
+
+			if !reflect.DeepEqual(testCase.Config, testCase.Expectation) {
+				t.Logf("Expected %+v got %+v", testCase.Expectation, testCase.Config)
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestDumpBytes(t *testing.T) {
+	salt := []byte{0xca, 0xfe}
+	config := bytesConfig{Key: []byte("hello"), Salt: &salt, Secret: []byte("secret")}
+
+	res, err := New("APP", "_").(Dumper).Dump(&config)
+
+	if err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	for name, expected := range map[string]string{
+		"APP_KEY":    "aGVsbG8=",
+		"APP_SALT":   "cafe",
+		"APP_SECRET": "c2VjcmV0",
+	} {
+		if res[name] != expected {
+			t.Logf("Expected %s to be %q, got %q", name, expected, res[name])
+			t.Fail()
+		}
+	}
+}
+
+func TestDescribeBytes(t *testing.T) {
+	vars, err := New("APP", "_").(Describer).Describe(&bytesConfig{})
+
+	if err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	expectation := map[string]string{"APP_KEY": "base64", "APP_SALT": "hex", "APP_SECRET": "base64"}
+	result := map[string]string{}
+
+	for _, v := range vars {
+		result[v.Name] = v.Encoding
+	}
+
+	if !reflect.DeepEqual(result, expectation) {
+		t.Logf("Expected encodings %v, got %v", expectation, result)
+		t.Fail()
+	}
+}
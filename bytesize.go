@@ -0,0 +1,7 @@
+package envconfig
+
+import "github.com/jlevesy/envconfig/setter"
+
+// ByteSize is a field type holding a number of bytes, parsed from human
+// readable sizes like "10MB" or "1GiB". See setter.ByteSize.
+type ByteSize = setter.ByteSize
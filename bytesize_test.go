@@ -0,0 +1,76 @@
+package envconfig
+
+import "testing"
+
+type byteSizeConfig struct {
+	BufferSize ByteSize
+}
+
+func TestLoadByteSize(t *testing.T) {
+	testCases := []struct {
+		Label        string
+		Value        string
+		Expectation  ByteSize
+		ExpectsError bool
+	}{
+		{"WithoutUnit", "512", 512, false},
+		{"WithBytes", "512B", 512, false},
+		{"WithDecimalUnit", "10MB", 10 * 1000 * 1000, false},
+		{"WithBinaryUnit", "1GiB", 1 << 30, false},
+		{"WithLowerCaseUnit", "64kib", 64 << 10, false},
+		{"WithSpace", "2 KB", 2000, false},
+		{"WithFraction", "1.5KiB", 1536, false},
+		{"WithUnknownUnit", "10XB", 0, true},
+		{"WithNegativeSize", "-1KB", 0, true},
+		{"WithoutNumber", "MB", 0, true},
+		{"WithOverflow", "20000PiB", 0, true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			var result byteSizeConfig
+
+			err := New("APP", "_", WithSource(mapSource{"APP_BUFFER_SIZE": testCase.Value})).Load(&result)
+
+			if testCase.ExpectsError {
+				if err == nil {
+					t.Log("Expected an error, got nothing")
+					t.Fail()
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Logf("Wasn't expecting an error, got [%v]", err)
+				t.FailNow()
+			}
+
+			if result.BufferSize != testCase.Expectation {
+				t.Logf("Expected %d, got %d", testCase.Expectation, result.BufferSize)
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestByteSizeString(t *testing.T) {
+	testCases := []struct {
+		Size        ByteSize
+		Expectation string
+	}{
+		{0, "0B"},
+		{1000, "1KB"},
+		{1024, "1KiB"},
+		{1536, "1536B"},
+		{10 * 1000 * 1000, "10MB"},
+		{3 << 30, "3GiB"},
+	}
+
+	for _, testCase := range testCases {
+		if result := testCase.Size.String(); result != testCase.Expectation {
+			t.Logf("Expected %s, got %s", testCase.Expectation, result)
+			t.Fail()
+		}
+	}
+}
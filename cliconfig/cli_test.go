@@ -0,0 +1,64 @@
+package cliconfig
+
+import (
+	"os"
+	"testing"
+
+	"github.com/urfave/cli/v2"
+)
+
+type serverConfig struct {
+	Database struct {
+		Host string
+		Port int
+	}
+	Debug bool
+}
+
+func TestFlagsThenLoad(t *testing.T) {
+	os.Setenv("APP_DATABASE_HOST", "env-host")
+	os.Setenv("APP_DATABASE_PORT", "5432")
+	defer os.Unsetenv("APP_DATABASE_HOST")
+	defer os.Unsetenv("APP_DATABASE_PORT")
+
+	flags, err := Flags(&serverConfig{}, "APP", "_")
+
+	if err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	if len(flags) != 3 {
+		t.Logf("Expected 3 flags, got %d", len(flags))
+		t.FailNow()
+	}
+
+	if flag := flags[0].(*cli.StringFlag); flag.Name != "database-host" || flag.EnvVars[0] != "APP_DATABASE_HOST" {
+		t.Logf("Unexpected flag %+v", flag)
+		t.Fail()
+	}
+
+	if _, ok := flags[2].(*cli.BoolFlag); !ok {
+		t.Logf("Expected a bool flag, got %T", flags[2])
+		t.Fail()
+	}
+
+	result := serverConfig{}
+
+	app := &cli.App{
+		Flags: flags,
+		Action: func(c *cli.Context) error {
+			return Load(c, &result, "APP", "_")
+		},
+	}
+
+	if err := app.Run([]string{"server", "--database-host=flag-host", "--debug"}); err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	if result.Database.Host != "flag-host" || result.Database.Port != 5432 || !result.Debug {
+		t.Logf("Unexpected config %+v", result)
+		t.Fail()
+	}
+}
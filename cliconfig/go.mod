@@ -0,0 +1,17 @@
+module github.com/jlevesy/envconfig/cliconfig
+
+go 1.19
+
+require (
+	github.com/jlevesy/envconfig v0.0.0
+	github.com/urfave/cli/v2 v2.27.1
+)
+
+require (
+	github.com/cpuguy83/go-md2man/v2 v2.0.2 // indirect
+	github.com/fatih/camelcase v1.0.0 // indirect
+	github.com/russross/blackfriday/v2 v2.1.0 // indirect
+	github.com/xrash/smetrics v0.0.0-20201216005158-039620a65673 // indirect
+)
+
+replace github.com/jlevesy/envconfig => ../
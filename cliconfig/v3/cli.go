@@ -0,0 +1,101 @@
+// Package cliconfig bridges envconfig and urfave/cli v3: it generates flags
+// from a configuration struct, with sources populated from the variable
+// names, so flags help and environment documentation stay in sync.
+package cliconfig
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/jlevesy/envconfig"
+	"github.com/jlevesy/envconfig/sources"
+)
+
+// FlagsSourceName is the name of the source serving flags values, it can be
+// used in `source` struct tags.
+const FlagsSourceName = "flags"
+
+// Flags returns a flag for each variable of given configuration struct.
+// Flags are named after the field path, and read the variable they are named
+// after: --database-host reads APP_DATABASE_HOST.
+func Flags(config interface{}, prefix, separator string) ([]cli.Flag, error) {
+	vars, err := envconfig.New(prefix, separator).(envconfig.Describer).Describe(config)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]cli.Flag, 0, len(vars))
+
+	for _, v := range vars {
+		if v.Type.Kind() == reflect.Bool {
+			res = append(res, &cli.BoolFlag{Name: v.FlagName(), Usage: "Sets $" + v.Name, Sources: cli.EnvVars(v.Name)})
+			continue
+		}
+
+		res = append(res, &cli.StringFlag{Name: v.FlagName(), Usage: "Sets $" + v.Name, Sources: cli.EnvVars(v.Name)})
+	}
+
+	return res, nil
+}
+
+// Load loads given configuration struct from the process environment, then
+// from sources declared in opts, then from flags generated by Flags and set
+// on the command line or through their variable.
+func Load(ctx context.Context, cmd *cli.Command, config interface{}, prefix, separator string, opts ...envconfig.Option) error {
+	vars, err := envconfig.New(prefix, separator, opts...).(envconfig.Describer).Describe(config)
+
+	if err != nil {
+		return err
+	}
+
+	flags := flagsSource{command: cmd, vars: map[string]envconfig.VarInfo{}}
+
+	for _, v := range vars {
+		flags.vars[v.Name] = v
+	}
+
+	loaderOpts := append(
+		[]envconfig.Option{envconfig.WithSource(sources.Env(), envconfig.WithName("env"))},
+		opts...,
+	)
+	loaderOpts = append(loaderOpts, envconfig.WithSource(flags, envconfig.WithName(FlagsSourceName)))
+
+	return envconfig.New(prefix, separator, loaderOpts...).(envconfig.ContextLoader).LoadContext(ctx, config)
+}
+
+// flagsSource serves values of set flags, under the name of their variable
+type flagsSource struct {
+	command *cli.Command
+	vars    map[string]envconfig.VarInfo
+}
+
+func (f flagsSource) Lookup(key string) (string, bool, error) {
+	v, ok := f.vars[key]
+
+	if !ok || !f.command.IsSet(v.FlagName()) {
+		return "", false, nil
+	}
+
+	if v.Type.Kind() == reflect.Bool {
+		return strconv.FormatBool(f.command.Bool(v.FlagName())), true, nil
+	}
+
+	return f.command.String(v.FlagName()), true, nil
+}
+
+func (f flagsSource) Keys(prefix string) ([]string, error) {
+	res := []string{}
+
+	for key := range f.vars {
+		if _, ok, _ := f.Lookup(key); ok && strings.HasPrefix(key, prefix) {
+			res = append(res, key)
+		}
+	}
+
+	return res, nil
+}
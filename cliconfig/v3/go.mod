@@ -0,0 +1,12 @@
+module github.com/jlevesy/envconfig/cliconfig/v3
+
+go 1.19
+
+require (
+	github.com/jlevesy/envconfig v0.0.0
+	github.com/urfave/cli/v3 v3.0.0-beta1
+)
+
+require github.com/fatih/camelcase v1.0.0 // indirect
+
+replace github.com/jlevesy/envconfig => ../../
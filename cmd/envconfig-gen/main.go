@@ -0,0 +1,359 @@
+// Command envconfig-gen embeds the table of variables a configuration struct
+// is loaded from into a file. It is meant to be invoked by go generate from
+// the package declaring the struct:
+//
+//	//go:generate envconfig-gen -struct Config -prefix APP -out CONFIG.md
+//
+// The table is written between <!-- envconfig:begin --> and
+// <!-- envconfig:end --> markers, content around them is left untouched. If
+// the file doesn't exist or has no markers, the table is appended to it.
+//
+// The struct is read from the sources of the package, so only fields of
+// types declared in this package are expanded, like the loader does. Fields
+// holding arrays, slices or maps are named after keys found in sources,
+// therefore they are not listed.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/jlevesy/envconfig/internal/tags"
+)
+
+const (
+	beginMarker = "<!-- envconfig:begin -->"
+	endMarker   = "<!-- envconfig:end -->"
+
+	envConfigTag = "envconfig"
+	maxDepth     = 10
+)
+
+func main() {
+	var (
+		structName = flag.String("struct", "", "name of the configuration struct")
+		out        = flag.String("out", "CONFIG.md", "file to embed the table into")
+		prefix     = flag.String("prefix", "", "prefix of variables")
+		separator  = flag.String("separator", "_", "separator of variables")
+		dir        = flag.String("dir", ".", "directory of the package declaring the struct")
+	)
+
+	flag.Parse()
+
+	if *structName == "" {
+		fmt.Fprintln(os.Stderr, "envconfig-gen: -struct is required")
+		os.Exit(2)
+	}
+
+	if err := run(*dir, *structName, *prefix, *separator, *out); err != nil {
+		fmt.Fprintf(os.Stderr, "envconfig-gen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(dir, structName, prefix, separator, out string) error {
+	types, err := parseTypes(dir)
+
+	if err != nil {
+		return err
+	}
+
+	g := &generator{types: types, prefix: prefix, separator: separator}
+	vars, err := g.describe(structName)
+
+	if err != nil {
+		return err
+	}
+
+	content, err := ioutil.ReadFile(out)
+
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return ioutil.WriteFile(out, embed(content, renderTable(vars)), 0644)
+}
+
+// parseTypes returns type declarations found in non test files of given dir
+func parseTypes(dir string) (map[string]*ast.TypeSpec, error) {
+	fset := token.NewFileSet()
+	notTest := func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}
+
+	pkgs, err := parser.ParseDir(fset, dir, notTest, parser.ParseComments)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := map[string]*ast.TypeSpec{}
+
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+
+				if !ok || gen.Tok != token.TYPE {
+					continue
+				}
+
+				for _, spec := range gen.Specs {
+					typeSpec := spec.(*ast.TypeSpec)
+					res[typeSpec.Name.Name] = typeSpec
+				}
+			}
+		}
+	}
+
+	return res, nil
+}
+
+type variable struct {
+	Name        string
+	Type        string
+	Description string
+}
+
+type generator struct {
+	types     map[string]*ast.TypeSpec
+	prefix    string
+	separator string
+}
+
+func (g *generator) describe(structName string) ([]variable, error) {
+	spec, ok := g.types[structName]
+
+	if !ok {
+		return nil, fmt.Errorf("Type %s not found", structName)
+	}
+
+	structType, ok := spec.Type.(*ast.StructType)
+
+	if !ok {
+		return nil, fmt.Errorf("Type %s is not a struct", structName)
+	}
+
+	return g.describeStruct(structType, nil, g.childName("", g.prefix))
+}
+
+func (g *generator) describeStruct(structType *ast.StructType, currentPath []string, structName string) ([]variable, error) {
+	res := []variable{}
+
+	for _, field := range structType.Fields.List {
+		// Embedded struct
+		if len(field.Names) == 0 {
+			if inner, ok := g.localStruct(field.Type); ok {
+				vars, err := g.describeStruct(inner, currentPath, structName)
+
+				if err != nil {
+					return nil, err
+				}
+
+				res = append(res, vars...)
+			}
+
+			continue
+		}
+
+		for _, name := range field.Names {
+			fieldPath := append(append([]string{}, currentPath...), name.Name)
+
+			if len(fieldPath) > maxDepth {
+				return nil, fmt.Errorf("Maxdepth exceeded, you might have a type loop in your structure")
+			}
+
+			varName, noexpand, ignored, err := g.fieldName(structName, name.Name, field)
+
+			if err != nil {
+				return nil, fmt.Errorf("Invalid envconfig tag of field %s: %v", strings.Join(fieldPath, "."), err)
+			}
+
+			if ignored {
+				continue
+			}
+
+			if noexpand {
+				res = append(res, g.variable(varName, field))
+				continue
+			}
+
+			switch fieldType := indirect(field.Type).(type) {
+			case *ast.ArrayType, *ast.MapType:
+				continue
+			case *ast.InterfaceType, *ast.ChanType, *ast.FuncType:
+				return nil, fmt.Errorf("Field %s type is not supported", strings.Join(fieldPath, "."))
+			default:
+				if inner, ok := g.localStruct(fieldType); ok {
+					vars, err := g.describeStruct(inner, fieldPath, varName)
+
+					if err != nil {
+						return nil, err
+					}
+
+					res = append(res, vars...)
+					continue
+				}
+			}
+
+			res = append(res, g.variable(varName, field))
+		}
+	}
+
+	return res, nil
+}
+
+// localStruct returns the struct type given expression refers to, if it is
+// declared inline or in the parsed package.
+func (g *generator) localStruct(expr ast.Expr) (*ast.StructType, bool) {
+	switch e := indirect(expr).(type) {
+	case *ast.StructType:
+		return e, true
+	case *ast.Ident:
+		spec, ok := g.types[e.Name]
+
+		if !ok {
+			return nil, false
+		}
+
+		return g.localStruct(spec.Type)
+	}
+
+	return nil, false
+}
+
+// fieldName returns the variable name of a field of a struct named structName,
+// and whether it is loaded as a whole or ignored according to its tag. Tags
+// are parsed like the loader does, so malformed ones are reported.
+func (g *generator) fieldName(structName, fieldName string, field *ast.Field) (string, bool, bool, error) {
+	name := g.childName(structName, fieldName)
+	t, ok := tagOf(field)
+
+	if !ok {
+		return name, false, false, nil
+	}
+
+	if t == tags.Ignore {
+		return "", false, true, nil
+	}
+
+	options, err := tags.Parse(t)
+
+	if err != nil {
+		return "", false, false, err
+	}
+
+	if override, ok := options.Get(tags.Name); ok {
+		name = override
+	}
+
+	return name, options.Whole(), false, nil
+}
+
+// childName names the variable of a field named key of a struct named parent
+func (g *generator) childName(parent, key string) string {
+	child := tags.VariableName(g.separator, key)
+
+	if parent == "" {
+		return child
+	}
+
+	return parent + g.separator + child
+}
+
+func (g *generator) variable(name string, field *ast.Field) variable {
+	description := field.Doc.Text()
+
+	if description == "" {
+		description = field.Comment.Text()
+	}
+
+	return variable{
+		Name:        name,
+		Type:        typeString(indirect(field.Type)),
+		Description: strings.Join(strings.Fields(description), " "),
+	}
+}
+
+func tagOf(field *ast.Field) (string, bool) {
+	if field.Tag == nil {
+		return "", false
+	}
+
+	tag, err := strconv.Unquote(field.Tag.Value)
+
+	if err != nil {
+		return "", false
+	}
+
+	return reflect.StructTag(tag).Lookup(envConfigTag)
+}
+
+func indirect(expr ast.Expr) ast.Expr {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		return indirect(star.X)
+	}
+
+	return expr
+}
+
+func typeString(expr ast.Expr) string {
+	var buf bytes.Buffer
+
+	switch e := expr.(type) {
+	case *ast.Ident:
+		buf.WriteString(e.Name)
+	case *ast.SelectorExpr:
+		buf.WriteString(typeString(e.X) + "." + e.Sel.Name)
+	case *ast.StarExpr:
+		buf.WriteString("*" + typeString(e.X))
+	case *ast.ArrayType:
+		buf.WriteString("[]" + typeString(e.Elt))
+	case *ast.MapType:
+		buf.WriteString("map[" + typeString(e.Key) + "]" + typeString(e.Value))
+	default:
+		buf.WriteString("struct")
+	}
+
+	return buf.String()
+}
+
+func renderTable(vars []variable) string {
+	var buf bytes.Buffer
+
+	buf.WriteString("| Variable | Type | Description |\n")
+	buf.WriteString("|----------|------|-------------|\n")
+
+	for _, v := range vars {
+		fmt.Fprintf(&buf, "| `%s` | `%s` | %s |\n", v.Name, v.Type, strings.Replace(v.Description, "|", `\|`, -1))
+	}
+
+	return buf.String()
+}
+
+// embed replaces content between markers by given table, or appends the
+// table surrounded by markers if there are none.
+func embed(content []byte, table string) []byte {
+	text := string(content)
+	begin := strings.Index(text, beginMarker)
+	end := strings.Index(text, endMarker)
+
+	if begin < 0 || end < begin {
+		if text != "" && !strings.HasSuffix(text, "\n") {
+			text += "\n"
+		}
+
+		return []byte(text + beginMarker + "\n" + table + endMarker + "\n")
+	}
+
+	return []byte(text[:begin+len(beginMarker)] + "\n" + table + text[end:])
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const fixture = `package fixture
+
+import "time"
+
+type Embedded struct {
+	Region string
+}
+
+type Database struct {
+	// Host of the database server
+	Host     string
+	MaxConns *int // Maximum count of open connections
+}
+
+type Config struct {
+	Embedded
+	Database Database
+	Timeout  time.Duration
+	Proxy    string   ` + "`envconfig:\"name=HTTP_PROXY,default=a,b\"`" + `
+	Repos    []string
+	Raw      []string ` + "`envconfig:\"noexpand\"`" + `
+	Ignored  string   ` + "`envconfig:\"-\"`" + `
+	Key      []byte   ` + "`envconfig:\"encoding=base64\"`" + `
+}
+`
+
+func TestRun(t *testing.T) {
+	dir, err := ioutil.TempDir("", "envconfig-gen")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "config.go"), []byte(fixture), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	out := filepath.Join(dir, "CONFIG.md")
+	header := "# Configuration\n\n" + beginMarker + "\nstale\n" + endMarker + "\n\nFooter\n"
+
+	if err := ioutil.WriteFile(out, []byte(header), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := run(dir, "Config", "APP", "_", out); err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	result, err := ioutil.ReadFile(out)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectation := "# Configuration\n\n" + beginMarker + `
+| Variable | Type | Description |
+|----------|------|-------------|
+| ` + "`APP_REGION` | `string` |  " + `|
+| ` + "`APP_DATABASE_HOST` | `string` | Host of the database server" + ` |
+| ` + "`APP_DATABASE_MAX_CONNS` | `int` | Maximum count of open connections" + ` |
+| ` + "`APP_TIMEOUT` | `time.Duration` |  " + `|
+| ` + "`HTTP_PROXY` | `string` |  " + `|
+| ` + "`APP_RAW` | `[]string` |  " + `|
+| ` + "`APP_KEY` | `[]byte` |  " + `|
+` + endMarker + "\n\nFooter\n"
+
+	if string(result) != expectation {
+		t.Logf("Expected\n%s\ngot\n%s", expectation, result)
+		t.Fail()
+	}
+}
+
+func TestEmbedWithoutMarkers(t *testing.T) {
+	result := string(embed([]byte("# Configuration"), "table\n"))
+	expectation := "# Configuration\n" + beginMarker + "\ntable\n" + endMarker + "\n"
+
+	if result != expectation {
+		t.Logf("Expected\n%s\ngot\n%s", expectation, result)
+		t.Fail()
+	}
+}
+
+func TestRunWithInvalidTag(t *testing.T) {
+	dir, err := ioutil.TempDir("", "envconfig-gen")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	source := "package fixture\n\ntype Config struct {\n\tHost string `envconfig:\"nmae=HOST\"`\n}\n"
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "config.go"), []byte(source), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	out := filepath.Join(dir, "CONFIG.md")
+
+	if err := run(dir, "Config", "APP", "_", out); err == nil {
+		t.Logf("Was expecting an error for an unknown option, got none")
+		t.Fail()
+	}
+
+	if _, err := os.Stat(out); !os.IsNotExist(err) {
+		t.Logf("Wasn't expecting %s to be written", out)
+		t.Fail()
+	}
+}
@@ -0,0 +1,124 @@
+// Package cobraconfig wires envconfig into cobra based CLIs: every variable
+// of a configuration struct gets a matching persistent flag, and flags take
+// precedence over the environment.
+package cobraconfig
+
+import (
+	"context"
+	"reflect"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/jlevesy/envconfig"
+	"github.com/jlevesy/envconfig/sources"
+)
+
+// FlagsSourceName is the name of the source serving flags values, it can be
+// used in `source` struct tags.
+const FlagsSourceName = "flags"
+
+type contextKey struct{}
+
+// Bind registers a persistent flag on cmd for each variable of given
+// configuration struct, then loads the struct before cmd, or any of its
+// subcommands, runs. Flags are named after the field path, so the
+// APP_DATABASE_HOST variable is overridden by --database-host.
+//
+// Values are read from the process environment, then from sources declared
+// in opts, then from flags set on the command line. The loaded struct is
+// injected into the command context and can be retrieved using FromContext.
+// Existing PersistentPreRunE of cmd is called after loading.
+func Bind(cmd *cobra.Command, config interface{}, prefix, separator string, opts ...envconfig.Option) error {
+	vars, err := envconfig.New(prefix, separator, opts...).(envconfig.Describer).Describe(config)
+
+	if err != nil {
+		return err
+	}
+
+	flagSet := cmd.PersistentFlags()
+	flags := flagsSource{flagSet: flagSet, names: map[string]string{}}
+
+	for _, v := range vars {
+		name := v.FlagName()
+		flagSet.String(name, "", "Overrides $"+v.Name)
+
+		if v.Type.Kind() == reflect.Bool {
+			flagSet.Lookup(name).NoOptDefVal = "true"
+		}
+
+		flags.names[v.Name] = name
+	}
+
+	loaderOpts := append(
+		[]envconfig.Option{envconfig.WithSource(sources.Env(), envconfig.WithName("env"))},
+		opts...,
+	)
+	loaderOpts = append(loaderOpts, envconfig.WithSource(flags, envconfig.WithName(FlagsSourceName)))
+
+	loader := envconfig.New(prefix, separator, loaderOpts...).(envconfig.ContextLoader)
+	next := cmd.PersistentPreRunE
+
+	cmd.PersistentPreRunE = func(c *cobra.Command, args []string) error {
+		ctx := c.Context()
+
+		if ctx == nil {
+			ctx = context.Background()
+		}
+
+		if err := loader.LoadContext(ctx, config); err != nil {
+			return err
+		}
+
+		c.SetContext(context.WithValue(ctx, contextKey{}, config))
+
+		if next != nil {
+			return next(c, args)
+		}
+
+		return nil
+	}
+
+	return nil
+}
+
+// FromContext returns the configuration struct injected by Bind, or nil.
+func FromContext(ctx context.Context) interface{} {
+	return ctx.Value(contextKey{})
+}
+
+// flagsSource serves values of flags set on the command line, under the name
+// of the variable they override.
+type flagsSource struct {
+	flagSet *pflag.FlagSet
+	names   map[string]string
+}
+
+func (f flagsSource) Lookup(key string) (string, bool, error) {
+	name, ok := f.names[key]
+
+	if !ok {
+		return "", false, nil
+	}
+
+	flag := f.flagSet.Lookup(name)
+
+	if flag == nil || !flag.Changed {
+		return "", false, nil
+	}
+
+	return flag.Value.String(), true, nil
+}
+
+func (f flagsSource) Keys(prefix string) ([]string, error) {
+	res := []string{}
+
+	for key := range f.names {
+		if _, ok, _ := f.Lookup(key); ok && strings.HasPrefix(key, prefix) {
+			res = append(res, key)
+		}
+	}
+
+	return res, nil
+}
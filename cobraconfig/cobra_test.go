@@ -0,0 +1,62 @@
+package cobraconfig
+
+import (
+	"os"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+type serverConfig struct {
+	Database struct {
+		Host string
+		Port int
+	}
+	Debug bool
+}
+
+func TestBind(t *testing.T) {
+	os.Setenv("APP_DATABASE_HOST", "env-host")
+	os.Setenv("APP_DATABASE_PORT", "5432")
+	defer os.Unsetenv("APP_DATABASE_HOST")
+	defer os.Unsetenv("APP_DATABASE_PORT")
+
+	var result *serverConfig
+
+	cmd := &cobra.Command{
+		Use: "server",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			result = FromContext(cmd.Context()).(*serverConfig)
+			return nil
+		},
+	}
+
+	if err := Bind(cmd, &serverConfig{}, "APP", "_"); err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	for _, name := range []string{"database-host", "database-port", "debug"} {
+		if cmd.PersistentFlags().Lookup(name) == nil {
+			t.Logf("Expected flag %s to be registered", name)
+			t.Fail()
+		}
+	}
+
+	cmd.SetArgs([]string{"--database-host", "flag-host", "--debug"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	if result == nil {
+		t.Log("Expected config to be injected into the command context")
+		t.FailNow()
+	}
+
+	if result.Database.Host != "flag-host" || result.Database.Port != 5432 || !result.Debug {
+		t.Logf("Unexpected config %+v", result)
+		t.Fail()
+	}
+}
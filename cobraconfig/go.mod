@@ -0,0 +1,16 @@
+module github.com/jlevesy/envconfig/cobraconfig
+
+go 1.19
+
+require (
+	github.com/jlevesy/envconfig v0.0.0
+	github.com/spf13/cobra v1.8.0
+	github.com/spf13/pflag v1.0.5
+)
+
+require (
+	github.com/fatih/camelcase v1.0.0 // indirect
+	github.com/inconshreveable/mousetrap v1.1.0 // indirect
+)
+
+replace github.com/jlevesy/envconfig => ../
@@ -0,0 +1,103 @@
+package envconfig
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrorCode classifies errors returned by the loader. Codes are stable, they
+// can be relied upon by tooling instead of matching error messages.
+type ErrorCode string
+
+const (
+	// CodeMissingRequired is used when a required value is not defined
+	CodeMissingRequired ErrorCode = "ENV001"
+	// CodeParseFailure is used when a value can't be parsed into its field
+	CodeParseFailure ErrorCode = "ENV002"
+	// CodeUnsupportedType is used when a field has a type which can't be set
+	CodeUnsupportedType ErrorCode = "ENV003"
+	// CodeSourceFailure is used when a source fails to look a variable up
+	CodeSourceFailure ErrorCode = "ENV004"
+	// CodeMaxDepth is used when the configuration struct is too deep, or
+	// nested into itself
+	CodeMaxDepth ErrorCode = "ENV005"
+	// CodeInvalidConfig is used when the configuration can't be loaded at all,
+	// like a configuration passed by value
+	CodeInvalidConfig ErrorCode = "ENV006"
+	// CodeInvalidKey is used when a variable key can't index a slice or an array
+	CodeInvalidKey ErrorCode = "ENV007"
+	// CodeEnvelope is used when a compressed or encrypted value can't be opened
+	CodeEnvelope ErrorCode = "ENV008"
+	// CodeUnhealthySource is used when a health check fails
+	CodeUnhealthySource ErrorCode = "ENV009"
+	// CodeInvalidValue is used when a value is rejected by a guard, like
+	// WithMaxValueLength
+	CodeInvalidValue ErrorCode = "ENV010"
+	// CodeUnsettableField is used when values are found for fields which
+	// can't be set, like unexported fields
+	CodeUnsettableField ErrorCode = "ENV011"
+	// CodeUnknownVariable is used when variables defined under the prefix
+	// don't map to any field, see WithStrict
+	CodeUnknownVariable ErrorCode = "ENV012"
+	// CodeInvalidTag is used when the envconfig tag of a field can't be
+	// parsed, like a tag holding an unknown option
+	CodeInvalidTag ErrorCode = "ENV013"
+	// CodeCanceled is used when a load is canceled while sources are looked up
+	CodeCanceled ErrorCode = "ENV014"
+	// CodeTimeout is used when the deadline of a load, or the timeout of a
+	// source, is exceeded while sources are looked up
+	CodeTimeout ErrorCode = "ENV015"
+)
+
+// CodeOf returns the code of given error, or an empty code if err doesn't
+// come from the loader.
+func CodeOf(err error) ErrorCode {
+	var coded interface{ Code() ErrorCode }
+
+	if errors.As(err, &coded) {
+		return coded.Code()
+	}
+
+	return ""
+}
+
+// codedError associates a code to an error
+type codedError struct {
+	code ErrorCode
+	err  error
+}
+
+func withCode(code ErrorCode, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &codedError{code, err}
+}
+
+// sourceError codes an error returned by a source. Context errors get their
+// own code, they still match context.Canceled and context.DeadlineExceeded
+// using errors.Is.
+func sourceError(err error) error {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return withCode(CodeCanceled, err)
+	case errors.Is(err, context.DeadlineExceeded):
+		return withCode(CodeTimeout, err)
+	}
+
+	return withCode(CodeSourceFailure, err)
+}
+
+func (c *codedError) Error() string {
+	return c.err.Error()
+}
+
+func (c *codedError) Unwrap() error {
+	return c.err
+}
+
+// Code returns the code of the error
+func (c *codedError) Code() ErrorCode {
+	return c.code
+}
@@ -0,0 +1,138 @@
+package envconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// failingSource is a mapSource failing every lookup
+type failingSource struct {
+	mapSource
+}
+
+func (f failingSource) Lookup(key string) (string, bool, error) {
+	return "", false, errors.New("backend is down")
+}
+
+type unsupportedConfig struct {
+	Callback func()
+}
+
+type indexedConfig struct {
+	Values [2]int
+}
+
+func TestErrorCodes(t *testing.T) {
+	testCases := []struct {
+		Label       string
+		Loader      ConfigLoader
+		Config      interface{}
+		Expectation ErrorCode
+	}{
+		{
+			"WithParseFailure",
+			New("APP", "_", WithSource(mapSource{"APP_INT_VALUE": "foo"})),
+			&basicAppConfig{},
+			CodeParseFailure,
+		},
+		{
+			"WithUnsupportedType",
+			New("APP", "_", WithSource(mapSource{})),
+			&unsupportedConfig{},
+			CodeUnsupportedType,
+		},
+		{
+			"WithSourceFailure",
+			New("APP", "_", WithSource(failingSource{})),
+			&basicAppConfig{},
+			CodeSourceFailure,
+		},
+		{
+			"WithMaxDepth",
+			New("APP", "_", WithSource(mapSource{})),
+			&recursiveAppConfig{},
+			CodeMaxDepth,
+		},
+		{
+			"WithConfigPassedByValue",
+			New("APP", "_", WithSource(mapSource{})),
+			basicAppConfig{},
+			CodeInvalidConfig,
+		},
+		{
+			"WithInvalidKey",
+			New("APP", "_", WithSource(mapSource{"APP_VALUES_2": "1"})),
+			&indexedConfig{},
+			CodeInvalidKey,
+		},
+		{
+			"WithEnvelope",
+			New("APP", "_", WithCompressedValues(), WithSource(mapSource{"APP_STRING_VALUE": "gz64:foo"})),
+			&basicAppConfig{},
+			CodeEnvelope,
+		},
+		{
+			"WithInvalidTag",
+			New("APP", "_", WithSource(mapSource{})),
+			&struct {
+				Value string `envconfig:"groot"`
+			}{},
+			CodeInvalidTag,
+		},
+		{
+			"WithErrorFormatter",
+			New("APP", "_", WithErrorFormatter(LinesFormatter), WithSource(mapSource{"APP_INT_VALUE": "foo"})),
+			&basicAppConfig{},
+			CodeParseFailure,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			err := testCase.Loader.Load(testCase.Config)
+
+			if code := CodeOf(err); code != testCase.Expectation {
+				t.Logf("Expected code %s got %q, error [%v]", testCase.Expectation, code, err)
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestErrorCodeOfForeignError(t *testing.T) {
+	if code := CodeOf(errors.New("foo")); code != "" {
+		t.Logf("Expected no code, got %s", code)
+		t.Fail()
+	}
+}
+
+func TestSourceErrorCodes(t *testing.T) {
+	testCases := []struct {
+		Label       string
+		Err         error
+		Expectation ErrorCode
+	}{
+		{"WithCanceled", context.Canceled, CodeCanceled},
+		{"WithDeadlineExceeded", context.DeadlineExceeded, CodeTimeout},
+		{"WithWrappedDeadlineExceeded", fmt.Errorf("Get vault: %w", context.DeadlineExceeded), CodeTimeout},
+		{"WithOtherError", errors.New("backend is down"), CodeSourceFailure},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			err := sourceError(testCase.Err)
+
+			if code := CodeOf(err); code != testCase.Expectation {
+				t.Logf("Expected code %s got %q", testCase.Expectation, code)
+				t.Fail()
+			}
+
+			if !errors.Is(err, testCase.Err) {
+				t.Logf("Expected [%v] to match [%v]", err, testCase.Err)
+				t.Fail()
+			}
+		})
+	}
+}
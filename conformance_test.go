@@ -0,0 +1,137 @@
+package envconfig
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type conformanceLeaf struct {
+	Port int
+}
+
+// collectionKinds are the collections nested by the conformance matrix, along
+// with the keys their variables are named after.
+var collectionKinds = []struct {
+	Label string
+	Wrap  func(elemType reflect.Type) reflect.Type
+}{
+	{"map[string]", func(t reflect.Type) reflect.Type { return reflect.MapOf(reflect.TypeOf(""), t) }},
+	{"map[int]", func(t reflect.Type) reflect.Type { return reflect.MapOf(reflect.TypeOf(0), t) }},
+	{"[]", reflect.SliceOf},
+	{"[2]", func(t reflect.Type) reflect.Type { return reflect.ArrayOf(2, t) }},
+}
+
+var conformanceLeaves = []reflect.Type{
+	reflect.TypeOf(0),
+	reflect.TypeOf(new(int)),
+	reflect.TypeOf(conformanceLeaf{}),
+	reflect.TypeOf(&conformanceLeaf{}),
+}
+
+// conformanceTypes generates every nesting of two collections, optionally
+// pointed, of each leaf type.
+func conformanceTypes() []reflect.Type {
+	var res []reflect.Type
+
+	for _, leaf := range conformanceLeaves {
+		for _, inner := range collectionKinds {
+			for _, outer := range collectionKinds {
+				for _, pointed := range []bool{false, true} {
+					innerType := inner.Wrap(leaf)
+
+					if pointed {
+						innerType = reflect.PtrTo(innerType)
+					}
+
+					res = append(res, outer.Wrap(innerType), reflect.PtrTo(outer.Wrap(innerType)))
+				}
+			}
+		}
+	}
+
+	return res
+}
+
+func conformanceKeys(valType reflect.Type) []string {
+	switch {
+	case valType.Kind() == reflect.Map && valType.Key().Kind() == reflect.String:
+		return []string{"foo", "bar"}
+	case valType.Kind() == reflect.Map:
+		return []string{"3", "4"}
+	default:
+		return []string{"0", "1"}
+	}
+}
+
+// buildConformanceValue builds the expected value of given type, and defines
+// variables setting it into env.
+func buildConformanceValue(valType reflect.Type, valPath []string, env mapSource) reflect.Value {
+	res := reflect.New(valType).Elem()
+
+	switch valType.Kind() {
+	case reflect.Ptr:
+		res.Set(reflect.New(valType.Elem()))
+		res.Elem().Set(buildConformanceValue(valType.Elem(), valPath, env))
+	case reflect.Map:
+		res.Set(reflect.MakeMap(valType))
+
+		for _, key := range conformanceKeys(valType) {
+			keyValue := reflect.New(valType.Key()).Elem()
+
+			if valType.Key().Kind() == reflect.String {
+				keyValue.SetString(key)
+			} else {
+				index, _ := strconv.Atoi(key)
+				keyValue.SetInt(int64(index))
+			}
+
+			res.SetMapIndex(keyValue, buildConformanceValue(valType.Elem(), append(valPath, key), env))
+		}
+	case reflect.Slice, reflect.Array:
+		if valType.Kind() == reflect.Slice {
+			res.Set(reflect.MakeSlice(valType, 2, 2))
+		}
+
+		for i, key := range conformanceKeys(valType) {
+			res.Index(i).Set(buildConformanceValue(valType.Elem(), append(valPath, key), env))
+		}
+	case reflect.Struct:
+		res.Field(0).Set(buildConformanceValue(reflect.TypeOf(0), append(valPath, "PORT"), env))
+	default:
+		value := len(env) + 1
+		env[strings.ToUpper(strings.Join(valPath, "_"))] = strconv.Itoa(value)
+		res.SetInt(int64(value))
+	}
+
+	return res
+}
+
+func TestLoadNestedCollectionsConformance(t *testing.T) {
+	for _, valType := range conformanceTypes() {
+		t.Run(valType.String(), func(t *testing.T) {
+			configType := reflect.StructOf([]reflect.StructField{{Name: "Value", Type: valType}})
+			env := mapSource{}
+
+			expectation := reflect.New(configType)
+			expectation.Elem().Field(0).Set(buildConformanceValue(valType, []string{"APP", "VALUE"}, env))
+
+			// Map iteration order is random, load a few times to catch
+			// assignments depending on it.
+			for i := 0; i < 3; i++ {
+				result := reflect.New(configType)
+
+				if err := New("APP", "_", WithSource(env)).Load(result.Interface()); err != nil {
+					t.Logf("Wasn't expecting an error, got [%v]", err)
+					t.FailNow()
+				}
+
+				if !reflect.DeepEqual(result.Interface(), expectation.Interface()) {
+					t.Logf("Expected %+v got %+v", expectation.Elem(), result.Elem())
+					t.FailNow()
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+// Package cue exports the variables a configuration struct is loaded from as
+// a CUE definition, so manifests injecting them can be validated at commit
+// time.
+//
+// Environment values are strings, each variable is constrained to the strings
+// its field's setter accepts: APP_DATABASE_MAX_CONNS, filled by an int field,
+// must match an integer pattern.
+package cue
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/jlevesy/envconfig"
+)
+
+// Definition writes a CUE definition named name holding given variables, as
+// optional fields. The definition is left open, so it can be unified with
+// environments defining other variables.
+func Definition(w io.Writer, name string, vars []envconfig.VarInfo) error {
+	if !strings.HasPrefix(name, "#") {
+		name = "#" + name
+	}
+
+	if _, err := fmt.Fprintf(w, "%s: {\n", name); err != nil {
+		return err
+	}
+
+	for _, v := range vars {
+		_, err := fmt.Fprintf(
+			w,
+			"\t// Sets %s\n\t%s?: %s\n",
+			strings.Join(v.Path, "."),
+			v.Name,
+			constraint(v.Type),
+		)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := io.WriteString(w, "\t...\n}\n")
+
+	return err
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+const (
+	boolConstraint     = `"1" | "t" | "T" | "TRUE" | "true" | "True" | "0" | "f" | "F" | "FALSE" | "false" | "False"`
+	intConstraint      = `=~#"^[+-]?[0-9]+$"#`
+	uintConstraint     = `=~#"^\+?[0-9]+$"#`
+	floatConstraint    = `=~#"^[+-]?([0-9]+\.?[0-9]*|\.[0-9]+)([eE][+-]?[0-9]+)?$"#`
+	durationConstraint = `=~#"^([+-]?(([0-9]+\.?[0-9]*|\.[0-9]+)(ns|us|µs|ms|s|m|h))+|0)$"#`
+)
+
+func constraint(valType reflect.Type) string {
+	if valType == durationType {
+		return durationConstraint
+	}
+
+	switch valType.Kind() {
+	case reflect.Bool:
+		return boolConstraint
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return intConstraint
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return uintConstraint
+	case reflect.Float32, reflect.Float64:
+		return floatConstraint
+	default:
+		return "string"
+	}
+}
@@ -0,0 +1,90 @@
+package cue
+
+import (
+	"bytes"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jlevesy/envconfig"
+)
+
+type appConfig struct {
+	Database struct {
+		Host     string
+		MaxConns int
+	}
+	Timeout time.Duration
+	Debug   bool
+}
+
+func TestDefinition(t *testing.T) {
+	vars, err := envconfig.New("APP", "_").(envconfig.Describer).Describe(&appConfig{})
+
+	if err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	var buf bytes.Buffer
+
+	if err := Definition(&buf, "AppEnv", vars); err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	expectation := `#AppEnv: {
+	// Sets Database.Host
+	APP_DATABASE_HOST?: string
+	// Sets Database.MaxConns
+	APP_DATABASE_MAX_CONNS?: =~#"^[+-]?[0-9]+$"#
+	// Sets Timeout
+	APP_TIMEOUT?: =~#"^([+-]?(([0-9]+\.?[0-9]*|\.[0-9]+)(ns|us|µs|ms|s|m|h))+|0)$"#
+	// Sets Debug
+	APP_DEBUG?: "1" | "t" | "T" | "TRUE" | "true" | "True" | "0" | "f" | "F" | "FALSE" | "false" | "False"
+	...
+}
+`
+
+	if buf.String() != expectation {
+		t.Logf("Expected\n%s\ngot\n%s", expectation, buf.String())
+		t.Fail()
+	}
+}
+
+func TestConstraintPatterns(t *testing.T) {
+	testCases := []struct {
+		Label   string
+		Type    reflect.Type
+		Valid   []string
+		Invalid []string
+	}{
+		{"Int", reflect.TypeOf(0), []string{"0", "-12", "+3"}, []string{"", "1.5", "foo"}},
+		{"Uint", reflect.TypeOf(uint(0)), []string{"0", "+12"}, []string{"-1", "1e3"}},
+		{"Float", reflect.TypeOf(0.0), []string{"1", "-1.5", ".5", "1e-3"}, []string{"", ".", "1,5"}},
+		{"Duration", durationType, []string{"0", "5s", "1h30m", "-1.5ms", "10µs"}, []string{"", "5", "1d"}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			pattern := strings.TrimSuffix(strings.TrimPrefix(constraint(testCase.Type), `=~#"`), `"#`)
+			re := regexp.MustCompile(pattern)
+
+			for _, value := range testCase.Valid {
+				if !re.MatchString(value) {
+					t.Logf("Expected %q to match %s", value, pattern)
+					t.Fail()
+				}
+			}
+
+			for _, value := range testCase.Invalid {
+				if re.MatchString(value) {
+					t.Logf("Expected %q not to match %s", value, pattern)
+					t.Fail()
+				}
+			}
+		})
+	}
+}
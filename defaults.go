@@ -0,0 +1,95 @@
+package envconfig
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// WithDefaults registers a struct holding default values. It is deep copied
+// into the configuration struct before values are assigned, so every load
+// starts from the same baseline and defaults is never modified.
+// defaults is either a struct or a pointer to a struct, of the type of the
+// loaded configuration.
+func WithDefaults(defaults interface{}) Option {
+	return func(e *envConfig) {
+		e.defaults = reflect.Indirect(reflect.ValueOf(defaults))
+	}
+}
+
+// applyDefaults deep copies registered defaults into configVal
+func (e *envConfig) applyDefaults(configVal reflect.Value) error {
+	if !e.defaults.IsValid() {
+		return nil
+	}
+
+	if e.defaults.Type() != configVal.Type() {
+		return withCode(CodeInvalidConfig, fmt.Errorf(
+			"Defaults of type [%v] can't be applied to configuration of type [%v]",
+			e.defaults.Type(),
+			configVal.Type(),
+		))
+	}
+
+	deepCopy(configVal, e.defaults)
+
+	return nil
+}
+
+// deepCopy copies src into dst, allocating new pointers, slices and maps.
+// Unexported fields are copied as is.
+func deepCopy(dst, src reflect.Value) {
+	switch src.Kind() {
+	case reflect.Ptr:
+		if src.IsNil() {
+			dst.Set(reflect.Zero(src.Type()))
+			return
+		}
+
+		copied := reflect.New(src.Type().Elem())
+		deepCopy(copied.Elem(), src.Elem())
+		dst.Set(copied)
+	case reflect.Struct:
+		dst.Set(src)
+
+		for i := 0; i < src.NumField(); i++ {
+			if dst.Field(i).CanSet() {
+				deepCopy(dst.Field(i), src.Field(i))
+			}
+		}
+	case reflect.Slice:
+		if src.IsNil() {
+			dst.Set(reflect.Zero(src.Type()))
+			return
+		}
+
+		copied := reflect.MakeSlice(src.Type(), src.Len(), src.Len())
+
+		for i := 0; i < src.Len(); i++ {
+			deepCopy(copied.Index(i), src.Index(i))
+		}
+
+		dst.Set(copied)
+	case reflect.Array:
+		for i := 0; i < src.Len(); i++ {
+			deepCopy(dst.Index(i), src.Index(i))
+		}
+	case reflect.Map:
+		if src.IsNil() {
+			dst.Set(reflect.Zero(src.Type()))
+			return
+		}
+
+		copied := reflect.MakeMapWithSize(src.Type(), src.Len())
+		iter := src.MapRange()
+
+		for iter.Next() {
+			elem := reflect.New(src.Type().Elem()).Elem()
+			deepCopy(elem, iter.Value())
+			copied.SetMapIndex(iter.Key(), elem)
+		}
+
+		dst.Set(copied)
+	default:
+		dst.Set(src)
+	}
+}
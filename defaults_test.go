@@ -0,0 +1,72 @@
+package envconfig
+
+import (
+	"reflect"
+	"testing"
+)
+
+type defaultsConfig struct {
+	Host   string
+	Ports  []int
+	Labels map[string]string
+	TLS    *struct {
+		Enabled bool
+	}
+}
+
+func TestLoadWithDefaults(t *testing.T) {
+	defaults := defaultsConfig{
+		Host:   "localhost",
+		Ports:  []int{80, 443},
+		Labels: map[string]string{"team": "groot"},
+		TLS:    &struct{ Enabled bool }{true},
+	}
+
+	source := mapSource{
+		"APP_PORTS_0":     "8080",
+		"APP_LABELS_TEAM": "rocket",
+		"APP_TLS_ENABLED": "false",
+	}
+
+	for _, opt := range []Option{WithDefaults(defaults), WithDefaults(&defaults)} {
+		var result defaultsConfig
+
+		if err := New("APP", "_", opt, WithSource(source)).Load(&result); err != nil {
+			t.Logf("Wasn't expecting an error, got [%v]", err)
+			t.FailNow()
+		}
+
+		expectation := defaultsConfig{
+			Host:   "localhost",
+			Ports:  []int{8080, 443},
+			Labels: map[string]string{"team": "rocket"},
+			TLS:    &struct{ Enabled bool }{false},
+		}
+
+		if !reflect.DeepEqual(result, expectation) {
+			t.Logf("Expected %+v got %+v", expectation, result)
+			t.Fail()
+		}
+	}
+
+	untouched := defaultsConfig{
+		Host:   "localhost",
+		Ports:  []int{80, 443},
+		Labels: map[string]string{"team": "groot"},
+		TLS:    &struct{ Enabled bool }{true},
+	}
+
+	if !reflect.DeepEqual(defaults, untouched) {
+		t.Logf("Expected defaults to be left untouched, got %+v", defaults)
+		t.Fail()
+	}
+}
+
+func TestLoadWithDefaultsOfAnotherType(t *testing.T) {
+	err := New("APP", "_", WithDefaults(basicAppConfig{}), WithSource(mapSource{})).Load(&defaultsConfig{})
+
+	if CodeOf(err) != CodeInvalidConfig {
+		t.Logf("Expected an invalid config error, got [%v]", err)
+		t.Fail()
+	}
+}
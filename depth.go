@@ -0,0 +1,74 @@
+package envconfig
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// WithMaxDepth sets the maximum depth allowed for a configuration structure,
+// overriding the depth given to the constructor.
+func WithMaxDepth(maxDepth int) Option {
+	return func(e *envConfig) {
+		e.maxDepth = maxDepth
+	}
+}
+
+// depthError is returned when analysis reaches a path deeper than maxDepth
+type depthError struct {
+	path     path
+	maxDepth int
+}
+
+func (d *depthError) Error() string {
+	return fmt.Sprintf("Maxdepth of %d exceeded at [%s]", d.maxDepth, strings.Join(d.path, "."))
+}
+
+// Code returns CodeMaxDepth
+func (d *depthError) Code() ErrorCode {
+	return CodeMaxDepth
+}
+
+// explainDepthError turns a depthError into a diagnostic telling a type loop
+// from a legitimately deep structure, by following its path from configType.
+// Other errors are returned untouched.
+func explainDepthError(err error, configType reflect.Type) error {
+	d, ok := err.(*depthError)
+
+	if !ok {
+		return err
+	}
+
+	seen := map[reflect.Type]struct{}{}
+	valType := indirectedType(configType)
+
+	for _, key := range d.path {
+		if valType.Kind() == reflect.Struct {
+			if _, ok := seen[valType]; ok {
+				return withCode(CodeMaxDepth, fmt.Errorf(
+					"Type loop detected at [%s]: type %v is nested into itself",
+					strings.Join(d.path, "."),
+					valType,
+				))
+			}
+
+			seen[valType] = struct{}{}
+
+			field, ok := valType.FieldByName(key)
+
+			if !ok {
+				break
+			}
+
+			valType = indirectedType(field.Type)
+			continue
+		}
+
+		switch valType.Kind() {
+		case reflect.Map, reflect.Slice, reflect.Array:
+			valType = indirectedType(valType.Elem())
+		}
+	}
+
+	return withCode(CodeMaxDepth, fmt.Errorf("%v, use WithMaxDepth to allow deeper structures", d))
+}
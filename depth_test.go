@@ -0,0 +1,68 @@
+package envconfig
+
+import (
+	"strings"
+	"testing"
+)
+
+type deepConfig struct {
+	A struct {
+		B struct {
+			C struct {
+				Value string
+			}
+		}
+	}
+}
+
+func TestMaxDepthDiagnostics(t *testing.T) {
+	testCases := []struct {
+		Label       string
+		Config      interface{}
+		MaxDepth    int
+		Expectation string
+	}{
+		{
+			"WithTypeLoop",
+			&loopStructureA{},
+			DefaultDepth,
+			"Type loop detected at [Inner.Inner.Inner",
+		},
+		{
+			"WithDeepStructure",
+			&deepConfig{},
+			3,
+			"Maxdepth of 3 exceeded at [A.B.C.Value], use WithMaxDepth",
+		},
+		{
+			"WithRaisedMaxDepth",
+			&deepConfig{},
+			4,
+			"",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			subject := New("APP", "_", WithMaxDepth(testCase.MaxDepth))
+
+			for _, err := range []error{
+				subject.Load(testCase.Config),
+				func() error { _, err := subject.(Describer).Describe(testCase.Config); return err }(),
+			} {
+				if testCase.Expectation == "" {
+					if err != nil {
+						t.Logf("Wasn't expecting an error, got [%v]", err)
+						t.Fail()
+					}
+					continue
+				}
+
+				if err == nil || !strings.HasPrefix(err.Error(), testCase.Expectation) {
+					t.Logf("Expected an error starting with [%s], got [%v]", testCase.Expectation, err)
+					t.Fail()
+				}
+			}
+		})
+	}
+}
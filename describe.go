@@ -0,0 +1,181 @@
+package envconfig
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/fatih/camelcase"
+)
+
+const descTag = "desc"
+
+// VarInfo describes a variable a configuration struct is loaded from
+type VarInfo struct {
+	// Name of the variable, like APP_DATABASE_HOST
+	Name string
+	// Path of the field in the configuration struct, like [Database Host]
+	Path []string
+	// Type of the field, pointers indirected
+	Type reflect.Type
+	// Default is the value of the default option of the field's envconfig
+	// tag, HasDefault reports if it has one.
+	Default    string
+	HasDefault bool
+	// Required reports if the field is tagged with the required option
+	Required bool
+	// Secret reports if the field is tagged with secret:"true"
+	Secret bool
+	// Encoding is the value of the encoding option of the field's envconfig
+	// tag, like base64, the value is decoded from
+	Encoding string
+	// Description is the value of the field's desc tag
+	Description string
+}
+
+// FlagName names a command line flag after the variable's field path: the
+// flag matching [Database MaxConns] is database-max-conns.
+func (v VarInfo) FlagName() string {
+	words := []string{}
+
+	for _, name := range v.Path {
+		words = append(words, camelcase.Split(name)...)
+	}
+
+	return strings.ToLower(strings.Join(words, "-"))
+}
+
+// Describer is implemented by loaders able to list the variables a
+// configuration struct is loaded from, like loaders returned by New.
+type Describer interface {
+	Describe(config interface{}) ([]VarInfo, error)
+}
+
+// Describe lists the variables given configuration struct is loaded from,
+// without looking any source up.
+func (e *envConfig) Describe(config interface{}) ([]VarInfo, error) {
+	configType := reflect.TypeOf(config)
+
+	if configType == nil || configType.Kind() != reflect.Ptr {
+		return nil, errors.New("Passing by value isn't supported, please provide a pointer")
+	}
+
+	if configType.Elem().Kind() != reflect.Struct {
+		return []VarInfo{}, nil
+	}
+
+	if err := e.checkTags(configType.Elem()); err != nil {
+		return nil, err
+	}
+
+	res, err := e.describeStruct(configType.Elem(), path{}, e.envVarFromPath(path{}))
+
+	return res, explainDepthError(err, configType)
+}
+
+func (e *envConfig) describeStruct(configType reflect.Type, currentPath path, name string) ([]VarInfo, error) {
+	res := []VarInfo{}
+
+	for i := 0; i < configType.NumField(); i++ {
+		field := configType.Field(i)
+
+		if field.Type.Kind() == reflect.Ptr && indirectedType(field.Type) == configType {
+			return nil, fmt.Errorf("Recursive type detected %v in field %s", field.Type, field.Name)
+		}
+
+		if e.skipsField(field) {
+			continue
+		}
+
+		if field.Anonymous {
+			if field.Type.Kind() == reflect.Interface {
+				continue
+			}
+
+			vars, err := e.describeStruct(indirectedType(field.Type), currentPath, name)
+
+			if err != nil {
+				return nil, err
+			}
+
+			res = append(res, vars...)
+			continue
+		}
+
+		fieldPath := append(currentPath.clone(), field.Name)
+		fieldName, noexpand := e.fieldVariable(name, field)
+
+		if noexpand {
+			res = append(res, varInfo(fieldName, fieldPath, field.Type, field.Tag))
+			continue
+		}
+
+		vars, err := e.describeValue(field.Type, fieldPath, fieldName, field.Tag)
+
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, vars...)
+	}
+
+	return res, nil
+}
+
+// describeValue describes variables of a value of given type, tag being the
+// one of the field holding it.
+func (e *envConfig) describeValue(valType reflect.Type, fieldPath path, name string, tag reflect.StructTag) ([]VarInfo, error) {
+	if len(fieldPath) > e.maxDepth {
+		return nil, &depthError{fieldPath.clone(), e.maxDepth}
+	}
+
+	valType = indirectedType(valType)
+
+	// Types decoding themselves are assigned from a single variable
+	if e.decodesItself(valType) {
+		return []VarInfo{varInfo(name, fieldPath, valType, tag)}, nil
+	}
+
+	switch valType.Kind() {
+	case reflect.Array, reflect.Slice, reflect.Map:
+		return nil, nil
+	case reflect.Struct:
+		if isLazy(valType) {
+			return []VarInfo{varInfo(name, fieldPath, reflect.New(valType).Interface().(lazyBinder).lazyElemType(), tag)}, nil
+		}
+
+		// Structs having a setter are assigned from a single variable
+		if _, ok := e.setterOf(valType); ok {
+			return []VarInfo{varInfo(name, fieldPath, valType, tag)}, nil
+		}
+
+		return e.describeStruct(valType, fieldPath, name)
+	case reflect.Invalid, reflect.Chan, reflect.Func, reflect.Interface, reflect.UnsafePointer:
+		if e.skipUnsupported {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("type %s is not supported by EnvSource", valType.Name())
+	default:
+		return []VarInfo{varInfo(name, fieldPath, valType, tag)}, nil
+	}
+}
+
+func varInfo(name string, fieldPath path, valType reflect.Type, tag reflect.StructTag) VarInfo {
+	options := fieldOptions(reflect.StructField{Tag: tag})
+	defaultValue, hasDefault := options.Get(defaultOption)
+	encoding, _ := options.Get(encodingOption)
+
+	return VarInfo{
+		Name:        name,
+		Path:        fieldPath,
+		Type:        indirectedType(valType),
+		Default:     defaultValue,
+		HasDefault:  hasDefault,
+		Required:    options.Has(requiredOption),
+		Secret:      tag.Get(secretTag) == "true",
+		Encoding:    encoding,
+		Description: tag.Get(descTag),
+	}
+}
@@ -0,0 +1,117 @@
+package envconfig
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type describedConfig struct {
+	embeddedConfig
+	StringValue string
+	PtrToInt    *int
+	Date        time.Time
+	Nested      *basicAppConfig
+	Items       []string
+	Mapping     map[string]string
+	Raw         []string `envconfig:"noexpand"`
+	Ignored     string   `envconfig:"-"`
+}
+
+func TestDescribe(t *testing.T) {
+	result, err := New("APP", "_").(Describer).Describe(&describedConfig{})
+
+	if err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	expectation := []VarInfo{
+		{Name: "APP_EMBEDDED_VALUE", Path: []string{"EmbeddedValue"}, Type: reflect.TypeOf("")},
+		{Name: "APP_STRING_VALUE", Path: []string{"StringValue"}, Type: reflect.TypeOf("")},
+		{Name: "APP_PTR_TO_INT", Path: []string{"PtrToInt"}, Type: reflect.TypeOf(0)},
+		{Name: "APP_DATE", Path: []string{"Date"}, Type: reflect.TypeOf(time.Time{})},
+		{Name: "APP_NESTED_STRING_VALUE", Path: []string{"Nested", "StringValue"}, Type: reflect.TypeOf("")},
+		{Name: "APP_NESTED_INT_VALUE", Path: []string{"Nested", "IntValue"}, Type: reflect.TypeOf(0)},
+		{Name: "APP_NESTED_BOOL_VALUE", Path: []string{"Nested", "BoolValue"}, Type: reflect.TypeOf(true)},
+		{Name: "APP_RAW", Path: []string{"Raw"}, Type: reflect.TypeOf([]string{})},
+	}
+
+	if len(result) != len(expectation) {
+		t.Logf("Expected %d variables, got %v", len(expectation), result)
+		t.FailNow()
+	}
+
+	for i := range expectation {
+		if result[i].Name != expectation[i].Name ||
+			!reflect.DeepEqual([]string(result[i].Path), expectation[i].Path) ||
+			result[i].Type != expectation[i].Type {
+			t.Logf("Expected %v got %v", expectation[i], result[i])
+			t.Fail()
+		}
+	}
+}
+
+type describedTagsConfig struct {
+	Port     int    `envconfig:"default=8080" desc:"HTTP port to listen on"`
+	Token    string `envconfig:"required" secret:"true"`
+	Database struct {
+		Host string `desc:"Database host"`
+	}
+}
+
+func TestDescribeTags(t *testing.T) {
+	result, err := New("APP", "_").(Describer).Describe(&describedTagsConfig{})
+
+	if err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	expectation := []VarInfo{
+		{
+			Name:        "APP_PORT",
+			Path:        []string{"Port"},
+			Type:        reflect.TypeOf(0),
+			Default:     "8080",
+			HasDefault:  true,
+			Description: "HTTP port to listen on",
+		},
+		{Name: "APP_TOKEN", Path: []string{"Token"}, Type: reflect.TypeOf(""), Required: true, Secret: true},
+		{Name: "APP_DATABASE_HOST", Path: []string{"Database", "Host"}, Type: reflect.TypeOf(""), Description: "Database host"},
+	}
+
+	if !reflect.DeepEqual(result, expectation) {
+		t.Logf("Expected %+v got %+v", expectation, result)
+		t.Fail()
+	}
+}
+
+func TestDescribeInvalidConfig(t *testing.T) {
+	testCases := []struct {
+		Label  string
+		Config interface{}
+	}{
+		{"ByValue", describedConfig{}},
+		{"WithRecursiveType", &recursiveAppConfig{}},
+		{"WithTypeLoop", &loopStructureA{}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			if _, err := New("APP", "_").(Describer).Describe(testCase.Config); err == nil {
+				t.Log("Expected an error, got nothing")
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestVarInfoFlagName(t *testing.T) {
+	v := VarInfo{Path: []string{"Database", "MaxIdleConns"}}
+
+	if name := v.FlagName(); name != "database-max-idle-conns" {
+		t.Logf("Expected database-max-idle-conns, got %s", name)
+		t.Fail()
+	}
+}
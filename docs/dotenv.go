@@ -0,0 +1,85 @@
+package docs
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jlevesy/envconfig"
+)
+
+// DotenvTemplate writes a commented .env template, like a .env.example file,
+// defining given variables. Each variable is preceded by a comment holding its
+// description and type. Variables having a default are set to it, required
+// ones are left empty for the developer to fill, and optional ones are
+// commented out. Defaults of secret variables are left out.
+func DotenvTemplate(w io.Writer, vars []envconfig.VarInfo) error {
+	for i, v := range vars {
+		if i > 0 {
+			if _, err := io.WriteString(w, "\n"); err != nil {
+				return err
+			}
+		}
+
+		if _, err := fmt.Fprintf(w, "# %s\n", comment(v)); err != nil {
+			return err
+		}
+
+		var line string
+
+		switch {
+		case v.HasDefault && !v.Secret:
+			line = v.Name + "=" + quoteDotenv(v.Default)
+		case v.Required || v.HasDefault:
+			line = v.Name + "="
+		default:
+			line = "# " + v.Name + "="
+		}
+
+		if _, err := io.WriteString(w, line+"\n"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// comment describes given variable on a single line
+func comment(v envconfig.VarInfo) string {
+	details := []string{v.Type.String()}
+
+	if v.Required {
+		details = append(details, "required")
+	}
+
+	if v.Secret {
+		details = append(details, "secret")
+	}
+
+	res := "(" + strings.Join(details, ", ") + ")"
+
+	if description := strings.Join(strings.Fields(v.Description), " "); description != "" {
+		res = description + " " + res
+	}
+
+	return res
+}
+
+// quoteDotenv quotes given value if it can't be written as is in a .env file
+func quoteDotenv(value string) string {
+	if !strings.ContainsAny(value, " \t\r\n#$'\"\\") {
+		return value
+	}
+
+	if !strings.ContainsAny(value, "'\r\n") {
+		return "'" + value + "'"
+	}
+
+	return `"` + strings.NewReplacer(
+		`\`, `\\`,
+		`"`, `\"`,
+		"$", `\$`,
+		"\n", `\n`,
+		"\r", `\r`,
+	).Replace(value) + `"`
+}
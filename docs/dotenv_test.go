@@ -0,0 +1,72 @@
+package docs
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jlevesy/envconfig"
+	"github.com/jlevesy/envconfig/sources"
+)
+
+type templateConfig struct {
+	Host    string `envconfig:"required" desc:"Database host"`
+	Port    int    `envconfig:"default=5432"`
+	Greet   string `envconfig:"default=Hello, $USER's world"`
+	Token   string `envconfig:"default=changeme" secret:"true"`
+	Verbose bool
+}
+
+func TestDotenvTemplate(t *testing.T) {
+	vars, err := envconfig.New("APP", "_").(envconfig.Describer).Describe(&templateConfig{})
+
+	if err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	var buf bytes.Buffer
+
+	if err := DotenvTemplate(&buf, vars); err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	expectation := "# Database host (string, required)\n" +
+		"APP_HOST=\n" +
+		"\n" +
+		"# (int)\n" +
+		"APP_PORT=5432\n" +
+		"\n" +
+		"# (string)\n" +
+		"APP_GREET=\"Hello, \\$USER's world\"\n" +
+		"\n" +
+		"# (string, secret)\n" +
+		"APP_TOKEN=\n" +
+		"\n" +
+		"# (bool)\n" +
+		"# APP_VERBOSE=\n"
+
+	if result := buf.String(); result != expectation {
+		t.Logf("Expected:\n%s\ngot:\n%s", expectation, result)
+		t.FailNow()
+	}
+
+	// The template must parse back
+	source, err := sources.Dotenv(&buf)
+
+	if err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	for name, expected := range map[string]string{
+		"APP_PORT":  "5432",
+		"APP_GREET": "Hello, $USER's world",
+		"APP_HOST":  "",
+	} {
+		if value, _, _ := source.Lookup(name); value != expected {
+			t.Logf("Expected %s to be %q, got %q", name, expected, value)
+			t.Fail()
+		}
+	}
+}
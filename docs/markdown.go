@@ -0,0 +1,66 @@
+// Package docs renders documentation of the variables a configuration struct
+// is loaded from, so it can be generated instead of maintained by hand.
+package docs
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jlevesy/envconfig"
+)
+
+// Markdown writes a Markdown table listing given variables along with their
+// type, default value, whether they are required, and description. Defaults
+// of secret variables are masked.
+func Markdown(w io.Writer, vars []envconfig.VarInfo) error {
+	if _, err := io.WriteString(w, "| Variable | Type | Default | Required | Description |\n"); err != nil {
+		return err
+	}
+
+	if _, err := io.WriteString(w, "|----------|------|---------|----------|-------------|\n"); err != nil {
+		return err
+	}
+
+	for _, v := range vars {
+		_, err := fmt.Fprintf(
+			w,
+			"| `%s` | `%v` | %s | %s | %s |\n",
+			v.Name,
+			v.Type,
+			defaultCell(v),
+			requiredCell(v),
+			escape(v.Description),
+		)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func defaultCell(v envconfig.VarInfo) string {
+	switch {
+	case !v.HasDefault:
+		return ""
+	case v.Secret:
+		return "*redacted*"
+	default:
+		return "`" + strings.Replace(v.Default, "`", "'", -1) + "`"
+	}
+}
+
+func requiredCell(v envconfig.VarInfo) string {
+	if v.Required {
+		return "yes"
+	}
+
+	return "no"
+}
+
+// escape makes s fit into a table cell
+func escape(s string) string {
+	return strings.Replace(strings.Join(strings.Fields(s), " "), "|", `\|`, -1)
+}
@@ -0,0 +1,44 @@
+package docs
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/jlevesy/envconfig"
+)
+
+type appConfig struct {
+	Database struct {
+		Host string `envconfig:"required" desc:"Database host, like db.local|db.remote"`
+	}
+	Timeout time.Duration `envconfig:"default=5s" desc:"Request timeout"`
+	Token   string        `envconfig:"default=changeme" secret:"true"`
+}
+
+func TestMarkdown(t *testing.T) {
+	vars, err := envconfig.New("APP", "_").(envconfig.Describer).Describe(&appConfig{})
+
+	if err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	var buf bytes.Buffer
+
+	if err := Markdown(&buf, vars); err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	expectation := "| Variable | Type | Default | Required | Description |\n" +
+		"|----------|------|---------|----------|-------------|\n" +
+		"| `APP_DATABASE_HOST` | `string` |  | yes | Database host, like db.local\\|db.remote |\n" +
+		"| `APP_TIMEOUT` | `time.Duration` | `5s` | no | Request timeout |\n" +
+		"| `APP_TOKEN` | `string` | *redacted* | no |  |\n"
+
+	if result := buf.String(); result != expectation {
+		t.Logf("Expected:\n%s\ngot:\n%s", expectation, result)
+		t.Fail()
+	}
+}
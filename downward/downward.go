@@ -0,0 +1,130 @@
+// Package downward binds Kubernetes Downward API fields into a configuration
+// struct, along with other values, instead of reading them using os.Getenv.
+//
+//	type AppConfig struct {
+//		Pod downward.Pod
+//	}
+//
+//	loader := envconfig.New(
+//		"APP",
+//		"_",
+//		envconfig.WithSource(downward.Source("APP_POD", "_", "/etc/podinfo")),
+//		envconfig.WithSource(sources.Env()),
+//	)
+//
+// Fields are exposed using conventional environment variables, like POD_NAME,
+// or files of a downward API volume, like /etc/podinfo/name.
+package downward
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jlevesy/envconfig/sources"
+)
+
+// Pod holds the Downward API fields of the running pod and container
+type Pod struct {
+	Name           string
+	Namespace      string
+	IP             string
+	NodeName       string
+	ServiceAccount string
+	// CPULimit and CPURequest are in units of the divisor of the resource
+	// field ref, cores by default.
+	CPULimit   int64
+	CPURequest int64
+	// MemoryLimit and MemoryRequest are in bytes by default
+	MemoryLimit   int64
+	MemoryRequest int64
+}
+
+// field describes where the value of a Pod field is exposed
+type field struct {
+	// key is the variable name of the field, without prefix
+	key string
+	// env is the conventional environment variable exposing the field
+	env string
+	// file is the conventional file name exposing the field in a volume
+	file string
+}
+
+var fields = []field{
+	{"NAME", "POD_NAME", "name"},
+	{"NAMESPACE", "POD_NAMESPACE", "namespace"},
+	{"IP", "POD_IP", "ip"},
+	{"NODE_NAME", "NODE_NAME", "node_name"},
+	{"SERVICE_ACCOUNT", "POD_SERVICE_ACCOUNT", "service_account"},
+	{"CPU_LIMIT", "CPU_LIMIT", "cpu_limit"},
+	{"CPU_REQUEST", "CPU_REQUEST", "cpu_request"},
+	{"MEMORY_LIMIT", "MEMORY_LIMIT", "memory_limit"},
+	{"MEMORY_REQUEST", "MEMORY_REQUEST", "memory_request"},
+}
+
+// Source returns a Source serving the fields of a Pod loaded using given
+// variable prefix and separator, eg: APP_POD_NAME for a Pod field of an
+// AppConfig loaded with the APP prefix.
+// Each field is read from its conventional environment variable, then from
+// its file in given directory, if not empty.
+func Source(prefix, separator, dir string) sources.Source {
+	res := map[string]field{}
+
+	for _, f := range fields {
+		res[prefix+separator+strings.ReplaceAll(f.key, "_", separator)] = f
+	}
+
+	return &source{res, dir}
+}
+
+type source struct {
+	fields map[string]field
+	dir    string
+}
+
+func (s *source) Lookup(key string) (string, bool, error) {
+	f, ok := s.fields[key]
+
+	if !ok {
+		return "", false, nil
+	}
+
+	if value, ok := os.LookupEnv(f.env); ok {
+		return value, true, nil
+	}
+
+	if s.dir == "" {
+		return "", false, nil
+	}
+
+	content, err := os.ReadFile(filepath.Join(s.dir, f.file))
+
+	if errors.Is(err, os.ErrNotExist) {
+		return "", false, nil
+	}
+
+	if err != nil {
+		return "", false, err
+	}
+
+	return strings.TrimSpace(string(content)), true, nil
+}
+
+func (s *source) Keys(prefix string) ([]string, error) {
+	res := []string{}
+
+	for key := range s.fields {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+
+		if _, ok, err := s.Lookup(key); err != nil {
+			return nil, err
+		} else if ok {
+			res = append(res, key)
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,67 @@
+package downward
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jlevesy/envconfig"
+)
+
+type appConfig struct {
+	Pod Pod
+}
+
+func TestLoadPod(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"name":         "groot-5d8f\n",
+		"namespace":    "guardians",
+		"memory_limit": "536870912\n",
+	}
+
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+			t.Logf("Wasn't expecting an error, got [%v]", err)
+			t.FailNow()
+		}
+	}
+
+	t.Setenv("POD_NAMESPACE", "galaxy")
+	t.Setenv("NODE_NAME", "node-1")
+
+	var result appConfig
+
+	err := envconfig.New("APP", "_", envconfig.WithSource(Source("APP_POD", "_", dir))).Load(&result)
+
+	if err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	expectation := Pod{
+		Name:        "groot-5d8f",
+		Namespace:   "galaxy",
+		NodeName:    "node-1",
+		MemoryLimit: 536870912,
+	}
+
+	if result.Pod != expectation {
+		t.Logf("Expected %+v, got %+v", expectation, result.Pod)
+		t.Fail()
+	}
+}
+
+func TestLoadPodInvalidValue(t *testing.T) {
+	t.Setenv("CPU_LIMIT", "500m")
+
+	var result appConfig
+
+	err := envconfig.New("APP", "_", envconfig.WithSource(Source("APP_POD", "_", ""))).Load(&result)
+
+	if code := envconfig.CodeOf(err); code != envconfig.CodeParseFailure {
+		t.Logf("Expected a parse failure, got [%v]", err)
+		t.Fail()
+	}
+}
@@ -0,0 +1,213 @@
+package envconfig
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// Dumper is implemented by loaders able to turn a configuration struct back
+// into variables, like loaders returned by New.
+type Dumper interface {
+	Dump(config interface{}) (map[string]string, error)
+}
+
+// Dump returns the variables given configuration struct would be loaded from,
+// along with their values. Values implementing encoding.TextMarshaler,
+// fmt.Stringer or encoding.BinaryMarshaler are formatted using them, durations
+// as "5s" and timestamps as RFC3339, or using the layout they are tagged
+// with. Byte slices tagged with an encoding are encoded back, so the result
+// can be fed back through Load.
+// Nil pointers and Lazy fields are left out, fields tagged with envconfig,
+// other than noexpand, too.
+func (e *envConfig) Dump(config interface{}) (map[string]string, error) {
+	configVal := reflect.ValueOf(config)
+
+	if configVal.Kind() != reflect.Ptr {
+		return nil, withCode(CodeInvalidConfig, errors.New("Passing by value isn't supported, please provide a pointer"))
+	}
+
+	if err := e.checkTags(configVal.Type().Elem()); err != nil {
+		return nil, err
+	}
+
+	res := map[string]string{}
+
+	if err := e.dumpValue(configVal.Elem(), path{}, e.envVarFromPath(path{}), false, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (e *envConfig) dumpValue(val reflect.Value, valPath path, name string, leaf bool, res map[string]string) error {
+	if len(valPath) > e.maxDepth {
+		return &depthError{valPath.clone(), e.maxDepth}
+	}
+
+	for val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return nil
+		}
+
+		val = val.Elem()
+	}
+
+	if _, ok := e.setterOf(val.Type()); ok || leaf {
+		return e.dumpLeaf(val, name, res)
+	}
+
+	switch val.Kind() {
+	case reflect.Struct:
+		if isLazy(val.Type()) {
+			return nil
+		}
+
+		return e.dumpStruct(val, valPath, name, res)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < val.Len(); i++ {
+			index := strconv.Itoa(i)
+
+			if err := e.dumpValue(val.Index(i), append(valPath.clone(), index), e.childVariable(name, index), false, res); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case reflect.Map:
+		iter := val.MapRange()
+
+		for iter.Next() {
+			key, err := formatValue(iter.Key())
+
+			if err != nil {
+				return err
+			}
+
+			if err := e.dumpValue(iter.Value(), append(valPath.clone(), key), e.childVariable(name, key), false, res); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case reflect.Invalid, reflect.Chan, reflect.Func, reflect.Interface, reflect.UnsafePointer:
+		if e.skipUnsupported {
+			return nil
+		}
+
+		return &unsupportedTypeError{val.Type()}
+	default:
+		return e.dumpLeaf(val, name, res)
+	}
+}
+
+func (e *envConfig) dumpStruct(val reflect.Value, valPath path, name string, res map[string]string) error {
+	valType := val.Type()
+
+	for i := 0; i < valType.NumField(); i++ {
+		field := valType.Field(i)
+
+		// Unexported fields can't be loaded, unless they embed a struct
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+
+		if e.skipsField(field) {
+			continue
+		}
+
+		fieldPath, fieldName := valPath, name
+		options := fieldOptions(field)
+		noexpand := options.Whole()
+
+		if !field.Anonymous {
+			fieldPath = append(valPath.clone(), field.Name)
+			fieldName, noexpand = e.fieldVariable(name, field)
+		}
+
+		if fieldVal := reflect.Indirect(val.Field(i)); isEncodedBytes(field, options) && fieldVal.IsValid() {
+			res[fieldName] = encodeBytes(fieldVal.Bytes(), options)
+			continue
+		}
+
+		if fieldVal := reflect.Indirect(val.Field(i)); options.Has(layoutOption) && fieldVal.IsValid() && fieldVal.Type() == timeType {
+			res[fieldName] = fieldVal.Interface().(time.Time).Format(options[layoutOption])
+			continue
+		}
+
+		if err := e.dumpValue(val.Field(i), fieldPath, fieldName, noexpand, res); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *envConfig) dumpLeaf(val reflect.Value, name string, res map[string]string) error {
+	value, err := formatValue(val)
+
+	if err != nil {
+		return withCode(CodeUnsupportedType, fmt.Errorf("Failed to dump [%s]: %v", name, err))
+	}
+
+	res[name] = value
+
+	return nil
+}
+
+// formatValue formats given value so it can be parsed back by its setter
+func formatValue(val reflect.Value) (string, error) {
+	if val.CanInterface() {
+		if m, ok := val.Interface().(encoding.TextMarshaler); ok {
+			text, err := m.MarshalText()
+			return string(text), err
+		}
+
+		if val.CanAddr() {
+			if m, ok := val.Addr().Interface().(encoding.TextMarshaler); ok {
+				text, err := m.MarshalText()
+				return string(text), err
+			}
+		}
+
+		if s, ok := val.Interface().(fmt.Stringer); ok {
+			return s.String(), nil
+		}
+
+		if val.CanAddr() {
+			if s, ok := val.Addr().Interface().(fmt.Stringer); ok {
+				return s.String(), nil
+			}
+		}
+
+		if m, ok := val.Interface().(encoding.BinaryMarshaler); ok {
+			data, err := m.MarshalBinary()
+			return string(data), err
+		}
+
+		if val.CanAddr() {
+			if m, ok := val.Addr().Interface().(encoding.BinaryMarshaler); ok {
+				data, err := m.MarshalBinary()
+				return string(data), err
+			}
+		}
+	}
+
+	switch val.Kind() {
+	case reflect.String:
+		return val.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(val.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(val.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(val.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(val.Float(), 'g', -1, val.Type().Bits()), nil
+	}
+
+	return "", fmt.Errorf("type %v doesn't implement encoding.TextMarshaler", val.Type())
+}
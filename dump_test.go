@@ -0,0 +1,143 @@
+package envconfig
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jlevesy/envconfig/setter"
+)
+
+type hostPort struct {
+	Host string
+	Port string
+}
+
+func (h hostPort) MarshalText() ([]byte, error) {
+	return []byte(h.Host + ":" + h.Port), nil
+}
+
+type dumpedConfig struct {
+	embeddedConfig
+	StringValue string
+	Timeout     time.Duration
+	Date        time.Time
+	Ratio       float64
+	Count       uint8
+	PtrToInt    *int
+	NilPtr      *int
+	Server      hostPort
+	Nested      basicAppConfig
+	Items       []string
+	Mapping     map[string]time.Duration
+	Address     hostPort `envconfig:"noexpand"`
+	Ignored     string   `envconfig:"-"`
+}
+
+func TestDumpRoundTrip(t *testing.T) {
+	setters := setter.LoadBasicTypes()
+	setters[reflect.TypeOf(hostPort{})] = setter.SetterFunc(func(strValue string, val reflect.Value) error {
+		parts := strings.SplitN(strValue, ":", 2)
+
+		if len(parts) != 2 {
+			return errors.New("Invalid host port")
+		}
+
+		val.Set(reflect.ValueOf(hostPort{parts[0], parts[1]}))
+
+		return nil
+	})
+
+	intValue := 42
+
+	config := dumpedConfig{
+		embeddedConfig: embeddedConfig{EmbeddedValue: "embedded"},
+		StringValue:    "foo",
+		Timeout:        5 * time.Second,
+		Date:           time.Date(2019, time.March, 4, 10, 30, 0, 500, time.UTC),
+		Ratio:          0.1,
+		Count:          255,
+		PtrToInt:       &intValue,
+		Server:         hostPort{"localhost", "8080"},
+		Nested:         basicAppConfig{StringValue: "bar", IntValue: 12, BoolValue: true},
+		Items:          []string{"a", "b"},
+		Mapping:        map[string]time.Duration{"read": time.Minute},
+		Address:        hostPort{"example.com", "443"},
+	}
+
+	result, err := NewWithSettersAndDepth("APP", "_", setters, DefaultDepth).(Dumper).Dump(&config)
+
+	if err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	expectation := map[string]string{
+		"APP_EMBEDDED_VALUE":      "embedded",
+		"APP_STRING_VALUE":        "foo",
+		"APP_TIMEOUT":             "5s",
+		"APP_DATE":                "2019-03-04T10:30:00.0000005Z",
+		"APP_RATIO":               "0.1",
+		"APP_COUNT":               "255",
+		"APP_PTR_TO_INT":          "42",
+		"APP_SERVER":              "localhost:8080",
+		"APP_NESTED_STRING_VALUE": "bar",
+		"APP_NESTED_INT_VALUE":    "12",
+		"APP_NESTED_BOOL_VALUE":   "true",
+		"APP_ITEMS_0":             "a",
+		"APP_ITEMS_1":             "b",
+		"APP_MAPPING_READ":        "1m0s",
+		"APP_ADDRESS":             "example.com:443",
+	}
+
+	if !reflect.DeepEqual(result, expectation) {
+		t.Logf("Expected %v, got %v", expectation, result)
+		t.FailNow()
+	}
+
+	var loaded dumpedConfig
+
+	err = NewWithSettersAndDepth("APP", "_", setters, DefaultDepth, WithSource(mapSource(result))).Load(&loaded)
+
+	if err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	if !reflect.DeepEqual(loaded, config) {
+		t.Logf("Expected %v, got %v", config, loaded)
+		t.Fail()
+	}
+}
+
+func TestDumpErrors(t *testing.T) {
+	testCases := []struct {
+		Label  string
+		Config interface{}
+		Code   ErrorCode
+	}{
+		{"ByValue", basicAppConfig{}, CodeInvalidConfig},
+		{"Unsupported", &struct{ Callback func() }{}, CodeUnsupportedType},
+		{"NotMarshalable", &struct {
+			Pair struct{ A, B string } `envconfig:"noexpand"`
+		}{}, CodeUnsupportedType},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			_, err := New("APP", "_").(Dumper).Dump(testCase.Config)
+
+			if err == nil {
+				t.Log("Expected an error, got nothing")
+				t.FailNow()
+			}
+
+			if code := CodeOf(err); code != testCase.Code {
+				t.Logf("Expected code %s, got %s (%v)", testCase.Code, code, err)
+				t.Fail()
+			}
+		})
+	}
+}
@@ -1,16 +1,19 @@
 package envconfig
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"os"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/jlevesy/envconfig/internal/tags"
 	"github.com/jlevesy/envconfig/setter"
-
-	"github.com/fatih/camelcase"
+	"github.com/jlevesy/envconfig/sources"
 )
 
 const (
@@ -18,7 +21,8 @@ const (
 	DefaultDepth = 10
 
 	envConfigTag = "envconfig"
-	noExpand     = "noexpand"
+	sourceTag    = "source"
+	secretTag    = "secret"
 )
 
 // ConfigLoader interface is an object that can be used to Loader
@@ -27,6 +31,23 @@ type ConfigLoader interface {
 	Load(config interface{}) error
 }
 
+// ContextLoader is implemented by loaders able to stop looking values up when
+// a context is done, like loaders returned by New.
+type ContextLoader interface {
+	LoadContext(ctx context.Context, config interface{}) error
+}
+
+// Callers assert loaders returned by New to these interfaces
+var (
+	_ ContextLoader  = (*envConfig)(nil)
+	_ Watcher        = (*envConfig)(nil)
+	_ HealthChecker  = (*envConfig)(nil)
+	_ Describer      = (*envConfig)(nil)
+	_ Dumper         = (*envConfig)(nil)
+	_ SetterRegistry = (*envConfig)(nil)
+	_ UsagePrinter   = (*envConfig)(nil)
+)
+
 // envConfig implements ConfigLoader
 // Enables to populate configuration struct with informations extracted from
 // process's environment variables.
@@ -35,39 +56,272 @@ type envConfig struct {
 	prefix    string
 	separator string
 	setters   map[reflect.Type]setter.Setter
+	settersMu sync.RWMutex
 	maxDepth  int
+	metrics   *loadMetrics
+	tracer    Tracer
+	layers    []layer
+
+	unprefixedFallback bool
+	compressedValues   bool
+	decryptors         []decryptor
+	rotationCallbacks  []rotationCallback
+	reportCallbacks    []func(*Report)
+	skipUnsupported    bool
+	errorFormatter     ErrorFormatter
+	maxValueLength     int
+	rejectControlChars bool
+	defaults           reflect.Value
+	mapTombstone       string
+	audit              *auditor
+	openInclude        func(path string) (sources.Source, error)
+	namedSetters       map[string]setter.Setter
+	allErrors          bool
+	location           *time.Location
+	timeLayouts        []string
+	relaxedBools       bool
+	strict             bool
+	sparsePolicy       SparsePolicy
+	skipUnexported     bool
+	structVariables    bool
 }
 
+// Option customizes the behaviour of an envConfig
+type Option func(*envConfig)
+
 // NewWithSettersAndDepth constructs a new instance of envConfig
 // It allows to setup prefix, separator supported setters and maximum structure depth.
-func NewWithSettersAndDepth(prefix, separator string, setters map[reflect.Type]setter.Setter, maxDepth int) ConfigLoader {
-	return &envConfig{prefix, separator, setters, maxDepth}
+func NewWithSettersAndDepth(prefix, separator string, setters map[reflect.Type]setter.Setter, maxDepth int, opts ...Option) ConfigLoader {
+	e := &envConfig{
+		prefix:    prefix,
+		separator: separator,
+		setters:   setters,
+		maxDepth:  maxDepth,
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e
 }
 
 // New returns a new instance of envConfig with given prefix and separator.
-func New(prefix, separator string) ConfigLoader {
-	return NewWithSettersAndDepth(prefix, separator, setter.LoadBasicTypes(), DefaultDepth)
+func New(prefix, separator string, opts ...Option) ConfigLoader {
+	return NewWithSettersAndDepth(prefix, separator, setter.LoadBasicTypes(), DefaultDepth, opts...)
+}
+
+// WithUnprefixedFallback makes the loader look a variable up without prefix when
+// the prefixed variable is not defined: if APP_PORT is not defined, PORT is used.
+// It is useful for standard variables imposed by platforms like HTTP_PROXY,
+// HOSTNAME or PORT. Only leaf values fall back, whole structs aren't read from
+// unprefixed variables. Keys of maps, slices and arrays are always discovered
+// using the prefix.
+func WithUnprefixedFallback() Option {
+	return func(e *envConfig) {
+		e.unprefixedFallback = true
+	}
 }
 
 // Load loads environment data into given configuration structure
 func (e *envConfig) Load(config interface{}) error {
+	return e.LoadContext(context.Background(), config)
+}
+
+// LoadContext loads environment data into given configuration structure.
+// Sources lookups are interrupted when ctx is done.
+func (e *envConfig) LoadContext(ctx context.Context, config interface{}) (err error) {
+	ctx, span := e.startSpan(ctx, "envconfig.Load")
+	defer func() { span.End(err) }()
+
+	span.SetAttribute("envconfig.prefix", e.prefix)
 
 	configVal := reflect.ValueOf(config)
 
 	if configVal.Kind() != reflect.Ptr {
-		return errors.New("Passing by value isn't supported, please provide a pointer")
+		return withCode(CodeInvalidConfig, errors.New("Passing by value isn't supported, please provide a pointer"))
 	}
 
 	configVal = configVal.Elem()
 	configType := configVal.Type()
 
-	values, err := e.analyzeStruct(configType, []string{})
+	state := newLoadState()
+	err = e.checkTags(configType)
+
+	if err == nil {
+		err = e.applyDefaults(configVal)
+	}
+
+	layers := e.sourceLayers()
+
+	if err == nil {
+		var included []layer
+		included, err = e.includedLayers(ctx)
+		layers = append(included, layers...)
+	}
+
+	var loadErrs []error
+
+	for _, l := range layers {
+		if err != nil {
+			break
+		}
+
+		l.warnings = &state.warnings
+		err = e.loadLayer(ctx, l, configVal, configType, state)
+
+		// Errors caused by values don't prevent reading next layers
+		if e.allErrors && isLoadErrors(err) {
+			loadErrs = append(loadErrs, err)
+			err = nil
+		}
+	}
+
+	if err == nil {
+		err = joinErrors(loadErrs)
+	}
+
+	if err == nil && e.sparsePolicy == RejectGaps {
+		err = e.checkGaps(configVal, configType, state.assigned)
+	}
+
+	if err == nil && configType.Kind() == reflect.Struct {
+		err = e.checkRequired(ctx, configVal, configVal, path{}, state.assigned)
+	}
+
+	if err == nil && configType.Kind() == reflect.Struct {
+		err = e.bindLazyFields(configVal, path{}, e.envVarFromPath(path{}))
+	}
+
+	if err == nil && (e.strict || len(e.reportCallbacks) > 0) {
+		err = e.checkUnused(ctx, layers, configType, state)
+	}
+
+	e.metrics.record(configType, state.stats.Matched, err)
+
+	if err == nil {
+		e.notifyReport(configVal, state)
+	}
+
+	e.notifyAudit(configVal, state, err)
+
+	return e.formatErrors(err)
+}
+
+// loadLayer looks up values defined in given layer's source, then assigns them
+// according to the layer's merge policy. Assigned values and statistics are
+// recorded into state.
+func (e *envConfig) loadLayer(ctx context.Context, l layer, configVal reflect.Value, configType reflect.Type, state *loadState) error {
+	lookupCtx, lookupSpan := e.startSpan(ctx, "envconfig.lookup")
+	lookupSpan.SetAttribute("envconfig.source", fmt.Sprintf("%T", l.source))
+
+	if l.timeout > 0 {
+		var cancel context.CancelFunc
+		lookupCtx, cancel = context.WithTimeout(lookupCtx, l.timeout)
+		defer cancel()
+	}
+
+	bound := l
+	source := sources.Snapshot(l.source)
+	bound.source = &countingSource{sources.WithContext(lookupCtx, source), &state.stats.Scanned}
+
+	if _, ok := source.(sources.BulkSource); ok {
+		prefetched, err := sources.Prefetch(lookupCtx, source, e.prefetchKeys(configType))
+
+		if err != nil {
+			err = sourceError(err)
+			lookupSpan.End(err)
+			return err
+		}
+
+		bound.source = &countingSource{sources.WithContext(lookupCtx, prefetched), &state.stats.Scanned}
+	}
+
+	analysisStart := time.Now()
+	values, err := e.analyzeRoot(bound, configType)
+	err = explainDepthError(err, configType)
+
+	if err == nil {
+		err = e.openEnvelopes(lookupCtx, values)
+	}
+
+	if err == nil {
+		err = e.checkValues(configType, values)
+	}
+
+	if err == nil {
+		err = e.checkSettable(configType, values)
+	}
+
+	state.stats.AnalysisDuration += time.Since(analysisStart)
+	state.stats.Matched += len(values)
+
+	for _, v := range values {
+		state.matched[v.Variable] = struct{}{}
+	}
+
+	lookupSpan.SetAttribute("envconfig.variable_count", strconv.Itoa(len(values)))
+	lookupSpan.End(err)
 
 	if err != nil {
 		return err
 	}
 
-	return e.assignValues(configVal, configType, values)
+	if l.policy == FillOnly {
+		values = unassignedValues(values, state.assigned)
+	}
+
+	values, tombstones := e.splitTombstones(configType, values)
+
+	assignmentStart := time.Now()
+	_, assignSpan := e.startSpan(ctx, "envconfig.assign")
+	appended := e.remapAppendedValues(configVal, configType, values)
+	err = e.assignValues(l, configVal, configType, values)
+	e.dedupSlices(configVal, appended)
+
+	for _, v := range values {
+		state.assigned[v.Path.key()] = e.reportEntry(l, v)
+	}
+
+	// Deletions are applied last, they win over values of the same source
+	if err == nil {
+		err = e.deleteMapEntries(configVal, configType, tombstones, state.assigned)
+	}
+
+	assignSpan.End(err)
+	state.stats.AssignmentDuration += time.Since(assignmentStart)
+
+	return err
+}
+
+// prefetchKeys lists variables a configuration struct is loaded from, so they
+// can be looked up in a single call. Lazy fields are left out.
+func (e *envConfig) prefetchKeys(configType reflect.Type) []string {
+	if configType.Kind() != reflect.Struct {
+		return nil
+	}
+
+	vars, err := e.describeStruct(configType, path{}, e.envVarFromPath(path{}))
+
+	if err != nil {
+		return nil
+	}
+
+	keys := make([]string, 0, len(vars))
+
+	for _, v := range vars {
+		if isLazyPath(configType, v.Path) {
+			continue
+		}
+
+		keys = append(keys, v.Name)
+
+		if unprefixed, ok := e.unprefixedVariable(v.Name); ok {
+			keys = append(keys, unprefixed)
+		}
+	}
+
+	return keys
 }
 
 // path represents path to a value in a struct
@@ -79,6 +333,11 @@ func (p path) clone() path {
 	return res
 }
 
+// key returns a string uniquely identifying the path
+func (p path) key() string {
+	return strings.Join(p, "\x00")
+}
+
 func (p path) popBack() (string, path) {
 	if len(p) == 1 {
 		return p[0], path{}
@@ -90,19 +349,51 @@ func (p path) popBack() (string, path) {
 type envValue struct {
 	StrValue string
 	Path     path
+	// Variable is the name of the variable defining the value
+	Variable string
+}
+
+// analyzeRoot looks up values of the configuration type, which is either a
+// struct, or a map, a slice or an array indexed right after the prefix.
+func (e *envConfig) analyzeRoot(l layer, configType reflect.Type) ([]*envValue, error) {
+	switch configType.Kind() {
+	case reflect.Struct:
+		return e.analyzeStruct(l, configType, path{}, e.envVarFromPath(path{}))
+	case reflect.Map, reflect.Slice, reflect.Array:
+		if e.prefix == "" {
+			return []*envValue{}, withCode(CodeInvalidConfig, errors.New("A prefix is required to load a map, a slice or an array"))
+		}
+
+		return e.analyzeIndexedType(l, configType, path{}, e.envVarFromPath(path{}))
+	default:
+		return []*envValue{}, withCode(CodeInvalidConfig, fmt.Errorf(
+			"Unsupported configuration type [%v], please provide a pointer to a struct, a map, a slice or an array",
+			configType,
+		))
+	}
 }
 
 // Recursively scan the given config structure type information
 // and look for defined environment variables.
 // Returns discovered values as a slice of *envValue
-func (e *envConfig) analyzeStruct(configType reflect.Type, currentPath path) ([]*envValue, error) {
+// name is the variable name of the struct, fields variables are named after it.
+func (e *envConfig) analyzeStruct(l layer, configType reflect.Type, currentPath path, name string) ([]*envValue, error) {
 	res := []*envValue{}
 
 	for i := 0; i < configType.NumField(); i++ {
 		field := configType.Field(i)
 
 		if field.Type.Kind() == reflect.Ptr && indirectedType(field.Type) == configType {
-			return []*envValue{}, fmt.Errorf("Recursive type detected %v in field %s", field.Type, field.Name)
+			return []*envValue{}, withCode(CodeMaxDepth, fmt.Errorf("Recursive type detected %v in field %s", field.Type, field.Name))
+		}
+
+		// Field is restricted to another source
+		if name, ok := field.Tag.Lookup(sourceTag); ok && name != l.name {
+			continue
+		}
+
+		if e.skipsField(field) {
+			continue
 		}
 
 		// If we're facing an embedded struct
@@ -112,7 +403,7 @@ func (e *envConfig) analyzeStruct(configType reflect.Type, currentPath path) ([]
 			if field.Type.Kind() == reflect.Interface {
 				continue
 			}
-			values, err := e.analyzeStruct(field.Type, currentPath)
+			values, err := e.analyzeStruct(l, indirectedType(field.Type), currentPath, name)
 
 			if err != nil {
 				return []*envValue{}, err
@@ -123,18 +414,23 @@ func (e *envConfig) analyzeStruct(configType reflect.Type, currentPath path) ([]
 		}
 
 		fieldPath := append(currentPath, field.Name)
+		fieldName, noexpand := e.fieldVariable(name, field)
+
+		if noexpand {
+			v, err := e.loadValue(l, fieldPath, fieldName)
+
+			if err != nil {
+				return []*envValue{}, err
+			}
 
-		if t, ok := field.Tag.Lookup(envConfigTag); ok {
-			if t == noExpand {
-				if v := e.loadValue(fieldPath); v != nil {
-					res = append(res, v)
-				}
+			if v != nil {
+				res = append(res, v)
 			}
 
 			continue
 		}
 
-		values, err := e.analyzeValue(field.Type, fieldPath)
+		values, err := e.analyzeValue(l, field.Type, fieldPath, fieldName)
 
 		if err != nil {
 			return []*envValue{}, err
@@ -146,27 +442,85 @@ func (e *envConfig) analyzeStruct(configType reflect.Type, currentPath path) ([]
 	return res, nil
 }
 
-func (e *envConfig) analyzeValue(valType reflect.Type, fieldPath path) ([]*envValue, error) {
+func (e *envConfig) analyzeValue(l layer, valType reflect.Type, fieldPath path, name string) ([]*envValue, error) {
 	var (
 		res []*envValue
 		err error
 	)
 
 	if len(fieldPath) > e.maxDepth {
-		return res, errors.New("Maxdepth exceeded, you might have a type loop in your structure")
+		return res, &depthError{fieldPath.clone(), e.maxDepth}
+	}
+
+	// Types decoding themselves are assigned from a single variable
+	if e.decodesItself(valType) {
+		v, err := e.loadValue(l, fieldPath, name)
+
+		if v != nil {
+			res = append(res, v)
+		}
+
+		return res, err
 	}
 
 	switch valType.Kind() {
 	case reflect.Array, reflect.Slice, reflect.Map:
-		res, err = e.analyzeIndexedType(valType, fieldPath)
+		res, err = e.analyzeIndexedType(l, valType, fieldPath, name)
 	case reflect.Ptr:
-		res, err = e.analyzeValue(valType.Elem(), fieldPath)
+		res, err = e.analyzeValue(l, valType.Elem(), fieldPath, name)
 	case reflect.Struct:
-		res, err = e.analyzeStruct(valType, fieldPath)
+		// Lazy values are looked up on access
+		if isLazy(valType) {
+			break
+		}
+
+		var v *envValue
+
+		// Structs having a setter, like time.Time, are assigned from a single
+		// variable
+		if _, ok := e.setterOf(valType); ok {
+			v, err = e.loadValue(l, fieldPath, name)
+
+			if v != nil {
+				res = append(res, v)
+			}
+
+			break
+		}
+
+		// With WithStructVariables, a struct can be defined as a whole by a
+		// single variable, expanded variables are looked up anyway and take
+		// precedence over it. Otherwise this variable only matters when it
+		// deletes a map entry, see WithMapTombstone.
+		if e.structVariables || e.mapTombstone != "" {
+			v, err = e.loadStructValue(l, fieldPath, name)
+
+			if err != nil {
+				break
+			}
+
+			if v != nil && (e.structVariables || v.StrValue == e.mapTombstone) {
+				res = append(res, v)
+			}
+		}
+
+		var values []*envValue
+
+		values, err = e.analyzeStruct(l, valType, fieldPath, name)
+		res = append(res, values...)
 	case reflect.Invalid, reflect.Chan, reflect.Func, reflect.Interface, reflect.UnsafePointer:
-		err = fmt.Errorf("type %s is not supported by EnvSource", valType.Name())
+		if e.skipUnsupported {
+			l.warn("Skipped field [%s]: type %v is not supported", strings.Join(fieldPath, "."), valType)
+			break
+		}
+
+		err = withCode(CodeUnsupportedType, fmt.Errorf("type %s is not supported by EnvSource", valType.Name()))
 	default:
-		if v := e.loadValue(fieldPath); v != nil {
+		var v *envValue
+
+		v, err = e.loadValue(l, fieldPath, name)
+
+		if v != nil {
 			res = append(res, v)
 		}
 	}
@@ -174,14 +528,20 @@ func (e *envConfig) analyzeValue(valType reflect.Type, fieldPath path) ([]*envVa
 	return res, err
 }
 
-func (e *envConfig) analyzeIndexedType(valType reflect.Type, fieldPath path) ([]*envValue, error) {
+func (e *envConfig) analyzeIndexedType(l layer, valType reflect.Type, fieldPath path, name string) ([]*envValue, error) {
 	var (
 		res []*envValue
 	)
 
-	prefix := e.envVarFromPath(fieldPath)
-	vars := e.envVarsWithPrefix(prefix)
+	prefix := name
+	vars, err := l.source.Keys(prefix)
+
+	if err != nil {
+		return res, sourceError(err)
+	}
+
 	nextKeys := unique(e.nextLevelKeys(prefix, vars))
+	e.sortKeys(nextKeys, prefix, valType.Kind() != reflect.Map)
 
 	for _, varName := range nextKeys {
 		key := e.keyFromEnvVar(varName, prefix)
@@ -193,27 +553,27 @@ func (e *envConfig) analyzeIndexedType(valType reflect.Type, fieldPath path) ([]
 			index, err := strconv.ParseUint(key, 10, 64)
 
 			if err != nil {
-				return res, fmt.Errorf(
+				return res, withCode(CodeInvalidKey, fmt.Errorf(
 					"Key [%s] is not usable as an int index in [%s]",
 					key,
 					varName,
-				)
+				))
 
 			}
 
 			if valType.Kind() == reflect.Array &&
 				int(index) >= valType.Len() {
-				return res, fmt.Errorf(
+				return res, withCode(CodeInvalidKey, fmt.Errorf(
 					"Detected key (%s) from variable %s is >= to array length %d",
 					key,
 					varName,
 					valType.Len(),
-				)
+				))
 			}
 		}
 
 		valPath := append(fieldPath, key)
-		keyValues, err := e.analyzeValue(valType.Elem(), valPath)
+		keyValues, err := e.analyzeValue(l, valType.Elem(), valPath, e.childVariable(name, key))
 		if err != nil {
 			return res, err
 		}
@@ -224,28 +584,66 @@ func (e *envConfig) analyzeIndexedType(valType reflect.Type, fieldPath path) ([]
 	return res, nil
 }
 
-func (e *envConfig) loadValue(fieldPath path) *envValue {
-	variableName := e.envVarFromPath(fieldPath)
+// loadValue looks the variable of a leaf value up, falling back to the
+// unprefixed variable if allowed.
+func (e *envConfig) loadValue(l layer, fieldPath path, name string) (*envValue, error) {
+	return e.lookupValue(l, fieldPath, name, true)
+}
 
-	value, ok := os.LookupEnv(variableName)
+// loadStructValue looks the variable defining a whole struct up. There is no
+// unprefixed fallback: a struct named like PATH or HOME must not be read from
+// the process's standard variables.
+func (e *envConfig) loadStructValue(l layer, fieldPath path, name string) (*envValue, error) {
+	return e.lookupValue(l, fieldPath, name, false)
+}
 
-	if !ok {
-		return nil
+func (e *envConfig) lookupValue(l layer, fieldPath path, name string, fallback bool) (*envValue, error) {
+	value, ok, err := l.source.Lookup(name)
+
+	// Try again without prefix if allowed
+	if unprefixed, allowed := e.unprefixedVariable(name); fallback && err == nil && !ok && allowed {
+		value, ok, err = l.source.Lookup(unprefixed)
 	}
 
-	return &envValue{value, fieldPath.clone()}
+	if err != nil || !ok {
+		return nil, sourceError(err)
+	}
+
+	return &envValue{value, fieldPath.clone(), name}, nil
 }
 
-func (e *envConfig) assignValues(configVal reflect.Value, configType reflect.Type, values []*envValue) error {
+func (e *envConfig) assignValues(l layer, configVal reflect.Value, configType reflect.Type, values []*envValue) error {
+	var errs []error
+
 	for _, v := range values {
-		if err := e.assignValue(configVal, configType, v.Path, v.StrValue); err != nil {
-			return err
+		err := e.assignValue(configVal, configType, v.Path, setter.SetContext{
+			RawValue: v.StrValue,
+			Path:     v.Path.clone(),
+			Variable: v.Variable,
+		})
+
+		if _, ok := err.(*unsupportedTypeError); ok && e.skipUnsupported {
+			l.warn("Skipped variable [%s]: %v", v.Variable, err)
+			continue
+		}
+
+		if err != nil {
+			errs = append(errs, newLoadError(configType, v.Variable, v.Path, v.StrValue, err))
+		}
+
+		if err != nil && !e.allErrors {
+			break
 		}
 	}
-	return nil
+
+	return joinErrors(errs)
 }
 
-func (e *envConfig) assignValue(val reflect.Value, valType reflect.Type, currentPath path, strValue string) error {
+func (e *envConfig) assignValue(val reflect.Value, valType reflect.Type, currentPath path, sc setter.SetContext) error {
+	if len(currentPath) == 0 && e.decodesItself(valType) {
+		return e.setValueWithContext(val, sc)
+	}
+
 	var err error
 	switch valType.Kind() {
 	case reflect.Ptr:
@@ -254,25 +652,30 @@ func (e *envConfig) assignValue(val reflect.Value, valType reflect.Type, current
 			return err
 		}
 
-		err = e.assignValue(val, valType, currentPath, strValue)
+		err = e.assignValue(val, valType, currentPath, sc)
 	case reflect.Struct:
-		err = e.assignToStruct(val, valType, currentPath, strValue)
+		if len(currentPath) == 0 {
+			err = e.setStruct(val, sc)
+			break
+		}
+
+		err = e.assignToStruct(val, valType, currentPath, sc)
 	case reflect.Slice:
-		err = e.assignToSlice(val, valType, currentPath, strValue)
+		err = e.assignToSlice(val, valType, currentPath, sc)
 	case reflect.Array:
-		err = e.assignToArray(val, valType, currentPath, strValue)
+		err = e.assignToArray(val, valType, currentPath, sc)
 	case reflect.Map:
-		err = e.assignToMap(val, valType, currentPath, strValue)
+		err = e.assignToMap(val, valType, currentPath, sc)
 	case reflect.Invalid, reflect.Chan, reflect.Func, reflect.Interface, reflect.UnsafePointer:
-		err = fmt.Errorf("type %s is not supported by EnvSource", valType.Name())
+		err = withCode(CodeUnsupportedType, fmt.Errorf("type %s is not supported by EnvSource", valType.Name()))
 	default:
-		err = e.setValue(val, strValue)
+		err = e.setValueWithContext(val, sc)
 	}
 
 	return err
 }
 
-func (e *envConfig) assignToStruct(val reflect.Value, valType reflect.Type, currentPath path, strValue string) error {
+func (e *envConfig) assignToStruct(val reflect.Value, valType reflect.Type, currentPath path, sc setter.SetContext) error {
 	fieldName, currentPath := currentPath.popBack()
 
 	structField, ok := valType.FieldByName(fieldName)
@@ -282,24 +685,84 @@ func (e *envConfig) assignToStruct(val reflect.Value, valType reflect.Type, curr
 	}
 
 	valType = structField.Type
-	val = val.FieldByName(fieldName)
+	val = fieldByIndex(val, structField.Index)
+	sc.Tag = structField.Tag
+	options := fieldOptions(structField)
+
+	if err := checkOneOf(options, sc); err != nil {
+		return err
+	}
+
+	if layout, ok := options.Get(layoutOption); ok {
+		val, _, err := e.allocate(val, valType)
+		if err != nil {
+			return err
+		}
+
+		return e.setTimeWithLayout(val, layout, sc)
+	}
 
 	// If we're dealing with a noexpand struct
 	// Directly perform allocation then intent to set value
-	if t, ok := structField.Tag.Lookup(envConfigTag); ok {
-		if t == noExpand {
-			val, _, err := e.allocate(val, valType)
-			if err != nil {
-				return err
+	if options.Whole() {
+		val, _, err := e.allocate(val, valType)
+		if err != nil {
+			return err
+		}
+
+		if name, ok := options.Get(setterOption); ok {
+			return e.setWithNamedSetter(name, val, sc)
+		}
+
+		if sep, ok := options.Get(splitOption); ok {
+			return e.setSplit(val, sep, sc)
+		}
+
+		if options.Has(jsonOption) {
+			return e.setFromJSON(val, sc)
+		}
+
+		if isEncodedBytes(structField, options) {
+			return setBytes(sc, val)
+		}
+
+		_, ok := e.setterOf(val.Type())
+
+		// Fallback to JSON decoding for types implementing json.Unmarshaler
+		if !ok && isJSONUnmarshaler(val.Type()) {
+			return setJSON(sc.RawValue, val)
+		}
+
+		// Fallback to query string parsing for structs lacking a setter
+		if !ok && val.Kind() == reflect.Struct {
+			return e.setStructFromQuery(val, sc.RawValue)
+		}
+
+		return e.setValueWithContext(val, sc)
+	}
+
+	return e.assignValue(val, valType, currentPath, sc)
+}
+
+// fieldByIndex returns the nested field at given index, allocating embedded
+// pointers on the way.
+func fieldByIndex(val reflect.Value, index []int) reflect.Value {
+	for i, fieldIndex := range index {
+		if i > 0 && val.Kind() == reflect.Ptr {
+			if val.IsNil() {
+				val.Set(reflect.New(val.Type().Elem()))
 			}
-			return e.setValue(val, strValue)
+
+			val = val.Elem()
 		}
+
+		val = val.Field(fieldIndex)
 	}
 
-	return e.assignValue(val, valType, currentPath, strValue)
+	return val
 }
 
-func (e *envConfig) assignToSlice(slice reflect.Value, sliceType reflect.Type, currentPath path, strValue string) error {
+func (e *envConfig) assignToSlice(slice reflect.Value, sliceType reflect.Type, currentPath path, sc setter.SetContext) error {
 	key, currentPath := currentPath.popBack()
 
 	indexU64, err := strconv.ParseUint(key, 10, 64)
@@ -310,27 +773,18 @@ func (e *envConfig) assignToSlice(slice reflect.Value, sliceType reflect.Type, c
 
 	index := int(indexU64)
 
-	var elemValue reflect.Value
-	elemType := sliceType.Elem()
-
-	if index < slice.Len() {
-		elemValue = slice.Index(index)
-	} else {
-		elemValue = reflect.New(elemType).Elem()
-	}
-
-	if err := e.assignValue(elemValue, elemType, currentPath, strValue); err != nil {
-		return err
-	}
-
+	// Values aren't assigned in index order, grow the slice up to the index,
+	// gaps are filled with zero values.
 	if index >= slice.Len() {
-		slice.Set(reflect.Append(slice, elemValue))
+		grown := reflect.MakeSlice(sliceType, index+1, index+1)
+		reflect.Copy(grown, slice)
+		slice.Set(grown)
 	}
 
-	return nil
+	return e.assignValue(slice.Index(index), sliceType.Elem(), currentPath, sc)
 }
 
-func (e *envConfig) assignToArray(array reflect.Value, arrayType reflect.Type, currentPath path, strValue string) error {
+func (e *envConfig) assignToArray(array reflect.Value, arrayType reflect.Type, currentPath path, sc setter.SetContext) error {
 	key, currentPath := currentPath.popBack()
 
 	indexU64, err := strconv.ParseUint(key, 10, 64)
@@ -350,10 +804,10 @@ func (e *envConfig) assignToArray(array reflect.Value, arrayType reflect.Type, c
 
 	elemValue = array.Index(index)
 
-	return e.assignValue(elemValue, elemType, currentPath, strValue)
+	return e.assignValue(elemValue, elemType, currentPath, sc)
 }
 
-func (e *envConfig) assignToMap(mapValue reflect.Value, mapType reflect.Type, currentPath path, strValue string) error {
+func (e *envConfig) assignToMap(mapValue reflect.Value, mapType reflect.Type, currentPath path, sc setter.SetContext) error {
 	keyString, currentPath := currentPath.popBack()
 
 	keyValue := reflect.New(mapType.Key()).Elem()
@@ -366,14 +820,15 @@ func (e *envConfig) assignToMap(mapValue reflect.Value, mapType reflect.Type, cu
 		mapValue.Set(reflect.MakeMap(mapType))
 	}
 
-	elemValue := mapValue.MapIndex(keyValue)
 	elemType := mapType.Elem()
+	elemValue := reflect.New(elemType).Elem()
 
-	if !elemValue.IsValid() {
-		elemValue = reflect.New(elemType).Elem()
+	// Map elements aren't addressable, work on a copy of the existing one
+	if existing := mapValue.MapIndex(keyValue); existing.IsValid() {
+		elemValue.Set(existing)
 	}
 
-	if err := e.assignValue(elemValue, elemType, currentPath, strValue); err != nil {
+	if err := e.assignValue(elemValue, elemType, currentPath, sc); err != nil {
 		return err
 	}
 
@@ -405,20 +860,21 @@ func (e *envConfig) allocate(val reflect.Value, valType reflect.Type) (reflect.V
 }
 
 func (e *envConfig) setValue(value reflect.Value, strValue string) error {
+	return e.setValueWithContext(value, setter.SetContext{RawValue: strValue})
+}
+
+func (e *envConfig) setValueWithContext(value reflect.Value, sc setter.SetContext) error {
 	if !value.CanSet() {
 		return fmt.Errorf("Value [%v] cannot be set", value)
 	}
 
-	setter, ok := e.setters[value.Type()]
+	s, ok := e.setterOf(value.Type())
 
 	if !ok {
-		return fmt.Errorf(
-			"Unsupported type [%s], please consider adding custom setter",
-			value.Type().String(),
-		)
+		return &unsupportedTypeError{value.Type()}
 	}
 
-	return setter.Set(strValue, value)
+	return setter.WithContext(s).SetWithContext(sc, value)
 }
 
 func (e *envConfig) nextLevelKeys(prefix string, envVars []string) []string {
@@ -436,17 +892,28 @@ func (e *envConfig) nextLevelKeys(prefix string, envVars []string) []string {
 	return res
 }
 
-func (e *envConfig) envVarsWithPrefix(prefix string) []string {
-	res := []string{}
-
-	for _, rawVar := range os.Environ() {
-		varName := strings.Split(rawVar, "=")[0]
-		if strings.HasPrefix(varName, prefix) {
-			res = append(res, varName)
+// sortKeys sorts variables of entries of an indexed value named prefix, so
+// values are assigned in a deterministic order whatever the order keys are
+// listed in by sources. Slice and array entries are sorted by index, keys
+// which aren't indexes last.
+func (e *envConfig) sortKeys(vars []string, prefix string, numeric bool) {
+	sort.SliceStable(vars, func(i, j int) bool {
+		if !numeric {
+			return vars[i] < vars[j]
 		}
-	}
 
-	return res
+		left, leftErr := strconv.ParseUint(e.keyFromEnvVar(vars[i], prefix), 10, 64)
+		right, rightErr := strconv.ParseUint(e.keyFromEnvVar(vars[j], prefix), 10, 64)
+
+		switch {
+		case leftErr == nil && rightErr == nil:
+			return left < right
+		case leftErr == nil || rightErr == nil:
+			return leftErr == nil
+		default:
+			return vars[i] < vars[j]
+		}
+	})
 }
 
 func (e *envConfig) keyFromEnvVar(fullVar, prefix string) string {
@@ -459,16 +926,90 @@ func (e *envConfig) keyFromEnvVar(fullVar, prefix string) string {
 }
 
 func (e *envConfig) envVarFromPath(currentPath []string) string {
-	if e.prefix != "" {
-		currentPath = append([]string{e.prefix}, currentPath...)
+	return e.envVarFromPathWithPrefix(e.prefix, currentPath)
+}
+
+// fieldVariable returns the variable name of given field of a struct named
+// name, and whether it is loaded as a whole according to its envconfig tag.
+func (e *envConfig) fieldVariable(name string, field reflect.StructField) (string, bool) {
+	options := fieldOptions(field)
+
+	if override, ok := options.Get(nameOption); ok {
+		return override, options.Whole()
+	}
+
+	return e.childVariable(name, field.Name), options.Whole()
+}
+
+// childVariable names the variable of a value at given key of a value named
+// parent, like a struct field or a map entry.
+func (e *envConfig) childVariable(parent, key string) string {
+	child := e.envVarFromPathWithPrefix("", []string{key})
+
+	if parent == "" {
+		return child
 	}
-	s := make([]string, 0, len(currentPath))
 
-	for _, word := range currentPath {
-		s = append(s, camelcase.Split(word)...)
+	return parent + e.separator + child
+}
+
+// variableName returns the name of the variable defining the value at given
+// path of configType, honoring name= tag options.
+func (e *envConfig) variableName(configType reflect.Type, valuePath path) string {
+	name := e.envVarFromPath(path{})
+	valType := configType
+
+	for _, key := range valuePath {
+		if valType == nil {
+			name = e.childVariable(name, key)
+			continue
+		}
+
+		valType = indirectedType(valType)
+
+		switch valType.Kind() {
+		case reflect.Struct:
+			field, ok := valType.FieldByName(key)
+
+			if !ok {
+				name, valType = e.childVariable(name, key), nil
+				continue
+			}
+
+			name, _ = e.fieldVariable(name, field)
+			valType = field.Type
+		case reflect.Map, reflect.Slice, reflect.Array:
+			name, valType = e.childVariable(name, key), valType.Elem()
+		default:
+			name, valType = e.childVariable(name, key), nil
+		}
+	}
+
+	return name
+}
+
+// unprefixedVariable returns given variable without the loader prefix, if the
+// unprefixed fallback is enabled and the variable is prefixed.
+func (e *envConfig) unprefixedVariable(name string) (string, bool) {
+	if !e.unprefixedFallback || e.prefix == "" {
+		return "", false
+	}
+
+	root := e.envVarFromPath(path{})
+
+	if !strings.HasPrefix(name, root+e.separator) {
+		return "", false
+	}
+
+	return strings.TrimPrefix(name, root+e.separator), true
+}
+
+func (e *envConfig) envVarFromPathWithPrefix(prefix string, currentPath []string) string {
+	if prefix != "" {
+		currentPath = append([]string{prefix}, currentPath...)
 	}
 
-	return strings.ToUpper(strings.Join(s, e.separator))
+	return tags.VariableName(e.separator, currentPath...)
 }
 
 func unique(in []string) []string {
@@ -0,0 +1,113 @@
+package envconfig
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"strings"
+)
+
+const gzipBase64Envelope = "gz64:"
+
+// Decryptor decrypts values stored as ciphertext, for instance using a
+// key management service.
+type Decryptor interface {
+	Decrypt(ctx context.Context, ciphertext string) (string, error)
+}
+
+// DecryptorFunc is a function implementing Decryptor
+type DecryptorFunc func(ctx context.Context, ciphertext string) (string, error)
+
+// Decrypt calls f(ctx, ciphertext)
+func (f DecryptorFunc) Decrypt(ctx context.Context, ciphertext string) (string, error) {
+	return f(ctx, ciphertext)
+}
+
+// decryptor binds a Decryptor to the envelope of values it handles
+type decryptor struct {
+	envelope  string
+	decryptor Decryptor
+}
+
+// WithCompressedValues makes the loader decompress values wrapped into a
+// gz64: envelope before parsing them. The payload following the envelope is
+// the standard base64 encoding of gzip compressed data, as produced by
+// `gzip -c license.txt | base64 -w0`.
+func WithCompressedValues() Option {
+	return func(e *envConfig) {
+		e.compressedValues = true
+	}
+}
+
+// WithDecryptor makes the loader decrypt values starting with given envelope,
+// for instance "enc:kms:", using given Decryptor. The envelope is stripped
+// from the value before it is handed to the Decryptor.
+// Decryptors are called with the lookup context, so they are interrupted
+// along with the source lookups.
+func WithDecryptor(envelope string, d Decryptor) Option {
+	return func(e *envConfig) {
+		e.decryptors = append(e.decryptors, decryptor{envelope, d})
+	}
+}
+
+// openEnvelopes replaces enveloped values by their actual value
+func (e *envConfig) openEnvelopes(ctx context.Context, values []*envValue) error {
+	if !e.compressedValues && len(e.decryptors) == 0 {
+		return nil
+	}
+
+	for _, v := range values {
+		value, err := e.openEnvelope(ctx, v.StrValue)
+
+		if err != nil {
+			return withCode(CodeEnvelope, fmt.Errorf("Failed to open variable [%s]: %v", v.Variable, err))
+		}
+
+		v.StrValue = value
+	}
+
+	return nil
+}
+
+// openEnvelope returns the actual value of a variable, unwrapping it from
+// its envelope if any.
+func (e *envConfig) openEnvelope(ctx context.Context, value string) (string, error) {
+	for _, d := range e.decryptors {
+		if strings.HasPrefix(value, d.envelope) {
+			return d.decryptor.Decrypt(ctx, strings.TrimPrefix(value, d.envelope))
+		}
+	}
+
+	if e.compressedValues && strings.HasPrefix(value, gzipBase64Envelope) {
+		return gunzipBase64(strings.TrimPrefix(value, gzipBase64Envelope))
+	}
+
+	return value, nil
+}
+
+func gunzipBase64(payload string) (string, error) {
+	compressed, err := base64.StdEncoding.DecodeString(strings.TrimSpace(payload))
+
+	if err != nil {
+		return "", err
+	}
+
+	r, err := gzip.NewReader(bytes.NewReader(compressed))
+
+	if err != nil {
+		return "", err
+	}
+
+	defer r.Close()
+
+	res, err := ioutil.ReadAll(r)
+
+	if err != nil {
+		return "", err
+	}
+
+	return string(res), nil
+}
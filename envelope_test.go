@@ -0,0 +1,171 @@
+package envconfig
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+)
+
+func gzipBase64(t *testing.T, value string) string {
+	var buf bytes.Buffer
+
+	w := gzip.NewWriter(&buf)
+
+	if _, err := w.Write([]byte(value)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+func TestLoadWithCompressedValues(t *testing.T) {
+	testCases := []struct {
+		Label       string
+		Options     []Option
+		Env         map[string]string
+		Expectation basicAppConfig
+		ShouldFail  bool
+	}{
+		{
+			"WithCompressedValue",
+			[]Option{WithCompressedValues()},
+			map[string]string{
+				"APP_STRING_VALUE": "gz64:" + gzipBase64(t, "FOO"),
+				"APP_INT_VALUE":    "10",
+			},
+			basicAppConfig{StringValue: "FOO", IntValue: 10},
+			false,
+		},
+		{
+			"WithoutOption",
+			nil,
+			map[string]string{
+				"APP_STRING_VALUE": "gz64:" + gzipBase64(t, "FOO"),
+			},
+			basicAppConfig{StringValue: "gz64:" + gzipBase64(t, "FOO")},
+			false,
+		},
+		{
+			"WithInvalidPayload",
+			[]Option{WithCompressedValues()},
+			map[string]string{
+				"APP_STRING_VALUE": "gz64:" + base64.StdEncoding.EncodeToString([]byte("FOO")),
+			},
+			basicAppConfig{},
+			true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			setupEnv(testCase.Env)
+			defer cleanupEnv(testCase.Env)
+
+			result := basicAppConfig{}
+			err := New("APP", "_", testCase.Options...).Load(&result)
+
+			if testCase.ShouldFail {
+				if err == nil {
+					t.Log("Expected an error, got nothing")
+					t.Fail()
+				}
+				return
+			}
+
+			if err != nil {
+				t.Logf("Wasn't expecting an error, got [%v]", err)
+				t.FailNow()
+			}
+
+			if result != testCase.Expectation {
+				t.Logf("Invalid assignation, expected %v got %v", testCase.Expectation, result)
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestLoadWithDecryptor(t *testing.T) {
+	reverse := DecryptorFunc(func(ctx context.Context, ciphertext string) (string, error) {
+		if ciphertext == "" {
+			return "", errors.New("empty ciphertext")
+		}
+
+		res := []rune(ciphertext)
+
+		for i, j := 0, len(res)-1; i < j; i, j = i+1, j-1 {
+			res[i], res[j] = res[j], res[i]
+		}
+
+		return string(res), nil
+	})
+
+	testCases := []struct {
+		Label       string
+		Env         map[string]string
+		Expectation basicAppConfig
+		ShouldFail  bool
+	}{
+		{
+			"WithEncryptedValue",
+			map[string]string{
+				"APP_STRING_VALUE": "enc:test:OOF",
+				"APP_INT_VALUE":    "01",
+			},
+			basicAppConfig{StringValue: "FOO", IntValue: 1},
+			false,
+		},
+		{
+			"WithEncryptedCompressedValue",
+			map[string]string{
+				"APP_STRING_VALUE": "enc:test:RAB",
+				"APP_INT_VALUE":    "gz64:" + gzipBase64(t, "10"),
+			},
+			basicAppConfig{StringValue: "BAR", IntValue: 10},
+			false,
+		},
+		{
+			"WithDecryptionFailure",
+			map[string]string{
+				"APP_STRING_VALUE": "enc:test:",
+			},
+			basicAppConfig{},
+			true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			setupEnv(testCase.Env)
+			defer cleanupEnv(testCase.Env)
+
+			result := basicAppConfig{}
+			err := New("APP", "_", WithCompressedValues(), WithDecryptor("enc:test:", reverse)).Load(&result)
+
+			if testCase.ShouldFail {
+				if err == nil {
+					t.Log("Expected an error, got nothing")
+					t.Fail()
+				}
+				return
+			}
+
+			if err != nil {
+				t.Logf("Wasn't expecting an error, got [%v]", err)
+				t.FailNow()
+			}
+
+			if result != testCase.Expectation {
+				t.Logf("Invalid assignation, expected %v got %v", testCase.Expectation, result)
+				t.Fail()
+			}
+		})
+	}
+}
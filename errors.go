@@ -0,0 +1,315 @@
+package envconfig
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrorFormatter renders the errors which failed a load
+type ErrorFormatter interface {
+	FormatErrors(errs []error) string
+}
+
+// ErrorFormatterFunc is a function implementing ErrorFormatter
+type ErrorFormatterFunc func(errs []error) string
+
+// FormatErrors calls f
+func (f ErrorFormatterFunc) FormatErrors(errs []error) string {
+	return f(errs)
+}
+
+var (
+	// LinesFormatter renders one error per line, prefixed by the name of the
+	// variable which caused it.
+	LinesFormatter ErrorFormatter = ErrorFormatterFunc(formatLines)
+	// JSONFormatter renders errors as a JSON array of objects holding the
+	// variable, the field path and the error message.
+	JSONFormatter ErrorFormatter = ErrorFormatterFunc(formatJSON)
+	// SectionsFormatter renders errors grouped by top level field of the
+	// configuration struct.
+	SectionsFormatter ErrorFormatter = ErrorFormatterFunc(formatSections)
+)
+
+// WithErrorFormatter makes errors returned by Load render using given
+// formatter. Underlying errors are still reachable using errors.Is and
+// errors.As.
+func WithErrorFormatter(f ErrorFormatter) Option {
+	return func(e *envConfig) {
+		e.errorFormatter = f
+	}
+}
+
+// WithAllErrors makes the loader report every value which can't be assigned,
+// instead of failing on the first one, so all misconfigured variables can be
+// fixed in one pass. Errors render like LinesFormatter unless a formatter is
+// set.
+func WithAllErrors() Option {
+	return func(e *envConfig) {
+		e.allErrors = true
+	}
+}
+
+// LoadError associates an error to the variable which caused it. It is
+// reachable from errors returned by Load using errors.As, so applications can
+// tell which variable failed.
+type LoadError struct {
+	// Variable is the name of the variable, empty if it can't be named, like
+	// fields of map entries whose tag is invalid
+	Variable string
+	// Path is the dot separated path of the field, eg: Database.Port
+	Path string
+	// RawValue is the value served for the variable, empty if the variable
+	// isn't defined, or redacted if the field is tagged secret:"true"
+	RawValue string
+	// Err is the cause
+	Err error
+}
+
+// newLoadError returns a LoadError for the variable at given path of
+// configType, redacting the raw value of secret fields.
+func newLoadError(configType reflect.Type, variable string, fieldPath path, rawValue string, err error) *LoadError {
+	if rawValue != "" && isSecretPath(configType, fieldPath) {
+		rawValue = redacted
+	}
+
+	return &LoadError{variable, strings.Join(fieldPath, "."), rawValue, err}
+}
+
+// Error returns the cause prefixed by the name of the variable. The cause of
+// secret fields is replaced, as parse errors usually quote the raw value.
+func (l *LoadError) Error() string {
+	if l.Variable == "" {
+		return l.message()
+	}
+
+	return fmt.Sprintf("%s: %s", l.Variable, l.message())
+}
+
+// message returns the text of the cause, or a generic one if the raw value is
+// redacted.
+func (l *LoadError) message() string {
+	if l.RawValue == redacted {
+		return fmt.Sprintf("Invalid secret value (%s)", l.Code())
+	}
+
+	return l.Err.Error()
+}
+
+func (l *LoadError) Unwrap() error {
+	return l.Err
+}
+
+// Code returns the code of the cause, or CodeParseFailure when the cause isn't
+// coded, setters failing to parse the value.
+func (l *LoadError) Code() ErrorCode {
+	if code := CodeOf(l.Err); code != "" {
+		return code
+	}
+
+	return CodeParseFailure
+}
+
+// formattedErrors are the errors of a load, rendered by a formatter
+type formattedErrors struct {
+	errs      []error
+	formatter ErrorFormatter
+}
+
+func (f *formattedErrors) Error() string {
+	return f.formatter.FormatErrors(f.errs)
+}
+
+func (f *formattedErrors) Unwrap() []error {
+	return f.errs
+}
+
+func (f *formattedErrors) Is(target error) bool {
+	return joinedErrors(f.errs).Is(target)
+}
+
+func (f *formattedErrors) As(target interface{}) bool {
+	return joinedErrors(f.errs).As(target)
+}
+
+// formatErrors wraps given error so it renders using the configured formatter
+func (e *envConfig) formatErrors(err error) error {
+	formatter := e.errorFormatter
+
+	if formatter == nil && e.allErrors {
+		formatter = LinesFormatter
+	}
+
+	if err == nil || formatter == nil {
+		return err
+	}
+
+	return &formattedErrors{flattenErrors(err), formatter}
+}
+
+// joinErrors returns nil if errs is empty, its single error, or an error
+// joining them.
+func joinErrors(errs []error) error {
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	default:
+		return joinedErrors(errs)
+	}
+}
+
+// joinedErrors are several errors reported at once. It implements Is and As
+// itself, as errors.Is and errors.As only walk Unwrap() []error from go 1.20.
+type joinedErrors []error
+
+func (j joinedErrors) Error() string {
+	msgs := make([]string, len(j))
+
+	for i, err := range j {
+		msgs[i] = err.Error()
+	}
+
+	return strings.Join(msgs, "\n")
+}
+
+func (j joinedErrors) Unwrap() []error {
+	return j
+}
+
+func (j joinedErrors) Is(target error) bool {
+	for _, err := range j {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (j joinedErrors) As(target interface{}) bool {
+	for _, err := range j {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// flattenErrors lists errors joined into err
+func flattenErrors(err error) []error {
+	joined, ok := err.(interface{ Unwrap() []error })
+
+	if !ok {
+		return []error{err}
+	}
+
+	var res []error
+
+	for _, err := range joined.Unwrap() {
+		res = append(res, flattenErrors(err)...)
+	}
+
+	return res
+}
+
+// isLoadErrors reports if err is a LoadError, or only joins LoadErrors
+func isLoadErrors(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	for _, err := range flattenErrors(err) {
+		if _, ok := err.(*LoadError); !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+func formatLines(errs []error) string {
+	lines := make([]string, 0, len(errs))
+
+	for _, err := range errs {
+		lines = append(lines, err.Error())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+type jsonError struct {
+	Code     ErrorCode `json:"code,omitempty"`
+	Variable string    `json:"variable,omitempty"`
+	Path     string    `json:"path,omitempty"`
+	Error    string    `json:"error"`
+}
+
+func formatJSON(errs []error) string {
+	res := make([]jsonError, 0, len(errs))
+
+	for _, err := range errs {
+		entry := jsonError{Code: CodeOf(err), Error: err.Error()}
+
+		if l, ok := err.(*LoadError); ok {
+			entry.Variable = l.Variable
+			entry.Path = l.Path
+			entry.Error = l.message()
+		}
+
+		res = append(res, entry)
+	}
+
+	out, err := json.Marshal(res)
+
+	if err != nil {
+		return fmt.Sprintf("%v", errs)
+	}
+
+	return string(out)
+}
+
+func formatSections(errs []error) string {
+	var (
+		sections []string
+		grouped  = map[string][]string{}
+	)
+
+	for _, err := range errs {
+		section, msg := "", err.Error()
+
+		if l, ok := err.(*LoadError); ok && l.Path != "" {
+			section = strings.SplitN(l.Path, ".", 2)[0]
+		}
+
+		if _, ok := grouped[section]; !ok {
+			sections = append(sections, section)
+		}
+
+		grouped[section] = append(grouped[section], msg)
+	}
+
+	var b strings.Builder
+
+	for i, section := range sections {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+
+		if section == "" {
+			b.WriteString("General:\n")
+		} else {
+			fmt.Fprintf(&b, "%s:\n", section)
+		}
+
+		for _, msg := range grouped[section] {
+			fmt.Fprintf(&b, "  - %s\n", msg)
+		}
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
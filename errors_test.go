@@ -0,0 +1,211 @@
+package envconfig
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+type errorsConfig struct {
+	Server struct {
+		Port int
+	}
+}
+
+func TestLoadWithErrorFormatter(t *testing.T) {
+	source := mapSource{"APP_SERVER_PORT": "http"}
+
+	testCases := []struct {
+		Label       string
+		Formatter   ErrorFormatter
+		Expectation string
+	}{
+		{
+			"WithoutFormatter",
+			nil,
+			`APP_SERVER_PORT: strconv.ParseInt: parsing "http": invalid syntax`,
+		},
+		{
+			"WithLinesFormatter",
+			LinesFormatter,
+			`APP_SERVER_PORT: strconv.ParseInt: parsing "http": invalid syntax`,
+		},
+		{
+			"WithJSONFormatter",
+			JSONFormatter,
+			`[{"code":"ENV002","variable":"APP_SERVER_PORT","path":"Server.Port","error":"strconv.ParseInt: parsing \"http\": invalid syntax"}]`,
+		},
+		{
+			"WithSectionsFormatter",
+			SectionsFormatter,
+			"Server:\n  - APP_SERVER_PORT: strconv.ParseInt: parsing \"http\": invalid syntax",
+		},
+		{
+			"WithCustomFormatter",
+			ErrorFormatterFunc(func(errs []error) string {
+				return strconv.Itoa(len(errs)) + " error(s)"
+			}),
+			"1 error(s)",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			opts := []Option{WithSource(source)}
+
+			if testCase.Formatter != nil {
+				opts = append(opts, WithErrorFormatter(testCase.Formatter))
+			}
+
+			var result errorsConfig
+
+			err := New("APP", "_", opts...).Load(&result)
+
+			if err == nil {
+				t.Log("Expected an error, got nothing")
+				t.FailNow()
+			}
+
+			if err.Error() != testCase.Expectation {
+				t.Logf("Expected %q, got %q", testCase.Expectation, err.Error())
+				t.Fail()
+			}
+
+			if !errors.Is(err, strconv.ErrSyntax) {
+				t.Log("Expected the parse error to be reachable")
+				t.Fail()
+			}
+		})
+	}
+}
+
+type loadErrorConfig struct {
+	Server struct {
+		Port int
+	}
+	Token struct {
+		TTL int
+	} `secret:"true"`
+}
+
+func TestLoadError(t *testing.T) {
+	testCases := []struct {
+		Label       string
+		Env         mapSource
+		Expectation LoadError
+		Message     string
+	}{
+		{
+			"WithInvalidValue",
+			mapSource{"APP_SERVER_PORT": "http"},
+			LoadError{Variable: "APP_SERVER_PORT", Path: "Server.Port", RawValue: "http"},
+			`APP_SERVER_PORT: strconv.ParseInt: parsing "http": invalid syntax`,
+		},
+		{
+			"WithSecretValue",
+			mapSource{"APP_TOKEN_TTL": "groot"},
+			LoadError{Variable: "APP_TOKEN_TTL", Path: "Token.TTL", RawValue: redacted},
+			"APP_TOKEN_TTL: Invalid secret value (ENV002)",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			err := New("APP", "_", WithSource(testCase.Env), WithErrorFormatter(LinesFormatter)).Load(&loadErrorConfig{})
+
+			var loadErr *LoadError
+
+			if !errors.As(err, &loadErr) {
+				t.Logf("Expected a LoadError, got [%v]", err)
+				t.FailNow()
+			}
+
+			var numErr *strconv.NumError
+
+			if !errors.As(loadErr, &numErr) {
+				t.Logf("Expected the cause to be a NumError, got [%v]", loadErr.Err)
+				t.Fail()
+			}
+
+			if msg := loadErr.Error(); msg != testCase.Message {
+				t.Logf("Expected message %q, got %q", testCase.Message, msg)
+				t.Fail()
+			}
+
+			loadErr.Err = nil
+
+			if *loadErr != testCase.Expectation {
+				t.Logf("Expected %+v got %+v", testCase.Expectation, *loadErr)
+				t.Fail()
+			}
+		})
+	}
+}
+
+type allErrorsConfig struct {
+	Port    int
+	Debug   bool
+	Workers int
+	Name    string
+}
+
+func TestLoadWithAllErrors(t *testing.T) {
+	testCases := []struct {
+		Label       string
+		Options     []Option
+		Expectation string
+	}{
+		{
+			"WithoutAllErrors",
+			nil,
+			`APP_PORT: strconv.ParseInt: parsing "http": invalid syntax`,
+		},
+		{
+			"WithAllErrors",
+			[]Option{WithAllErrors()},
+			"APP_PORT: strconv.ParseInt: parsing \"http\": invalid syntax\n" +
+				"APP_DEBUG: strconv.ParseBool: parsing \"groot\": invalid syntax\n" +
+				"APP_WORKERS: strconv.ParseInt: parsing \"many\": invalid syntax",
+		},
+		{
+			"WithAllErrorsAndFormatter",
+			[]Option{WithAllErrors(), WithErrorFormatter(ErrorFormatterFunc(func(errs []error) string {
+				return strconv.Itoa(len(errs)) + " error(s)"
+			}))},
+			"3 error(s)",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			opts := append(
+				[]Option{
+					WithSource(mapSource{"APP_PORT": "http", "APP_DEBUG": "groot", "APP_NAME": "groot"}),
+					WithSource(mapSource{"APP_WORKERS": "many"}),
+				},
+				testCase.Options...,
+			)
+
+			var result allErrorsConfig
+
+			err := New("APP", "_", opts...).Load(&result)
+
+			if err == nil || err.Error() != testCase.Expectation {
+				t.Logf("Expected error %q got [%v]", testCase.Expectation, err)
+				t.Fail()
+			}
+
+			var loadErr *LoadError
+
+			if !errors.As(err, &loadErr) || loadErr.Variable != "APP_PORT" {
+				t.Logf("Expected a LoadError of APP_PORT, got [%v]", err)
+				t.Fail()
+			}
+
+			if code := CodeOf(err); code != CodeParseFailure {
+				t.Logf("Expected code %s, got %s", CodeParseFailure, code)
+				t.Fail()
+			}
+		})
+	}
+}
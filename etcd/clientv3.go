@@ -0,0 +1,46 @@
+package etcd
+
+import (
+	"context"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// NewClientKV returns a KV using given etcd client.
+func NewClientKV(client *clientv3.Client) KV {
+	return clientKV{client}
+}
+
+type clientKV struct {
+	client *clientv3.Client
+}
+
+func (c clientKV) Get(ctx context.Context, key string) (string, bool, error) {
+	resp, err := c.client.Get(ctx, key)
+
+	if err != nil {
+		return "", false, err
+	}
+
+	if len(resp.Kvs) == 0 {
+		return "", false, nil
+	}
+
+	return string(resp.Kvs[0].Value), true, nil
+}
+
+func (c clientKV) Keys(ctx context.Context, prefix string) ([]string, error) {
+	resp, err := c.client.Get(ctx, prefix, clientv3.WithPrefix(), clientv3.WithKeysOnly())
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]string, 0, len(resp.Kvs))
+
+	for _, kv := range resp.Kvs {
+		res = append(res, string(kv.Key))
+	}
+
+	return res, nil
+}
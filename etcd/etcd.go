@@ -0,0 +1,70 @@
+// Package etcd serves configuration values stored in an etcd v3 cluster. Its
+// Source is meant to be given to envconfig.WithSource:
+//
+//	envconfig.New("APP", "_", envconfig.WithSource(etcd.Source(kv, "/config/")))
+package etcd
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jlevesy/envconfig/sources"
+)
+
+// KV reads keys of an etcd v3 store.
+// It is a small subset of an etcd client, so any client can be plugged in.
+type KV interface {
+	// Get returns the value stored at given key, the returned boolean
+	// reports if the key is defined.
+	Get(ctx context.Context, key string) (string, bool, error)
+	// Keys returns every key starting with given prefix.
+	Keys(ctx context.Context, prefix string) ([]string, error)
+}
+
+// Source returns a Source serving variables stored under given key prefix,
+// eg: APP_PORT is read from the /config/APP_PORT key when keyPrefix is
+// /config/.
+func Source(kv KV, keyPrefix string) sources.ContextSource {
+	return &source{kv, keyPrefix}
+}
+
+type source struct {
+	kv        KV
+	keyPrefix string
+}
+
+func (s *source) Lookup(key string) (string, bool, error) {
+	return s.LookupContext(context.Background(), key)
+}
+
+func (s *source) LookupContext(ctx context.Context, key string) (string, bool, error) {
+	return s.kv.Get(ctx, s.keyPrefix+key)
+}
+
+func (s *source) Keys(prefix string) ([]string, error) {
+	return s.KeysContext(context.Background(), prefix)
+}
+
+func (s *source) KeysContext(ctx context.Context, prefix string) ([]string, error) {
+	keys, err := s.kv.Keys(ctx, s.keyPrefix+prefix)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]string, 0, len(keys))
+
+	for _, key := range keys {
+		if trimmed := strings.TrimPrefix(key, s.keyPrefix); trimmed != key || s.keyPrefix == "" {
+			res = append(res, trimmed)
+		}
+	}
+
+	return res, nil
+}
+
+// Health checks that the store can be reached
+func (s *source) Health(ctx context.Context) error {
+	_, _, err := s.kv.Get(ctx, s.keyPrefix)
+	return err
+}
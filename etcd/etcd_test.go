@@ -0,0 +1,107 @@
+package etcd
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+type mapKV map[string]string
+
+func (m mapKV) Get(ctx context.Context, key string) (string, bool, error) {
+	if err := ctx.Err(); err != nil {
+		return "", false, err
+	}
+
+	value, ok := m[key]
+	return value, ok, nil
+}
+
+func (m mapKV) Keys(ctx context.Context, prefix string) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	res := []string{}
+
+	for key := range m {
+		if strings.HasPrefix(key, prefix) {
+			res = append(res, key)
+		}
+	}
+
+	return res, nil
+}
+
+func TestSource(t *testing.T) {
+	source := Source(
+		mapKV{
+			"/config/APP_PORT":         "8080",
+			"/config/APP_ROUTES_FOO":   "foo",
+			"/config/APP_ROUTES_BAR":   "bar",
+			"/other/APP_ROUTES_BAZ":    "baz",
+			"/config/OTHER_ROUTES_FOO": "foo",
+		},
+		"/config/",
+	)
+
+	testCases := []struct {
+		Label       string
+		Key         string
+		Expectation string
+		Found       bool
+	}{
+		{"WithDefinedKey", "APP_PORT", "8080", true},
+		{"WithUndefinedKey", "APP_HOST", "", false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			value, found, err := source.Lookup(testCase.Key)
+
+			if err != nil {
+				t.Logf("Wasn't expecting an error, got [%v]", err)
+				t.FailNow()
+			}
+
+			if value != testCase.Expectation || found != testCase.Found {
+				t.Logf("Expected %q %t, got %q %t", testCase.Expectation, testCase.Found, value, found)
+				t.Fail()
+			}
+		})
+	}
+
+	keys, err := source.Keys("APP_ROUTES_")
+
+	if err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	sort.Strings(keys)
+
+	if expectation := []string{"APP_ROUTES_BAR", "APP_ROUTES_FOO"}; !reflect.DeepEqual(keys, expectation) {
+		t.Logf("Expected %v got %v", expectation, keys)
+		t.Fail()
+	}
+}
+
+func TestSourceWithCancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	source := Source(mapKV{"APP_PORT": "8080"}, "")
+
+	if _, _, err := source.LookupContext(ctx, "APP_PORT"); !errors.Is(err, context.Canceled) {
+		t.Logf("Expected a cancellation error, got [%v]", err)
+		t.Fail()
+	}
+
+	if _, err := source.KeysContext(ctx, "APP_"); !errors.Is(err, context.Canceled) {
+		t.Logf("Expected a cancellation error, got [%v]", err)
+		t.Fail()
+	}
+}
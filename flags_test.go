@@ -0,0 +1,73 @@
+package envconfig
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jlevesy/envconfig/setter"
+)
+
+type features uint8
+
+const (
+	tracing features = 1 << iota
+	compression
+	caching
+)
+
+type featuresConfig struct {
+	Features features
+}
+
+func TestLoadFlags(t *testing.T) {
+	setters := setter.LoadBasicTypes()
+	setters[reflect.TypeOf(features(0))] = setter.Flags(map[string]uint64{
+		"tracing":     uint64(tracing),
+		"compression": uint64(compression),
+		"caching":     uint64(caching),
+	})
+
+	testCases := []struct {
+		Label        string
+		Value        string
+		Expectation  features
+		ExpectsError bool
+	}{
+		{"WithFlags", "tracing, Compression", tracing | compression, false},
+		{"WithEmptyValue", "", 0, false},
+		{"WithUnknownFlag", "tracing,profiling", 0, true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			var result featuresConfig
+
+			err := NewWithSettersAndDepth(
+				"APP",
+				"_",
+				setters,
+				DefaultDepth,
+				WithSource(mapSource{"APP_FEATURES": testCase.Value}),
+			).Load(&result)
+
+			if testCase.ExpectsError {
+				if CodeOf(err) != CodeParseFailure {
+					t.Logf("Expected a parse failure, got [%v]", err)
+					t.Fail()
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Logf("Wasn't expecting an error, got [%v]", err)
+				t.FailNow()
+			}
+
+			if result.Features != testCase.Expectation {
+				t.Logf("Expected %b, got %b", testCase.Expectation, result.Features)
+				t.Fail()
+			}
+		})
+	}
+}
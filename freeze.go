@@ -0,0 +1,164 @@
+package envconfig
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"hash"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+var (
+	// ErrNotFrozen is returned by Verify when the given config has never been frozen
+	ErrNotFrozen = errors.New("Config has not been frozen, please call Freeze first")
+
+	// ErrConfigMutated is returned by Verify when the given config has changed since
+	// it has been frozen
+	ErrConfigMutated = errors.New("Config has been mutated since it has been frozen")
+)
+
+// frozenConfigs holds content hashes of frozen configs, indexed by config pointer
+var frozenConfigs = struct {
+	sync.Mutex
+	hashes map[interface{}][]byte
+}{hashes: map[interface{}][]byte{}}
+
+// Freeze records a content hash of given configuration structure.
+// It is intended to be called right after Load, then Verify can be used to detect
+// any later mutation of the config.
+func Freeze(config interface{}) error {
+	sum, err := contentHash(config)
+
+	if err != nil {
+		return err
+	}
+
+	frozenConfigs.Lock()
+	defer frozenConfigs.Unlock()
+
+	frozenConfigs.hashes[config] = sum
+
+	return nil
+}
+
+// Unfreeze forgets the content hash recorded for given configuration structure,
+// it should be called once the config isn't used anymore so it can be garbage
+// collected.
+func Unfreeze(config interface{}) {
+	frozenConfigs.Lock()
+	defer frozenConfigs.Unlock()
+
+	delete(frozenConfigs.hashes, config)
+}
+
+// Verify checks that given configuration structure hasn't been mutated since it
+// has been frozen.
+func Verify(config interface{}) error {
+	sum, err := contentHash(config)
+
+	if err != nil {
+		return err
+	}
+
+	frozenConfigs.Lock()
+	defer frozenConfigs.Unlock()
+
+	frozenSum, ok := frozenConfigs.hashes[config]
+
+	if !ok {
+		return ErrNotFrozen
+	}
+
+	if string(frozenSum) != string(sum) {
+		return ErrConfigMutated
+	}
+
+	return nil
+}
+
+func contentHash(config interface{}) ([]byte, error) {
+	configVal := reflect.ValueOf(config)
+
+	if configVal.Kind() != reflect.Ptr || configVal.IsNil() {
+		return nil, errors.New("Passing by value isn't supported, please provide a pointer")
+	}
+
+	h := sha256.New()
+	hashValue(h, configVal.Elem(), map[uintptr]struct{}{})
+
+	return h.Sum(nil), nil
+}
+
+// hashValue writes a deterministic representation of given value into h.
+// Pointed values are hashed by content, visited only holds pointers being
+// hashed to break cycles, map keys are sorted.
+func hashValue(h hash.Hash, val reflect.Value, visited map[uintptr]struct{}) {
+	switch val.Kind() {
+	case reflect.Invalid:
+		fmt.Fprint(h, "nil;")
+	case reflect.Ptr:
+		if val.IsNil() {
+			fmt.Fprint(h, "nil;")
+			return
+		}
+
+		if _, ok := visited[val.Pointer()]; ok {
+			fmt.Fprint(h, "cycle;")
+			return
+		}
+
+		visited[val.Pointer()] = struct{}{}
+		hashValue(h, val.Elem(), visited)
+		delete(visited, val.Pointer())
+	case reflect.Interface:
+		if val.IsNil() {
+			fmt.Fprint(h, "nil;")
+			return
+		}
+
+		fmt.Fprintf(h, "%s:", val.Elem().Type())
+		hashValue(h, val.Elem(), visited)
+	case reflect.Struct:
+		fmt.Fprint(h, "{")
+		for i := 0; i < val.NumField(); i++ {
+			fmt.Fprintf(h, "%s:", val.Type().Field(i).Name)
+			hashValue(h, val.Field(i), visited)
+		}
+		fmt.Fprint(h, "}")
+	case reflect.Array, reflect.Slice:
+		fmt.Fprintf(h, "[%d:", val.Len())
+		for i := 0; i < val.Len(); i++ {
+			hashValue(h, val.Index(i), visited)
+		}
+		fmt.Fprint(h, "]")
+	case reflect.Map:
+		keys := val.MapKeys()
+		sums := make([]string, len(keys))
+
+		for i, key := range keys {
+			sum := sha256.New()
+			hashValue(sum, key, visited)
+			sums[i] = string(sum.Sum(nil))
+		}
+
+		order := make([]int, len(keys))
+		for i := range order {
+			order[i] = i
+		}
+
+		sort.Slice(order, func(i, j int) bool { return sums[order[i]] < sums[order[j]] })
+
+		fmt.Fprintf(h, "map[%d:", val.Len())
+		for _, i := range order {
+			fmt.Fprintf(h, "%x=", sums[i])
+			hashValue(h, val.MapIndex(keys[i]), visited)
+		}
+		fmt.Fprint(h, "]")
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		fmt.Fprintf(h, "ptr(%x);", val.Pointer())
+	default:
+		fmt.Fprintf(h, "%v;", val)
+	}
+}
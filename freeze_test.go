@@ -0,0 +1,167 @@
+package envconfig
+
+import (
+	"testing"
+)
+
+type freezableConfig struct {
+	StringValue string
+	PtrToValue  *int
+	Items       []string
+	Mapping     map[string]*basicAppConfig
+}
+
+func TestFreezeThenVerify(t *testing.T) {
+	testCases := []struct {
+		Label       string
+		Mutate      func(config *freezableConfig)
+		Expectation error
+	}{
+		{
+			"WithoutMutation",
+			func(config *freezableConfig) {},
+			nil,
+		},
+		{
+			"WithSameValueReassigned",
+			func(config *freezableConfig) {
+				config.StringValue = "FOO"
+			},
+			nil,
+		},
+		{
+			"WithMutatedValue",
+			func(config *freezableConfig) {
+				config.StringValue = "BAR"
+			},
+			ErrConfigMutated,
+		},
+		{
+			"WithMutatedPointedValue",
+			func(config *freezableConfig) {
+				*config.PtrToValue = 43
+			},
+			ErrConfigMutated,
+		},
+		{
+			"WithMutatedSlice",
+			func(config *freezableConfig) {
+				config.Items = append(config.Items, "BIZ")
+			},
+			ErrConfigMutated,
+		},
+		{
+			"WithMutatedMapEntry",
+			func(config *freezableConfig) {
+				config.Mapping["foo"].IntValue = 10
+			},
+			ErrConfigMutated,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			value := 42
+			config := &freezableConfig{
+				StringValue: "FOO",
+				PtrToValue:  &value,
+				Items:       []string{"FOO", "BAR"},
+				Mapping: map[string]*basicAppConfig{
+					"foo": {StringValue: "FOO"},
+					"bar": {StringValue: "BAR"},
+				},
+			}
+
+			if err := Freeze(config); err != nil {
+				t.Logf("Wasn't expecting an error, got [%v]", err)
+				t.FailNow()
+			}
+
+			testCase.Mutate(config)
+
+			if err := Verify(config); err != testCase.Expectation {
+				t.Logf("Expected [%v] got [%v]", testCase.Expectation, err)
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestVerifySharedPointers(t *testing.T) {
+	shared := &basicAppConfig{StringValue: "FOO"}
+	config := &struct {
+		Mapping map[string]*basicAppConfig
+	}{
+		Mapping: map[string]*basicAppConfig{},
+	}
+
+	for _, key := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		config.Mapping[key] = shared
+	}
+
+	if err := Freeze(config); err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	defer Unfreeze(config)
+
+	for i := 0; i < 20; i++ {
+		if err := Verify(config); err != nil {
+			t.Logf("Wasn't expecting an error, got [%v]", err)
+			t.FailNow()
+		}
+	}
+}
+
+func TestVerifyCycle(t *testing.T) {
+	type node struct {
+		Value string
+		Next  *node
+	}
+
+	config := &node{Value: "FOO"}
+	config.Next = config
+
+	if err := Freeze(config); err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	defer Unfreeze(config)
+
+	if err := Verify(config); err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.Fail()
+	}
+}
+
+func TestUnfreeze(t *testing.T) {
+	config := &freezableConfig{}
+
+	if err := Freeze(config); err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	Unfreeze(config)
+
+	if err := Verify(config); err != ErrNotFrozen {
+		t.Logf("Expected [%v] got [%v]", ErrNotFrozen, err)
+		t.Fail()
+	}
+}
+
+func TestVerifyNotFrozen(t *testing.T) {
+	if err := Verify(&freezableConfig{}); err != ErrNotFrozen {
+		t.Logf("Expected [%v] got [%v]", ErrNotFrozen, err)
+		t.Fail()
+	}
+}
+
+func TestFreezeByValue(t *testing.T) {
+	if err := Freeze(freezableConfig{}); err == nil {
+		t.Log("Expected an error, got nothing")
+		t.Fail()
+	}
+}
@@ -0,0 +1,5 @@
+module github.com/jlevesy/envconfig
+
+go 1.19
+
+require github.com/fatih/camelcase v1.0.0
@@ -0,0 +1,70 @@
+package envconfig
+
+import (
+	"fmt"
+	"reflect"
+	"unicode"
+)
+
+// WithMaxValueLength makes the loader reject values longer than given count
+// of bytes, protecting from pathological payloads. Limit applies to values
+// once compressed or encrypted envelopes are opened.
+func WithMaxValueLength(length int) Option {
+	return func(e *envConfig) {
+		e.maxValueLength = length
+	}
+}
+
+// WithRejectControlChars makes the loader reject values holding NUL bytes or
+// control characters other than tabs and line breaks.
+func WithRejectControlChars() Option {
+	return func(e *envConfig) {
+		e.rejectControlChars = true
+	}
+}
+
+// checkValues applies value guards to given values, before any setter runs
+func (e *envConfig) checkValues(configType reflect.Type, values []*envValue) error {
+	var errs []error
+
+	for _, v := range values {
+		err := e.checkValue(v.StrValue)
+
+		if err == nil {
+			continue
+		}
+
+		errs = append(errs, newLoadError(configType, v.Variable, v.Path, v.StrValue, err))
+
+		if !e.allErrors {
+			break
+		}
+	}
+
+	return joinErrors(errs)
+}
+
+// checkValue reports why given value is rejected, without quoting it
+func (e *envConfig) checkValue(value string) error {
+	if e.maxValueLength > 0 && len(value) > e.maxValueLength {
+		return withCode(
+			CodeInvalidValue,
+			fmt.Errorf("Value length of %d bytes exceeds the limit of %d bytes", len(value), e.maxValueLength),
+		)
+	}
+
+	if !e.rejectControlChars {
+		return nil
+	}
+
+	for i, r := range value {
+		if unicode.IsControl(r) && r != '\t' && r != '\n' && r != '\r' {
+			return withCode(
+				CodeInvalidValue,
+				fmt.Errorf("Value holds control character %U at offset %d", r, i),
+			)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package envconfig
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadWithValueGuards(t *testing.T) {
+	testCases := []struct {
+		Label      string
+		Options    []Option
+		Value      string
+		ShouldFail bool
+	}{
+		{"WithoutGuards", nil, "foo\x00bar", false},
+		{"WithValueInLimit", []Option{WithMaxValueLength(3)}, "foo", false},
+		{"WithValueTooLong", []Option{WithMaxValueLength(3)}, "fooo", true},
+		{"WithNULByte", []Option{WithRejectControlChars()}, "foo\x00bar", true},
+		{"WithEscapeCharacter", []Option{WithRejectControlChars()}, "foo\x1b[2J", true},
+		{"WithLineBreaks", []Option{WithRejectControlChars()}, "foo\n\tbar\r\n", false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			opts := append(testCase.Options, WithSource(mapSource{"APP_STRING_VALUE": testCase.Value}))
+
+			var result basicAppConfig
+
+			err := New("APP", "_", opts...).Load(&result)
+
+			if !testCase.ShouldFail {
+				if err != nil {
+					t.Logf("Wasn't expecting an error, got [%v]", err)
+					t.FailNow()
+				}
+
+				if result.StringValue != testCase.Value {
+					t.Logf("Expected %q got %q", testCase.Value, result.StringValue)
+					t.Fail()
+				}
+
+				return
+			}
+
+			if CodeOf(err) != CodeInvalidValue {
+				t.Logf("Expected an invalid value error, got [%v]", err)
+				t.FailNow()
+			}
+
+			if strings.Contains(err.Error(), testCase.Value) {
+				t.Logf("Rejected value shouldn't be quoted, got [%v]", err)
+				t.Fail()
+			}
+		})
+	}
+}
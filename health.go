@@ -0,0 +1,59 @@
+package envconfig
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jlevesy/envconfig/sources"
+)
+
+// UnhealthySource associates an unhealthy source with its health check error
+type UnhealthySource struct {
+	Source sources.Source
+	Err    error
+}
+
+// HealthError is returned by Health when at least one source is unhealthy
+type HealthError struct {
+	Unhealthy []UnhealthySource
+}
+
+func (h *HealthError) Error() string {
+	msgs := make([]string, 0, len(h.Unhealthy))
+
+	for _, u := range h.Unhealthy {
+		msgs = append(msgs, fmt.Sprintf("%T: %v", u.Source, u.Err))
+	}
+
+	return "Unhealthy sources: " + strings.Join(msgs, ", ")
+}
+
+// Code returns CodeUnhealthySource
+func (h *HealthError) Code() ErrorCode {
+	return CodeUnhealthySource
+}
+
+// HealthChecker is implemented by loaders able to check the health of their
+// sources, like loaders returned by New.
+type HealthChecker interface {
+	Health(ctx context.Context) error
+}
+
+// Health checks the health of every loader source implementing
+// sources.HealthChecker, and returns a *HealthError if any of them is unhealthy.
+func (e *envConfig) Health(ctx context.Context) error {
+	var unhealthy []UnhealthySource
+
+	for _, l := range e.sourceLayers() {
+		if err := sources.CheckHealth(ctx, l.source); err != nil {
+			unhealthy = append(unhealthy, UnhealthySource{l.source, err})
+		}
+	}
+
+	if len(unhealthy) > 0 {
+		return &HealthError{unhealthy}
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package envconfig
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// unhealthySource is a mapSource reporting given health
+type unhealthySource struct {
+	mapSource
+	err error
+}
+
+func (u unhealthySource) Health(ctx context.Context) error {
+	return u.err
+}
+
+func TestHealth(t *testing.T) {
+	errBackendDown := errors.New("backend is down")
+
+	testCases := []struct {
+		Label             string
+		Options           []Option
+		ExpectedUnhealthy int
+	}{
+		{"WithoutSource", nil, 0},
+		{
+			"WithHealthySources",
+			[]Option{
+				WithSource(mapSource{}),
+				WithSource(unhealthySource{mapSource{}, nil}),
+			},
+			0,
+		},
+		{
+			"WithUnhealthySources",
+			[]Option{
+				WithSource(unhealthySource{mapSource{}, errBackendDown}),
+				WithSource(mapSource{}),
+				WithSource(unhealthySource{mapSource{}, errBackendDown}),
+			},
+			2,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			err := New("APP", "_", testCase.Options...).(HealthChecker).Health(context.Background())
+
+			if testCase.ExpectedUnhealthy == 0 {
+				if err != nil {
+					t.Logf("Wasn't expecting an error, got [%v]", err)
+					t.Fail()
+				}
+				return
+			}
+
+			healthErr, ok := err.(*HealthError)
+
+			if !ok {
+				t.Logf("Expected a *HealthError got [%v]", err)
+				t.FailNow()
+			}
+
+			if len(healthErr.Unhealthy) != testCase.ExpectedUnhealthy {
+				t.Logf("Expected %d unhealthy sources got %d", testCase.ExpectedUnhealthy, len(healthErr.Unhealthy))
+				t.Fail()
+			}
+
+			for _, u := range healthErr.Unhealthy {
+				if u.Err != errBackendDown {
+					t.Logf("Expected [%v] got [%v]", errBackendDown, u.Err)
+					t.Fail()
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+// Package helm generates Helm chart files from the variables a configuration
+// struct is loaded from, so charts can't drift from the application's actual
+// configuration surface.
+//
+// Chart values are named after field paths in lower camel case: the variable
+// APP_DATABASE_MAX_CONNS, filled by the field Database.MaxConns, maps to the
+// database.maxConns value.
+package helm
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/fatih/camelcase"
+
+	"github.com/jlevesy/envconfig"
+)
+
+// ValuesSchema writes a values.schema.json document describing chart values
+// matching given variables.
+func ValuesSchema(w io.Writer, vars []envconfig.VarInfo) error {
+	root := map[string]interface{}{
+		"$schema":    "https://json-schema.org/draft-07/schema#",
+		"type":       "object",
+		"properties": map[string]interface{}{},
+	}
+
+	for _, v := range vars {
+		node := root
+
+		for _, key := range valuePath(v) {
+			properties := node["properties"].(map[string]interface{})
+			child, ok := properties[key].(map[string]interface{})
+
+			if !ok {
+				child = map[string]interface{}{
+					"type":       "object",
+					"properties": map[string]interface{}{},
+				}
+				properties[key] = child
+			}
+
+			node = child
+		}
+
+		delete(node, "properties")
+		node["type"] = schemaType(v.Type)
+		node["description"] = "Sets " + v.Name
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(root)
+}
+
+// Values writes a values.yaml skeleton holding chart values matching given
+// variables, set to the zero value of their type.
+func Values(w io.Writer, vars []envconfig.VarInfo) error {
+	written := map[string]struct{}{}
+
+	for _, v := range vars {
+		keys := valuePath(v)
+
+		// Write parent keys not written yet
+		for i := range keys[:len(keys)-1] {
+			parent := strings.Join(keys[:i+1], ".")
+
+			if _, ok := written[parent]; ok {
+				continue
+			}
+
+			written[parent] = struct{}{}
+
+			if _, err := fmt.Fprintf(w, "%s%s:\n", indent(i), keys[i]); err != nil {
+				return err
+			}
+		}
+
+		_, err := fmt.Fprintf(
+			w,
+			"%s%s: %s # %s\n",
+			indent(len(keys)-1),
+			keys[len(keys)-1],
+			zeroValue(v.Type),
+			v.Name,
+		)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Env writes the env section of a container spec, setting each variable from
+// its chart value.
+func Env(w io.Writer, vars []envconfig.VarInfo) error {
+	for _, v := range vars {
+		_, err := fmt.Fprintf(
+			w,
+			"- name: %s\n  value: {{ .Values.%s | quote }}\n",
+			v.Name,
+			strings.Join(valuePath(v), "."),
+		)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// valuePath names a chart value after the field path of a variable
+func valuePath(v envconfig.VarInfo) []string {
+	res := make([]string, len(v.Path))
+
+	for i, name := range v.Path {
+		words := camelcase.Split(name)
+
+		for j, word := range words {
+			words[j] = strings.ToLower(word)
+
+			if j > 0 {
+				words[j] = strings.ToUpper(word[:1]) + words[j][1:]
+			}
+		}
+
+		res[i] = strings.Join(words, "")
+	}
+
+	return res
+}
+
+func indent(level int) string {
+	return strings.Repeat("  ", level)
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+func schemaType(valType reflect.Type) string {
+	if valType == durationType {
+		return "string"
+	}
+
+	switch valType.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	default:
+		return "string"
+	}
+}
+
+func zeroValue(valType reflect.Type) string {
+	switch schemaType(valType) {
+	case "boolean":
+		return "false"
+	case "integer", "number":
+		return "0"
+	default:
+		return `""`
+	}
+}
@@ -0,0 +1,101 @@
+package helm
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jlevesy/envconfig"
+)
+
+type appConfig struct {
+	Database struct {
+		Host     string
+		MaxConns int
+	}
+	Timeout time.Duration
+	Debug   bool
+}
+
+func describe(t *testing.T) []envconfig.VarInfo {
+	vars, err := envconfig.New("APP", "_").(envconfig.Describer).Describe(&appConfig{})
+
+	if err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	return vars
+}
+
+func TestValuesSchema(t *testing.T) {
+	var buf bytes.Buffer
+
+	if err := ValuesSchema(&buf, describe(t)); err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	var result map[string]interface{}
+
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Logf("Expected a valid JSON document, got [%v]", err)
+		t.FailNow()
+	}
+
+	expectation := map[string]interface{}{
+		"database": map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"host":     map[string]interface{}{"type": "string", "description": "Sets APP_DATABASE_HOST"},
+				"maxConns": map[string]interface{}{"type": "integer", "description": "Sets APP_DATABASE_MAX_CONNS"},
+			},
+		},
+		"timeout": map[string]interface{}{"type": "string", "description": "Sets APP_TIMEOUT"},
+		"debug":   map[string]interface{}{"type": "boolean", "description": "Sets APP_DEBUG"},
+	}
+
+	if !reflect.DeepEqual(result["properties"], expectation) {
+		t.Logf("Expected %v got %v", expectation, result["properties"])
+		t.Fail()
+	}
+}
+
+func TestValues(t *testing.T) {
+	var buf bytes.Buffer
+
+	if err := Values(&buf, describe(t)); err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	expectation := `database:
+  host: "" # APP_DATABASE_HOST
+  maxConns: 0 # APP_DATABASE_MAX_CONNS
+timeout: "" # APP_TIMEOUT
+debug: false # APP_DEBUG
+`
+
+	if buf.String() != expectation {
+		t.Logf("Expected\n%s\ngot\n%s", expectation, buf.String())
+		t.Fail()
+	}
+}
+
+func TestEnv(t *testing.T) {
+	var buf bytes.Buffer
+
+	if err := Env(&buf, describe(t)[:1]); err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	expectation := "- name: APP_DATABASE_HOST\n  value: {{ .Values.database.host | quote }}\n"
+
+	if buf.String() != expectation {
+		t.Logf("Expected\n%s\ngot\n%s", expectation, buf.String())
+		t.Fail()
+	}
+}
@@ -0,0 +1,93 @@
+package envconfig
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// Holder holds the current configuration of a watched loader. The
+// configuration is swapped atomically on each successful reload, readers
+// never observe a partially updated configuration.
+// Configurations returned by Get must be treated as read only.
+type Holder[T any] struct {
+	current atomic.Pointer[T]
+
+	mu          sync.Mutex
+	subscribers []func(config *T, err error)
+}
+
+// NewHolder loads a configuration using given loader, then reloads it each
+// time a loader source reports a change, until ctx is done.
+// If the loader isn't a Watcher or no loader source reports changes, the
+// configuration is never reloaded.
+// Changes reported while the holder starts are picked up by the next reload.
+func NewHolder[T any](ctx context.Context, loader ConfigLoader) (*Holder[T], error) {
+	config := new(T)
+
+	if err := loadContext(ctx, loader, config); err != nil {
+		return nil, err
+	}
+
+	h := &Holder[T]{}
+	h.current.Store(config)
+
+	watcher, ok := loader.(Watcher)
+
+	if !ok {
+		return h, nil
+	}
+
+	go func() {
+		err := watcher.Watch(ctx, new(T), func(reloaded interface{}, err error) {
+			if err == nil {
+				h.current.Store(reloaded.(*T))
+			}
+
+			h.notify(err)
+		})
+
+		if err != nil && err != ErrNotWatchable {
+			h.notify(err)
+		}
+	}()
+
+	return h, nil
+}
+
+// Get returns the current configuration
+func (h *Holder[T]) Get() *T {
+	return h.current.Load()
+}
+
+// Subscribe registers a function called after each reload, with the current
+// configuration and the reload error. The previous configuration is kept when
+// a reload fails.
+func (h *Holder[T]) Subscribe(fn func(config *T, err error)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.subscribers = append(h.subscribers, fn)
+}
+
+func (h *Holder[T]) notify(err error) {
+	h.mu.Lock()
+	subscribers := h.subscribers
+	h.mu.Unlock()
+
+	config := h.Get()
+
+	for _, fn := range subscribers {
+		fn(config, err)
+	}
+}
+
+// loadContext loads config using loader, bounded by ctx if loader is a
+// ContextLoader.
+func loadContext(ctx context.Context, loader ConfigLoader, config interface{}) error {
+	if l, ok := loader.(ContextLoader); ok {
+		return l.LoadContext(ctx, config)
+	}
+
+	return loader.Load(config)
+}
@@ -0,0 +1,100 @@
+package envconfig
+
+import (
+	"context"
+	"testing"
+)
+
+func TestHolder(t *testing.T) {
+	source := &notifyingSource{
+		mapSource: mapSource{"APP_STRING_VALUE": "FOO", "APP_INT_VALUE": "1"},
+		changes:   make(chan struct{}),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	holder, err := NewHolder[basicAppConfig](ctx, New("APP", "_", WithSource(source)))
+
+	if err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	if holder.Get().StringValue != "FOO" {
+		t.Logf("Expected initial load to set [FOO] got [%s]", holder.Get().StringValue)
+		t.Fail()
+	}
+
+	reloads := make(chan error)
+	holder.Subscribe(func(config *basicAppConfig, err error) {
+		reloads <- err
+	})
+
+	// Wait for the watch to be running before touching the source
+	source.changes <- struct{}{}
+	<-reloads
+
+	initial := holder.Get()
+
+	source.mapSource["APP_STRING_VALUE"] = "BAR"
+	source.changes <- struct{}{}
+
+	if err := <-reloads; err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	if holder.Get().StringValue != "BAR" {
+		t.Logf("Expected reload to set [BAR] got [%s]", holder.Get().StringValue)
+		t.Fail()
+	}
+
+	if initial.StringValue != "FOO" {
+		t.Logf("Expected previous configuration to be left untouched, got [%s]", initial.StringValue)
+		t.Fail()
+	}
+
+	source.mapSource["APP_INT_VALUE"] = "groot"
+	source.changes <- struct{}{}
+
+	if err := <-reloads; err == nil {
+		t.Log("Expected a reload error, got nothing")
+		t.Fail()
+	}
+
+	if holder.Get().StringValue != "BAR" || holder.Get().IntValue != 1 {
+		t.Logf("Expected failed reload to keep current configuration, got %+v", holder.Get())
+		t.Fail()
+	}
+}
+
+func TestHolderWithFailingLoad(t *testing.T) {
+	loader := New("APP", "_", WithSource(mapSource{"APP_INT_VALUE": "groot"}))
+
+	if _, err := NewHolder[basicAppConfig](context.Background(), loader); err == nil {
+		t.Log("Expected an error, got nothing")
+		t.Fail()
+	}
+}
+
+// loadOnlyLoader implements ConfigLoader and nothing else
+type loadOnlyLoader struct {
+	ConfigLoader
+}
+
+func TestNewHolderWithLoadOnlyLoader(t *testing.T) {
+	loader := loadOnlyLoader{New("APP", "_", WithSource(mapSource{"APP_STRING_VALUE": "FOO"}))}
+
+	holder, err := NewHolder[basicAppConfig](context.Background(), loader)
+
+	if err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	if holder.Get().StringValue != "FOO" {
+		t.Logf("Expected FOO, got %+v", holder.Get())
+		t.Fail()
+	}
+}
@@ -0,0 +1,121 @@
+package envconfig
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/jlevesy/envconfig/sources"
+)
+
+// maxIncludeDepth bounds the nesting of included files
+const maxIncludeDepth = 8
+
+// WithIncludes makes the loader read files listed by the PREFIX_INCLUDE
+// variable, comma separated, before its sources: values of included files are
+// overridden by values of the sources, and of the files listing them.
+// Included files can list files themselves, paths being relative to the
+// including file. Each file is opened using open, eg: sources.EnvrcFile.
+func WithIncludes(open func(path string) (sources.Source, error)) Option {
+	return func(e *envConfig) {
+		e.openInclude = open
+	}
+}
+
+// includedLayers returns layers of files included by the sources, from the
+// lowest precedence to the highest.
+func (e *envConfig) includedLayers(ctx context.Context) ([]layer, error) {
+	if e.openInclude == nil {
+		return nil, nil
+	}
+
+	variable := e.envVarFromPath(path{"Include"})
+	value, _, err := e.lookupVariable(ctx, variable, "", false)
+
+	if err != nil {
+		return nil, err
+	}
+
+	r := includeResolver{e: e, variable: variable, seen: map[string]struct{}{}}
+
+	for _, p := range includePaths(value, "") {
+		if err := r.include(ctx, p, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return r.layers, nil
+}
+
+type includeResolver struct {
+	e        *envConfig
+	variable string
+	seen     map[string]struct{}
+	layers   []layer
+}
+
+// include adds a layer for file at given path, after the layers of files it
+// includes. stack lists files including it.
+func (r *includeResolver) include(ctx context.Context, p string, stack []string) error {
+	for _, s := range stack {
+		if s == p {
+			return withCode(CodeInvalidConfig, fmt.Errorf("Include cycle: %s -> %s", strings.Join(stack, " -> "), p))
+		}
+	}
+
+	if len(stack) >= maxIncludeDepth {
+		return withCode(CodeMaxDepth, fmt.Errorf("Includes are nested deeper than %d files: %s", maxIncludeDepth, strings.Join(stack, " -> ")))
+	}
+
+	if _, ok := r.seen[p]; ok {
+		return nil
+	}
+
+	source, err := r.e.openInclude(p)
+
+	if err != nil {
+		return withCode(CodeSourceFailure, fmt.Errorf("Failed to include [%s]: %v", p, err))
+	}
+
+	value, _, err := sources.WithContext(ctx, source).Lookup(r.variable)
+
+	if err != nil {
+		return sourceError(err)
+	}
+
+	stack = append(stack, p)
+
+	for _, nested := range includePaths(value, filepath.Dir(p)) {
+		if err := r.include(ctx, nested, stack); err != nil {
+			return err
+		}
+	}
+
+	r.seen[p] = struct{}{}
+	r.layers = append(r.layers, layer{name: p, source: source, policy: Overwrite})
+
+	return nil
+}
+
+// includePaths splits a list of included files, relative paths being resolved
+// from dir.
+func includePaths(value, dir string) []string {
+	var res []string
+
+	for _, p := range strings.Split(value, ",") {
+		p = strings.TrimSpace(p)
+
+		if p == "" {
+			continue
+		}
+
+		if dir != "" && !filepath.IsAbs(p) {
+			p = filepath.Join(dir, p)
+		}
+
+		res = append(res, filepath.Clean(p))
+	}
+
+	return res
+}
@@ -0,0 +1,97 @@
+package envconfig
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jlevesy/envconfig/sources"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+			t.Logf("Wasn't expecting an error, got [%v]", err)
+			t.FailNow()
+		}
+	}
+}
+
+func TestLoadWithIncludes(t *testing.T) {
+	dir := t.TempDir()
+
+	writeFiles(t, dir, map[string]string{
+		"shared.envrc":  "export APP_STRING_VALUE=shared\nexport APP_INT_VALUE=1\n",
+		"service.envrc": "export APP_INCLUDE=shared.envrc\nexport APP_INT_VALUE=2\n",
+	})
+
+	var result basicAppConfig
+
+	err := New(
+		"APP",
+		"_",
+		WithSource(mapSource{
+			"APP_INCLUDE":    filepath.Join(dir, "service.envrc"),
+			"APP_BOOL_VALUE": "true",
+		}),
+		WithIncludes(sources.EnvrcFile),
+	).Load(&result)
+
+	if err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	expectation := basicAppConfig{StringValue: "shared", IntValue: 2, BoolValue: true}
+
+	if result != expectation {
+		t.Logf("Expected %v, got %v", expectation, result)
+		t.Fail()
+	}
+}
+
+func TestLoadWithInvalidIncludes(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"a.envrc": "export APP_INCLUDE=b.envrc\n",
+		"b.envrc": "export APP_INCLUDE=a.envrc\n",
+	}
+
+	for i := 0; i <= maxIncludeDepth; i++ {
+		files[fmt.Sprintf("deep%d.envrc", i)] = fmt.Sprintf("export APP_INCLUDE=deep%d.envrc\n", i+1)
+	}
+
+	files[fmt.Sprintf("deep%d.envrc", maxIncludeDepth+1)] = ""
+
+	writeFiles(t, dir, files)
+
+	testCases := []struct {
+		Label   string
+		Include string
+		Code    ErrorCode
+	}{
+		{"WithCycle", "a.envrc", CodeInvalidConfig},
+		{"WithTooDeepIncludes", "deep0.envrc", CodeMaxDepth},
+		{"WithMissingFile", "missing.envrc", CodeSourceFailure},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			var result basicAppConfig
+
+			err := New(
+				"APP",
+				"_",
+				WithSource(mapSource{"APP_INCLUDE": filepath.Join(dir, testCase.Include)}),
+				WithIncludes(sources.EnvrcFile),
+			).Load(&result)
+
+			if code := CodeOf(err); code != testCase.Code {
+				t.Logf("Expected code %s, got [%v]", testCase.Code, err)
+				t.Fail()
+			}
+		})
+	}
+}
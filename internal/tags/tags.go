@@ -0,0 +1,161 @@
+// Package tags parses envconfig struct tags and names variables, so the loader
+// and the envconfig-gen command agree on both.
+package tags
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/camelcase"
+)
+
+// Options of the envconfig struct tag
+const (
+	NoExpand = "noexpand"
+	Name     = "name"
+	Default  = "default"
+	Required = "required"
+	Setter   = "setter"
+	Split    = "split"
+	JSON     = "json"
+	Layout   = "layout"
+	OneOf    = "oneof"
+	Encoding = "encoding"
+
+	// Separator is an alias of Split, Parse stores its value under Split
+	Separator = "separator"
+
+	// Ignore excludes a field from loads, like envconfig:"-"
+	Ignore = "-"
+)
+
+// Values of the encoding option
+const (
+	Base64 = "base64"
+	Hex    = "hex"
+)
+
+// takesValue lists supported options, and whether they take a value. Options
+// are hooked in the loader by reading them from Options.
+var takesValue = map[string]bool{
+	NoExpand:  false,
+	Name:      true,
+	Default:   true,
+	Required:  false,
+	Setter:    true,
+	Split:     true,
+	Separator: true,
+	JSON:      false,
+	Layout:    true,
+	OneOf:     true,
+	Encoding:  true,
+}
+
+// Options holds the options of an envconfig struct tag, flags being mapped to
+// an empty value.
+type Options map[string]string
+
+// Has reports if given option is set
+func (o Options) Has(key string) bool {
+	_, ok := o[key]
+	return ok
+}
+
+// Get returns the value of given option, and whether it is set
+func (o Options) Get(key string) (string, bool) {
+	value, ok := o[key]
+	return value, ok
+}
+
+// Whole reports if the field is assigned from a single variable, instead of
+// being expanded into a variable per field, item or entry.
+func (o Options) Whole() bool {
+	return o.Has(NoExpand) || o.Has(Setter) || o.Has(Split) || o.Has(JSON) || o.Has(Encoding)
+}
+
+// Parse parses an envconfig struct tag: a comma separated list of flags like
+// noexpand, and of key=value options like name=DATABASE_URL.
+// Values may hold commas, as long as they aren't followed by a supported
+// option, eg: default=a,b,noexpand holds the a,b default value.
+func Parse(tag string) (Options, error) {
+	res := Options{}
+
+	var (
+		current  string
+		hasValue bool
+	)
+
+	for _, item := range strings.Split(tag, ",") {
+		key := item
+
+		if i := strings.IndexByte(item, '='); i >= 0 {
+			key = item[:i]
+		}
+
+		if _, known := takesValue[key]; !known {
+			if !hasValue {
+				return nil, fmt.Errorf("unknown option %s", item)
+			}
+
+			// Part of the previous value
+			res[current] += "," + item
+			continue
+		}
+
+		if _, ok := res[key]; ok {
+			return nil, fmt.Errorf("option %s is set twice", key)
+		}
+
+		value := strings.TrimPrefix(item, key)
+
+		switch {
+		case takesValue[key] && !strings.HasPrefix(value, "="):
+			return nil, fmt.Errorf("option %s expects a value", key)
+		case !takesValue[key] && value != "":
+			return nil, fmt.Errorf("option %s doesn't take a value", key)
+		}
+
+		current, hasValue = key, takesValue[key]
+		res[key] = strings.TrimPrefix(value, "=")
+	}
+
+	if value, ok := res[Separator]; ok {
+		if res.Has(Split) {
+			return nil, fmt.Errorf("options %s and %s can't be both set", Separator, Split)
+		}
+
+		delete(res, Separator)
+		res[Split] = value
+	}
+
+	for _, key := range []string{Name, Setter, Split, Layout, OneOf, Encoding} {
+		if value, ok := res[key]; ok && value == "" {
+			return nil, fmt.Errorf("option %s expects a value", key)
+		}
+	}
+
+	if value, ok := res[Encoding]; ok && value != Base64 && value != Hex {
+		return nil, fmt.Errorf("unknown encoding %s, expected %s or %s", value, Base64, Hex)
+	}
+
+	return res, nil
+}
+
+// VariableName names the variable of given path: words of each element are
+// split on case changes, upper cased, then joined using separator. Words made
+// of separators only, like in a APP_TENANTS prefix, are kept once.
+func VariableName(separator string, path ...string) string {
+	words := make([]string, 0, len(path))
+
+	for _, element := range path {
+		for _, w := range camelcase.Split(element) {
+			if separator != "" && strings.Trim(w, separator) == "" {
+				continue
+			}
+
+			words = append(words, w)
+		}
+	}
+
+	return strings.ToUpper(strings.Join(words, separator))
+}
@@ -0,0 +1,38 @@
+package tags
+
+import "testing"
+
+func TestVariableName(t *testing.T) {
+	for _, test := range []struct {
+		Label       string
+		Separator   string
+		Path        []string
+		Expectation string
+	}{
+		{
+			Label:       "WithCamelCase",
+			Separator:   "_",
+			Path:        []string{"Database", "MaxConns"},
+			Expectation: "DATABASE_MAX_CONNS",
+		},
+		{
+			Label:       "WithSeparatorInPrefix",
+			Separator:   "_",
+			Path:        []string{"APP_TENANTS", "Host"},
+			Expectation: "APP_TENANTS_HOST",
+		},
+		{
+			Label:       "WithEmptySeparator",
+			Separator:   "",
+			Path:        []string{"Database", "Host"},
+			Expectation: "DATABASEHOST",
+		},
+	} {
+		t.Run(test.Label, func(t *testing.T) {
+			if result := VariableName(test.Separator, test.Path...); result != test.Expectation {
+				t.Logf("Expected [%s], got [%s]", test.Expectation, result)
+				t.Fail()
+			}
+		})
+	}
+}
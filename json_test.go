@@ -0,0 +1,79 @@
+package envconfig
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type jsonTagConfig struct {
+	Labels   map[string]string        `envconfig:"json"`
+	Timeouts map[string]time.Duration `envconfig:"json"`
+	Ports    map[int]bool             `envconfig:"json"`
+	Backends map[string]struct {
+		Host string
+		Port int
+	} `envconfig:"json"`
+	Weights []float64 `envconfig:"json"`
+}
+
+func TestLoadWithJSONTag(t *testing.T) {
+	testCases := []struct {
+		Label       string
+		Env         mapSource
+		Expectation jsonTagConfig
+		Error       ErrorCode
+	}{
+		{
+			"WithValues",
+			mapSource{
+				"GROOT_LABELS":   `{"a": "b", "c": "d"}`,
+				"GROOT_TIMEOUTS": `{"read": "5s", "write": "1m"}`,
+				"GROOT_PORTS":    `{"80": true, "443": false}`,
+				"GROOT_BACKENDS": `{"api": {"host": "localhost", "port": 8080}}`,
+				"GROOT_WEIGHTS":  `[0.5, 0.25]`,
+			},
+			jsonTagConfig{
+				Labels:   map[string]string{"a": "b", "c": "d"},
+				Timeouts: map[string]time.Duration{"read": 5 * time.Second, "write": time.Minute},
+				Ports:    map[int]bool{80: true, 443: false},
+				Backends: map[string]struct {
+					Host string
+					Port int
+				}{"api": {"localhost", 8080}},
+				Weights: []float64{0.5, 0.25},
+			},
+			"",
+		},
+		{"WithInvalidJSON", mapSource{"GROOT_LABELS": "a=b"}, jsonTagConfig{}, CodeParseFailure},
+		{"WithInvalidKey", mapSource{"GROOT_PORTS": `{"http": true}`}, jsonTagConfig{}, CodeParseFailure},
+		{"WithInvalidValue", mapSource{"GROOT_TIMEOUTS": `{"read": "soon"}`}, jsonTagConfig{}, CodeParseFailure},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			var result jsonTagConfig
+
+			err := New("GROOT", "_", WithSource(testCase.Env)).Load(&result)
+
+			if testCase.Error != "" {
+				if code := CodeOf(err); code != testCase.Error {
+					t.Logf("Expected code %s, got [%v]", testCase.Error, err)
+					t.Fail()
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Logf("Wasn't expecting an error, got [%v]", err)
+				t.FailNow()
+			}
+
+			if !reflect.DeepEqual(result, testCase.Expectation) {
+				t.Logf("Expected %+v got %+v", testCase.Expectation, result)
+				t.Fail()
+			}
+		})
+	}
+}
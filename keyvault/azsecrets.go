@@ -0,0 +1,66 @@
+package keyvault
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+)
+
+// NewAzureClient returns a Client using given Key Vault secrets client.
+func NewAzureClient(client *azsecrets.Client) Client {
+	return azureClient{client}
+}
+
+type azureClient struct {
+	client *azsecrets.Client
+}
+
+func (c azureClient) GetSecret(ctx context.Context, name string) (string, bool, error) {
+	resp, err := c.client.GetSecret(ctx, name, "", nil)
+
+	var respErr *azcore.ResponseError
+
+	if errors.As(err, &respErr) && respErr.StatusCode == http.StatusNotFound {
+		return "", false, nil
+	}
+
+	if err != nil {
+		return "", false, err
+	}
+
+	if resp.Value == nil {
+		return "", false, nil
+	}
+
+	return *resp.Value, true, nil
+}
+
+func (c azureClient) ListSecrets(ctx context.Context) ([]string, error) {
+	res := []string{}
+	pager := c.client.NewListSecretPropertiesPager(nil)
+
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, secret := range page.Value {
+			if secret.ID == nil {
+				continue
+			}
+
+			if secret.Attributes != nil && secret.Attributes.Enabled != nil && !*secret.Attributes.Enabled {
+				continue
+			}
+
+			res = append(res, secret.ID.Name())
+		}
+	}
+
+	return res, nil
+}
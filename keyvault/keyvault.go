@@ -0,0 +1,91 @@
+// Package keyvault serves configuration values stored as Azure Key Vault
+// secrets. Its Source is meant to be given to envconfig.WithSource:
+//
+//	envconfig.New("APP", "_", envconfig.WithSource(keyvault.Source(client)))
+package keyvault
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jlevesy/envconfig/sources"
+)
+
+// Client reads secrets of a vault.
+// It is a small subset of a Key Vault client, so any SDK can be plugged in.
+type Client interface {
+	// GetSecret returns the current value of the secret with given name, the
+	// returned boolean reports if the secret exists.
+	GetSecret(ctx context.Context, name string) (string, bool, error)
+	// ListSecrets returns the names of enabled secrets.
+	ListSecrets(ctx context.Context) ([]string, error)
+}
+
+// Source returns a Source serving variables stored as secrets. Secret names
+// only allow alphanumerics and dashes, so underscores of variables are turned
+// into dashes: APP_DB_PASSWORD is read from the APP-DB-PASSWORD secret.
+// Secret names are case insensitive, listed names are upper cased.
+func Source(client Client) sources.ContextSource {
+	return &source{client}
+}
+
+type source struct {
+	client Client
+}
+
+func (s *source) Lookup(key string) (string, bool, error) {
+	return s.LookupContext(context.Background(), key)
+}
+
+func (s *source) LookupContext(ctx context.Context, key string) (string, bool, error) {
+	name, ok := secretName(key)
+
+	if !ok {
+		return "", false, nil
+	}
+
+	return s.client.GetSecret(ctx, name)
+}
+
+func (s *source) Keys(prefix string) ([]string, error) {
+	return s.KeysContext(context.Background(), prefix)
+}
+
+func (s *source) KeysContext(ctx context.Context, prefix string) ([]string, error) {
+	names, err := s.client.ListSecrets(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := []string{}
+
+	for _, name := range names {
+		key := strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+
+		if strings.HasPrefix(key, strings.ToUpper(prefix)) {
+			res = append(res, key)
+		}
+	}
+
+	return res, nil
+}
+
+// secretName returns the name of the secret holding the variable with given
+// name. The second result is false if the variable can't be stored in a
+// secret.
+func secretName(key string) (string, bool) {
+	name := strings.ReplaceAll(key, "_", "-")
+
+	if name == "" || len(name) > 127 {
+		return "", false
+	}
+
+	for _, c := range name {
+		if c != '-' && !(c >= 'a' && c <= 'z') && !(c >= 'A' && c <= 'Z') && !(c >= '0' && c <= '9') {
+			return "", false
+		}
+	}
+
+	return name, true
+}
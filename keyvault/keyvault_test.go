@@ -0,0 +1,84 @@
+package keyvault
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// vault is a Client backed by a map of secrets, names being case insensitive
+type vault map[string]string
+
+func (v vault) GetSecret(ctx context.Context, name string) (string, bool, error) {
+	for secretName, value := range v {
+		if strings.EqualFold(secretName, name) {
+			return value, true, nil
+		}
+	}
+
+	return "", false, nil
+}
+
+func (v vault) ListSecrets(ctx context.Context) ([]string, error) {
+	res := []string{}
+
+	for name := range v {
+		res = append(res, name)
+	}
+
+	return res, nil
+}
+
+func TestSource(t *testing.T) {
+	source := Source(vault{
+		"APP-DB-PASSWORD":   "secret",
+		"app-routes-foo":    "foo",
+		"APP-ROUTES-BAR":    "bar",
+		"OTHER-ROUTES-FOO":  "foo",
+		"APP-DB-REPLICA-01": "replica",
+	})
+
+	testCases := []struct {
+		Label       string
+		Key         string
+		Expectation string
+		Found       bool
+	}{
+		{"WithDefinedSecret", "APP_DB_PASSWORD", "secret", true},
+		{"WithCaseInsensitiveName", "APP_ROUTES_FOO", "foo", true},
+		{"WithUndefinedSecret", "APP_DB_USER", "", false},
+		{"WithInvalidName", "APP.DB", "", false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			value, found, err := source.Lookup(testCase.Key)
+
+			if err != nil {
+				t.Logf("Wasn't expecting an error, got [%v]", err)
+				t.FailNow()
+			}
+
+			if value != testCase.Expectation || found != testCase.Found {
+				t.Logf("Expected %q %t, got %q %t", testCase.Expectation, testCase.Found, value, found)
+				t.Fail()
+			}
+		})
+	}
+
+	keys, err := source.Keys("APP_ROUTES_")
+
+	if err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	sort.Strings(keys)
+
+	if expectation := []string{"APP_ROUTES_BAR", "APP_ROUTES_FOO"}; !reflect.DeepEqual(keys, expectation) {
+		t.Logf("Expected %v got %v", expectation, keys)
+		t.Fail()
+	}
+}
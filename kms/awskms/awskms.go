@@ -0,0 +1,30 @@
+// Package awskms plugs AWS KMS into the kms package. It is a separate module,
+// so the AWS SDK isn't a dependency of programs which don't need it.
+package awskms
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+
+	envkms "github.com/jlevesy/envconfig/kms"
+)
+
+// NewDecryptor returns a Decryptor using given AWS KMS client
+func NewDecryptor(client *kms.Client) *envkms.Decryptor {
+	return envkms.NewDecryptor(awsClient{client})
+}
+
+type awsClient struct {
+	client *kms.Client
+}
+
+func (c awsClient) Decrypt(ctx context.Context, ciphertextBlob []byte) ([]byte, error) {
+	out, err := c.client.Decrypt(ctx, &kms.DecryptInput{CiphertextBlob: ciphertextBlob})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return out.Plaintext, nil
+}
@@ -0,0 +1,51 @@
+package awskms
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+)
+
+func TestNewDecryptor(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var input struct {
+			CiphertextBlob []byte
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"Plaintext": []byte("plain:" + string(input.CiphertextBlob)),
+		})
+	}))
+	defer server.Close()
+
+	client := kms.New(kms.Options{
+		Region:       "us-east-1",
+		BaseEndpoint: aws.String(server.URL),
+		Credentials:  aws.AnonymousCredentials{},
+	})
+
+	ciphertext := base64.StdEncoding.EncodeToString([]byte("secret"))
+	result, err := NewDecryptor(client).Decrypt(context.Background(), ciphertext)
+
+	if err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	if result != "plain:secret" {
+		t.Logf("Expected [plain:secret], got [%s]", result)
+		t.Fail()
+	}
+}
@@ -0,0 +1,17 @@
+module github.com/jlevesy/envconfig/kms/awskms
+
+go 1.20
+
+require (
+	github.com/aws/aws-sdk-go-v2 v1.26.1
+	github.com/aws/aws-sdk-go-v2/service/kms v1.31.0
+	github.com/jlevesy/envconfig v0.0.0
+)
+
+require (
+	github.com/aws/aws-sdk-go-v2/internal/configsources v1.3.5 // indirect
+	github.com/aws/aws-sdk-go-v2/internal/endpoints/v2 v2.6.5 // indirect
+	github.com/aws/smithy-go v1.20.2 // indirect
+)
+
+replace github.com/jlevesy/envconfig => ../../
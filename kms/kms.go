@@ -0,0 +1,54 @@
+// Package kms decrypts configuration values encrypted using a key management
+// service like AWS KMS. Its Decryptor is meant to be given to
+// envconfig.WithDecryptor:
+//
+//	envconfig.New("APP", "_", envconfig.WithDecryptor("enc:kms:", kms.NewDecryptor(client)))
+package kms
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// Client decrypts a ciphertext blob.
+// It is a small subset of a KMS client, so any SDK can be plugged in.
+type Client interface {
+	Decrypt(ctx context.Context, ciphertextBlob []byte) ([]byte, error)
+}
+
+// ClientFunc is a function implementing Client
+type ClientFunc func(ctx context.Context, ciphertextBlob []byte) ([]byte, error)
+
+// Decrypt calls f(ctx, ciphertextBlob)
+func (f ClientFunc) Decrypt(ctx context.Context, ciphertextBlob []byte) ([]byte, error) {
+	return f(ctx, ciphertextBlob)
+}
+
+// Decryptor decrypts base64 encoded ciphertexts using a Client
+type Decryptor struct {
+	client Client
+}
+
+// NewDecryptor returns a Decryptor using given client
+func NewDecryptor(client Client) *Decryptor {
+	return &Decryptor{client}
+}
+
+// Decrypt decodes given base64 ciphertext, then decrypts it
+func (d *Decryptor) Decrypt(ctx context.Context, ciphertext string) (string, error) {
+	blob, err := base64.StdEncoding.DecodeString(strings.TrimSpace(ciphertext))
+
+	if err != nil {
+		return "", fmt.Errorf("Invalid ciphertext: %v", err)
+	}
+
+	plaintext, err := d.client.Decrypt(ctx, blob)
+
+	if err != nil {
+		return "", err
+	}
+
+	return string(plaintext), nil
+}
@@ -0,0 +1,55 @@
+package kms
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+)
+
+func TestDecrypt(t *testing.T) {
+	errKMS := errors.New("access denied")
+
+	client := ClientFunc(func(ctx context.Context, blob []byte) ([]byte, error) {
+		if string(blob) == "denied" {
+			return nil, errKMS
+		}
+
+		return []byte("plain:" + string(blob)), nil
+	})
+
+	testCases := []struct {
+		Label       string
+		Ciphertext  string
+		Expectation string
+		ShouldFail  bool
+	}{
+		{"WithValidCiphertext", base64.StdEncoding.EncodeToString([]byte("secret")), "plain:secret", false},
+		{"WithInvalidBase64", "not base64!", "", true},
+		{"WithClientError", base64.StdEncoding.EncodeToString([]byte("denied")), "", true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			result, err := NewDecryptor(client).Decrypt(context.Background(), testCase.Ciphertext)
+
+			if testCase.ShouldFail {
+				if err == nil {
+					t.Log("Expected an error, got nothing")
+					t.Fail()
+				}
+				return
+			}
+
+			if err != nil {
+				t.Logf("Wasn't expecting an error, got [%v]", err)
+				t.FailNow()
+			}
+
+			if result != testCase.Expectation {
+				t.Logf("Expected %s got %s", testCase.Expectation, result)
+				t.Fail()
+			}
+		})
+	}
+}
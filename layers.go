@@ -0,0 +1,146 @@
+package envconfig
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jlevesy/envconfig/sources"
+)
+
+// MergePolicy defines how values found in a source combine with values set by
+// previously applied sources.
+type MergePolicy int
+
+const (
+	// Overwrite replaces values set by previous sources
+	Overwrite MergePolicy = iota
+	// FillOnly only sets values left unset by previous sources
+	FillOnly
+)
+
+// defaultSourceName is the name of the process environment source used when no
+// source is declared
+const defaultSourceName = "env"
+
+// layer is a source applied during a Load
+type layer struct {
+	name    string
+	source  sources.Source
+	policy  MergePolicy
+	timeout time.Duration
+
+	// warnings collects warnings of the current load
+	warnings *[]string
+}
+
+func (l layer) warn(format string, args ...interface{}) {
+	if l.warnings != nil {
+		*l.warnings = append(*l.warnings, fmt.Sprintf(format, args...))
+	}
+}
+
+// SourceOption customizes how a source is applied during a Load
+type SourceOption func(*layer)
+
+// WithMergePolicy sets the merge policy of a source, default is Overwrite.
+func WithMergePolicy(policy MergePolicy) SourceOption {
+	return func(l *layer) {
+		l.policy = policy
+	}
+}
+
+// WithName names a source, fields tagged with `source:"<name>"` are only looked
+// up in the source with this name.
+func WithName(name string) SourceOption {
+	return func(l *layer) {
+		l.name = name
+	}
+}
+
+// WithTimeout bounds the time spent looking values up in a source during a
+// Load, by default lookups are only bounded by the context given to LoadContext.
+func WithTimeout(timeout time.Duration) SourceOption {
+	return func(l *layer) {
+		l.timeout = timeout
+	}
+}
+
+// WithSource adds a source to the loader.
+// Sources are applied in declaration order: by default a source overwrites
+// values set by previous ones, this can be changed using WithMergePolicy.
+// If no source is declared, the loader reads the process environment, using a
+// source named "env".
+func WithSource(source sources.Source, opts ...SourceOption) Option {
+	return func(e *envConfig) {
+		l := layer{source: source, policy: Overwrite}
+
+		for _, opt := range opts {
+			opt(&l)
+		}
+
+		e.layers = append(e.layers, l)
+	}
+}
+
+func (e *envConfig) sourceLayers() []layer {
+	if len(e.layers) == 0 {
+		return []layer{{name: defaultSourceName, source: sources.Env(), policy: Overwrite}}
+	}
+
+	return e.layers
+}
+
+// unassignedValues filters out values whose path has already been assigned
+func unassignedValues(values []*envValue, assigned map[string]ReportEntry) []*envValue {
+	res := make([]*envValue, 0, len(values))
+
+	for _, v := range values {
+		if _, ok := assigned[v.Path.key()]; ok {
+			continue
+		}
+
+		res = append(res, v)
+	}
+
+	return res
+}
+
+// lookupVariable looks given variable up in every source layer, outside of a
+// load, applying merge policies and timeouts. If restricted, only the layer
+// named sourceName is used.
+func (e *envConfig) lookupVariable(ctx context.Context, name, sourceName string, restricted bool) (string, bool, error) {
+	var (
+		value string
+		found bool
+	)
+
+	for _, l := range e.sourceLayers() {
+		if restricted && l.name != sourceName {
+			continue
+		}
+
+		if found && l.policy == FillOnly {
+			continue
+		}
+
+		lookupCtx, cancel := ctx, context.CancelFunc(func() {})
+
+		if l.timeout > 0 {
+			lookupCtx, cancel = context.WithTimeout(ctx, l.timeout)
+		}
+
+		v, ok, err := sources.WithContext(lookupCtx, l.source).Lookup(name)
+		cancel()
+
+		if err != nil {
+			return "", false, sourceError(err)
+		}
+
+		if ok {
+			value, found = v, true
+		}
+	}
+
+	return value, found, nil
+}
@@ -0,0 +1,299 @@
+package envconfig
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jlevesy/envconfig/sources"
+)
+
+// mapSource is a sources.Source backed by a map
+type mapSource map[string]string
+
+func (m mapSource) Lookup(key string) (string, bool, error) {
+	value, ok := m[key]
+	return value, ok, nil
+}
+
+func (m mapSource) Keys(prefix string) ([]string, error) {
+	res := []string{}
+
+	for key := range m {
+		if strings.HasPrefix(key, prefix) {
+			res = append(res, key)
+		}
+	}
+
+	return res, nil
+}
+
+func TestLoadWithSources(t *testing.T) {
+	base := mapSource{
+		"APP_STRING_VALUE": "FROM_BASE",
+		"APP_INT_VALUE":    "1",
+	}
+	override := mapSource{
+		"APP_STRING_VALUE": "FROM_OVERRIDE",
+	}
+	defaults := mapSource{
+		"APP_STRING_VALUE": "FROM_DEFAULTS",
+		"APP_INT_VALUE":    "2",
+		"APP_BOOL_VALUE":   "true",
+	}
+
+	testCases := []struct {
+		Label       string
+		Options     []Option
+		Expectation basicAppConfig
+	}{
+		{
+			"WithOverwritingSources",
+			[]Option{
+				WithSource(base),
+				WithSource(override),
+			},
+			basicAppConfig{StringValue: "FROM_OVERRIDE", IntValue: 1},
+		},
+		{
+			"WithFillOnlySource",
+			[]Option{
+				WithSource(base),
+				WithSource(override),
+				WithSource(defaults, WithMergePolicy(FillOnly)),
+			},
+			basicAppConfig{StringValue: "FROM_OVERRIDE", IntValue: 1, BoolValue: true},
+		},
+		{
+			"WithFillOnlyFirstSource",
+			[]Option{
+				WithSource(defaults, WithMergePolicy(FillOnly)),
+				WithSource(override),
+			},
+			basicAppConfig{StringValue: "FROM_OVERRIDE", IntValue: 2, BoolValue: true},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			result := basicAppConfig{}
+
+			if err := New("APP", "_", testCase.Options...).Load(&result); err != nil {
+				t.Logf("Wasn't expecting an error, got [%v]", err)
+				t.FailNow()
+			}
+
+			if result != testCase.Expectation {
+				t.Logf("Invalid assignation, expected %v got %v", testCase.Expectation, result)
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestLoadWithoutSourcesReadsEnv(t *testing.T) {
+	env := map[string]string{"APP_STRING_VALUE": "FROM_ENV"}
+	setupEnv(env)
+	defer cleanupEnv(env)
+
+	result := basicAppConfig{}
+
+	if err := New("APP", "_").Load(&result); err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	if result.StringValue != "FROM_ENV" {
+		t.Logf("Expected [FROM_ENV] got [%s]", result.StringValue)
+		t.Fail()
+	}
+
+	result = basicAppConfig{}
+
+	if err := New("APP", "_", WithSource(sources.Env())).Load(&result); err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	if result.StringValue != "FROM_ENV" {
+		t.Logf("Expected [FROM_ENV] got [%s]", result.StringValue)
+		t.Fail()
+	}
+}
+
+// blockingSource never answers until released
+type blockingSource struct {
+	release chan struct{}
+}
+
+func (b blockingSource) Lookup(key string) (string, bool, error) {
+	<-b.release
+	return "", false, nil
+}
+
+func (b blockingSource) Keys(prefix string) ([]string, error) {
+	<-b.release
+	return nil, nil
+}
+
+func TestLoadWithSourceTimeout(t *testing.T) {
+	slow := blockingSource{make(chan struct{})}
+	defer close(slow.release)
+
+	err := New(
+		"APP",
+		"_",
+		WithSource(mapSource{"APP_STRING_VALUE": "FOO"}),
+		WithSource(slow, WithTimeout(10*time.Millisecond)),
+	).Load(&basicAppConfig{})
+
+	if !errors.Is(err, context.DeadlineExceeded) || CodeOf(err) != CodeTimeout {
+		t.Logf("Expected [%v] with code %s got [%v]", context.DeadlineExceeded, CodeTimeout, err)
+		t.Fail()
+	}
+}
+
+func TestLoadContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := New("APP", "_", WithSource(mapSource{})).(ContextLoader).LoadContext(ctx, &basicAppConfig{})
+
+	if !errors.Is(err, context.Canceled) || CodeOf(err) != CodeCanceled {
+		t.Logf("Expected [%v] with code %s got [%v]", context.Canceled, CodeCanceled, err)
+		t.Fail()
+	}
+}
+
+type sourceTaggedConfig struct {
+	Password string `source:"vault"`
+	Username string `source:"env"`
+	Host     string
+}
+
+func TestLoadWithSourceTag(t *testing.T) {
+	vault := mapSource{
+		"APP_PASSWORD": "FROM_VAULT",
+		"APP_USERNAME": "FROM_VAULT",
+		"APP_HOST":     "FROM_VAULT",
+	}
+	env := mapSource{
+		"APP_PASSWORD": "FROM_ENV",
+		"APP_USERNAME": "FROM_ENV",
+	}
+
+	result := sourceTaggedConfig{}
+
+	err := New(
+		"APP",
+		"_",
+		WithSource(vault, WithName("vault")),
+		WithSource(env, WithName("env")),
+	).Load(&result)
+
+	if err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	expectation := sourceTaggedConfig{
+		Password: "FROM_VAULT",
+		Username: "FROM_ENV",
+		Host:     "FROM_VAULT",
+	}
+
+	if result != expectation {
+		t.Logf("Invalid assignation, expected %v got %v", expectation, result)
+		t.Fail()
+	}
+}
+
+// bulkMapSource is a mapSource supporting bulk lookups, which records calls
+type bulkMapSource struct {
+	mapSource
+	lookups int
+	bulks   int
+}
+
+func (b *bulkMapSource) Lookup(key string) (string, bool, error) {
+	b.lookups++
+	return b.mapSource.Lookup(key)
+}
+
+func (b *bulkMapSource) BulkLookup(keys []string) (map[string]string, error) {
+	b.bulks++
+
+	res := map[string]string{}
+
+	for _, key := range keys {
+		if value, ok := b.mapSource[key]; ok {
+			res[key] = value
+		}
+	}
+
+	return res, nil
+}
+
+func TestLoadWithBulkSource(t *testing.T) {
+	source := &bulkMapSource{
+		mapSource: mapSource{"APP_STRING_VALUE": "FOO", "APP_INT_VALUE": "1"},
+	}
+
+	var result basicAppConfig
+
+	if err := New("APP", "_", WithSource(source)).Load(&result); err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	if result.StringValue != "FOO" || result.IntValue != 1 {
+		t.Logf("Unexpected result %+v", result)
+		t.Fail()
+	}
+
+	if source.bulks != 1 || source.lookups != 0 {
+		t.Logf("Expected a single bulk lookup, got %d bulk lookups and %d lookups", source.bulks, source.lookups)
+		t.Fail()
+	}
+}
+
+// snapshotMapSource is a mapSource which records snapshots, and fails lookups
+// made outside of them
+type snapshotMapSource struct {
+	mapSource
+	snapshots int
+}
+
+func (s *snapshotMapSource) Lookup(key string) (string, bool, error) {
+	return "", false, errors.New("Lookup outside of a snapshot")
+}
+
+func (s *snapshotMapSource) Snapshot() sources.Source {
+	s.snapshots++
+	return s.mapSource
+}
+
+func TestLoadWithSnapshotter(t *testing.T) {
+	source := &snapshotMapSource{
+		mapSource: mapSource{"APP_STRING_VALUE": "FOO", "APP_INT_VALUE": "1"},
+	}
+
+	var result basicAppConfig
+
+	if err := New("APP", "_", WithSource(source)).Load(&result); err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	if result.StringValue != "FOO" || result.IntValue != 1 {
+		t.Logf("Unexpected result %+v", result)
+		t.Fail()
+	}
+
+	if source.snapshots != 1 {
+		t.Logf("Expected a single snapshot, got %d", source.snapshots)
+		t.Fail()
+	}
+}
@@ -0,0 +1,226 @@
+package envconfig
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jlevesy/envconfig/setter"
+)
+
+const ttlTag = "ttl"
+
+// Lazy is a field type whose value is only looked up in sources on first
+// access, then cached. It suits rarely used secrets which shouldn't be pulled
+// from a remote store on every startup.
+//
+//	type AppConfig struct {
+//		ReportingToken envconfig.Lazy[string] `ttl:"1h"`
+//	}
+//
+// The cached value expires after the duration given by the optional ttl
+// struct tag, or when Refresh is called.
+// A Lazy field is bound to the loader which loaded its struct, it must not be
+// copied once loaded.
+type Lazy[T any] struct {
+	mu       sync.Mutex
+	resolve  func(ctx context.Context, dst reflect.Value) error
+	ttl      time.Duration
+	value    T
+	resolved bool
+	expires  time.Time
+}
+
+// Get returns the value, looking it up in sources if it isn't cached.
+// An undefined variable resolves to the zero value of T.
+func (l *Lazy[T]) Get(ctx context.Context) (T, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.resolved && (l.ttl == 0 || time.Now().Before(l.expires)) {
+		return l.value, nil
+	}
+
+	var value T
+
+	if l.resolve == nil {
+		return value, errors.New("Lazy value isn't bound to a loader, please load its struct first")
+	}
+
+	if err := l.resolve(ctx, reflect.ValueOf(&value).Elem()); err != nil {
+		return value, err
+	}
+
+	l.value = value
+	l.resolved = true
+	l.expires = time.Now().Add(l.ttl)
+
+	return value, nil
+}
+
+// Refresh drops the cached value, next call to Get looks it up again
+func (l *Lazy[T]) Refresh() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.resolved = false
+}
+
+func (l *Lazy[T]) bindLazy(resolve func(ctx context.Context, dst reflect.Value) error, ttl time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.resolve = resolve
+	l.ttl = ttl
+	l.resolved = false
+}
+
+func (l *Lazy[T]) lazyElemType() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// lazyBinder is implemented by pointers to Lazy
+type lazyBinder interface {
+	bindLazy(resolve func(ctx context.Context, dst reflect.Value) error, ttl time.Duration)
+	lazyElemType() reflect.Type
+}
+
+var lazyBinderType = reflect.TypeOf((*lazyBinder)(nil)).Elem()
+
+func isLazy(valType reflect.Type) bool {
+	return reflect.PtrTo(valType).Implements(lazyBinderType)
+}
+
+// isLazyPath reports if the field at given path of configType is a Lazy
+func isLazyPath(configType reflect.Type, fieldPath path) bool {
+	valType := configType
+
+	for _, name := range fieldPath {
+		field, ok := indirectedType(valType).FieldByName(name)
+
+		if !ok {
+			return false
+		}
+
+		valType = field.Type
+	}
+
+	return isLazy(indirectedType(valType))
+}
+
+// bindLazyFields binds Lazy fields of given struct value, named name, to the
+// loader.
+func (e *envConfig) bindLazyFields(val reflect.Value, currentPath path, name string) error {
+	valType := val.Type()
+
+	for i := 0; i < valType.NumField(); i++ {
+		field := valType.Field(i)
+		fieldVal := val.Field(i)
+		fieldPath := currentPath
+		fieldName := name
+
+		if e.skipsField(field) {
+			continue
+		}
+
+		if !field.Anonymous {
+			fieldPath = append(currentPath.clone(), field.Name)
+			fieldName, _ = e.fieldVariable(name, field)
+		}
+
+		for fieldVal.Kind() == reflect.Ptr && !fieldVal.IsNil() {
+			fieldVal = fieldVal.Elem()
+		}
+
+		if fieldVal.Kind() != reflect.Struct || !fieldVal.CanAddr() {
+			continue
+		}
+
+		if !isLazy(fieldVal.Type()) {
+			if err := e.bindLazyFields(fieldVal, fieldPath, fieldName); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if !fieldVal.Addr().CanInterface() {
+			continue
+		}
+
+		var ttl time.Duration
+
+		if t, ok := field.Tag.Lookup(ttlTag); ok {
+			var err error
+
+			if ttl, err = time.ParseDuration(t); err != nil {
+				return err
+			}
+		}
+
+		tag := field.Tag
+
+		fieldVal.Addr().Interface().(lazyBinder).bindLazy(
+			func(ctx context.Context, dst reflect.Value) error {
+				return e.resolveLazy(ctx, fieldPath, fieldName, tag, dst)
+			},
+			ttl,
+		)
+	}
+
+	return nil
+}
+
+// resolveLazy looks the variable of the value at given path up, applying
+// sources like a Load does, then assigns it to dst. tag is the tag of the Lazy
+// field.
+func (e *envConfig) resolveLazy(ctx context.Context, fieldPath path, variable string, tag reflect.StructTag, dst reflect.Value) error {
+	sourceName, restricted := tag.Lookup(sourceTag)
+
+	value, found, err := e.lookupVariable(ctx, variable, sourceName, restricted)
+
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return nil
+	}
+
+	value, err = e.openEnvelope(ctx, value)
+
+	if err != nil {
+		return withCode(CodeEnvelope, err)
+	}
+
+	// Only the tag of the Lazy field is known here, secrets are flagged on it
+	rawValue := value
+
+	if tag.Get(secretTag) == "true" {
+		rawValue = redacted
+	}
+
+	if err := e.checkValue(value); err != nil {
+		return &LoadError{variable, strings.Join(fieldPath, "."), rawValue, err}
+	}
+
+	sc := setter.SetContext{RawValue: value, Path: fieldPath.clone(), Variable: variable, Tag: tag}
+	dst, _, err = e.allocate(dst, dst.Type())
+
+	if err == nil {
+		if dst.Kind() == reflect.Struct {
+			err = e.setStruct(dst, sc)
+		} else {
+			err = e.setValueWithContext(dst, sc)
+		}
+	}
+
+	if err != nil {
+		return &LoadError{variable, strings.Join(fieldPath, "."), rawValue, err}
+	}
+
+	return nil
+}
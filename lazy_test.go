@@ -0,0 +1,150 @@
+package envconfig
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// lookupCounter is a mapSource counting lookups per key
+type lookupCounter struct {
+	mapSource
+	lookups map[string]int
+}
+
+func (c *lookupCounter) Lookup(key string) (string, bool, error) {
+	c.lookups[key]++
+	return c.mapSource.Lookup(key)
+}
+
+type lazyCredentials struct {
+	User     string
+	Password string
+}
+
+type lazyConfig struct {
+	Host        string
+	Token       Lazy[string]
+	Credentials Lazy[lazyCredentials] `ttl:"1h"`
+	Missing     Lazy[int]
+	Nested      struct {
+		Timeout Lazy[*time.Duration]
+	}
+}
+
+func TestLoadLazy(t *testing.T) {
+	source := &lookupCounter{
+		mapSource: mapSource{
+			"APP_HOST":                 "localhost",
+			"APP_TOKEN":                "s3cr3t",
+			"APP_CREDENTIALS":          `{"User": "groot", "Password": "iamgroot"}`,
+			"APP_NESTED_TIMEOUT":       "5s",
+			"APP_CREDENTIALS_PASSWORD": "unused",
+		},
+		lookups: map[string]int{},
+	}
+
+	var result lazyConfig
+
+	if err := New("APP", "_", WithSource(source)).Load(&result); err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	if result.Host != "localhost" {
+		t.Logf("Expected host to be loaded, got %q", result.Host)
+		t.Fail()
+	}
+
+	if source.lookups["APP_TOKEN"] != 0 || source.lookups["APP_CREDENTIALS"] != 0 {
+		t.Logf("Expected lazy values not to be looked up on load, got %v", source.lookups)
+		t.FailNow()
+	}
+
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		token, err := result.Token.Get(ctx)
+
+		if err != nil || token != "s3cr3t" {
+			t.Logf("Unexpected token %q, error [%v]", token, err)
+			t.Fail()
+		}
+	}
+
+	if source.lookups["APP_TOKEN"] != 1 {
+		t.Logf("Expected token to be looked up once, got %d", source.lookups["APP_TOKEN"])
+		t.Fail()
+	}
+
+	source.mapSource["APP_TOKEN"] = "rotated"
+	result.Token.Refresh()
+
+	if token, _ := result.Token.Get(ctx); token != "rotated" {
+		t.Logf("Expected refreshed token, got %q", token)
+		t.Fail()
+	}
+
+	credentials, err := result.Credentials.Get(ctx)
+
+	if err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	if !reflect.DeepEqual(credentials, lazyCredentials{"groot", "iamgroot"}) {
+		t.Logf("Unexpected credentials %v", credentials)
+		t.Fail()
+	}
+
+	if missing, err := result.Missing.Get(ctx); err != nil || missing != 0 {
+		t.Logf("Expected a zero value, got %d, error [%v]", missing, err)
+		t.Fail()
+	}
+
+	timeout, err := result.Nested.Timeout.Get(ctx)
+
+	if err != nil || timeout == nil || *timeout != 5*time.Second {
+		t.Logf("Unexpected timeout %v, error [%v]", timeout, err)
+		t.Fail()
+	}
+}
+
+func TestLazyNotBound(t *testing.T) {
+	var value Lazy[string]
+
+	if _, err := value.Get(context.Background()); err == nil {
+		t.Log("Expected an error, got nothing")
+		t.Fail()
+	}
+}
+
+func TestDescribeLazy(t *testing.T) {
+	vars, err := New("APP", "_").(Describer).Describe(&lazyConfig{})
+
+	if err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	expectation := map[string]reflect.Type{
+		"APP_HOST":           reflect.TypeOf(""),
+		"APP_TOKEN":          reflect.TypeOf(""),
+		"APP_CREDENTIALS":    reflect.TypeOf(lazyCredentials{}),
+		"APP_MISSING":        reflect.TypeOf(0),
+		"APP_NESTED_TIMEOUT": reflect.TypeOf(time.Duration(0)),
+	}
+
+	if len(vars) != len(expectation) {
+		t.Logf("Expected %d variables, got %v", len(expectation), vars)
+		t.FailNow()
+	}
+
+	for _, v := range vars {
+		if expectation[v.Name] != v.Type {
+			t.Logf("Unexpected type %v for variable %s", v.Type, v.Name)
+			t.Fail()
+		}
+	}
+}
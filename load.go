@@ -0,0 +1,26 @@
+package envconfig
+
+// Load loads a T using a loader built from given prefix, separator and
+// options, and returns it.
+func Load[T any](prefix, separator string, opts ...Option) (*T, error) {
+	res := new(T)
+
+	if err := New(prefix, separator, opts...).Load(res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// MustLoad is like Load, but panics if the configuration can't be loaded. It
+// suits configurations loaded once at startup, from main or a package level
+// variable.
+func MustLoad[T any](prefix, separator string, opts ...Option) *T {
+	res, err := Load[T](prefix, separator, opts...)
+
+	if err != nil {
+		panic(err)
+	}
+
+	return res
+}
@@ -0,0 +1,50 @@
+package envconfig
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLoadGeneric(t *testing.T) {
+	source := mapSource{
+		"GROOT_STRING_VALUE": "FOO",
+		"GROOT_INT_VALUE":    "10",
+	}
+
+	result, err := Load[basicAppConfig]("GROOT", "_", WithSource(source))
+
+	if err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	expectation := &basicAppConfig{StringValue: "FOO", IntValue: 10}
+
+	if !reflect.DeepEqual(result, expectation) {
+		t.Logf("Invalid assignation, expected %v got %v", expectation, result)
+		t.Fail()
+	}
+
+	if _, err := Load[basicAppConfig]("GROOT", "_", WithSource(mapSource{"GROOT_INT_VALUE": "groot"})); err == nil {
+		t.Log("Expected an error, got nothing")
+		t.Fail()
+	}
+}
+
+func TestMustLoad(t *testing.T) {
+	result := MustLoad[basicAppConfig]("GROOT", "_", WithSource(mapSource{"GROOT_BOOL_VALUE": "true"}))
+
+	if !result.BoolValue {
+		t.Logf("Expected BoolValue to be set, got %v", result)
+		t.Fail()
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Log("Expected a panic, got nothing")
+			t.Fail()
+		}
+	}()
+
+	MustLoad[basicAppConfig]("GROOT", "_", WithSource(mapSource{"GROOT_INT_VALUE": "groot"}))
+}
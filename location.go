@@ -0,0 +1,58 @@
+package envconfig
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/jlevesy/envconfig/setter"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// WithLocation sets the location date only and local time values, like
+// "2021-03-14" or "2021-03-14 15:09:26", are parsed in. Timestamps holding a
+// zone are not affected. By default, such values are parsed in UTC.
+func WithLocation(loc *time.Location) Option {
+	return func(e *envConfig) {
+		e.location = loc
+		e.RegisterSetter(timeType, setter.TimeLayouts(e.timeLocation(), e.timeLayouts...))
+	}
+}
+
+// WithTimeLayouts sets layouts time.Time values are parsed with when they
+// aren't RFC3339 timestamps, tried in order before the date only and local
+// time ones. Fields tagged with the layout option only use their own.
+func WithTimeLayouts(layouts ...string) Option {
+	return func(e *envConfig) {
+		e.timeLayouts = append(e.timeLayouts, layouts...)
+		e.RegisterSetter(timeType, setter.TimeLayouts(e.timeLocation(), e.timeLayouts...))
+	}
+}
+
+// timeLocation returns the location values lacking zone information are
+// parsed in.
+func (e *envConfig) timeLocation() *time.Location {
+	if e.location == nil {
+		return time.UTC
+	}
+
+	return e.location
+}
+
+// setTimeWithLayout parses the value of a field tagged with the layout option
+func (e *envConfig) setTimeWithLayout(val reflect.Value, layout string, sc setter.SetContext) error {
+	if val.Type() != timeType {
+		return withCode(CodeInvalidConfig, fmt.Errorf("The layout option only applies to time.Time fields, got a %v", val.Type()))
+	}
+
+	v, err := time.ParseInLocation(layout, sc.RawValue, e.timeLocation())
+
+	if err != nil {
+		return err
+	}
+
+	val.Set(reflect.ValueOf(v))
+
+	return nil
+}
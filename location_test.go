@@ -0,0 +1,156 @@
+package envconfig
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type timeConfig struct {
+	Date      time.Time
+	LocalTime time.Time
+	Timestamp time.Time
+}
+
+func TestLoadWithLocation(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+
+	if err != nil {
+		t.Skipf("Time zone database is not available: %v", err)
+	}
+
+	source := mapSource{
+		"APP_DATE":       "2021-03-14",
+		"APP_LOCAL_TIME": "2021-03-14 15:09:26",
+		"APP_TIMESTAMP":  "2021-03-14T15:09:26Z",
+	}
+
+	testCases := []struct {
+		Label    string
+		Options  []Option
+		Location *time.Location
+	}{
+		{"WithoutLocation", nil, time.UTC},
+		{"WithLocation", []Option{WithLocation(paris)}, paris},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			var result timeConfig
+
+			opts := append(testCase.Options, WithSource(source))
+
+			if err := New("APP", "_", opts...).Load(&result); err != nil {
+				t.Logf("Wasn't expecting an error, got [%v]", err)
+				t.FailNow()
+			}
+
+			expectations := map[time.Time]time.Time{
+				result.Date:      time.Date(2021, 3, 14, 0, 0, 0, 0, testCase.Location),
+				result.LocalTime: time.Date(2021, 3, 14, 15, 9, 26, 0, testCase.Location),
+				result.Timestamp: time.Date(2021, 3, 14, 15, 9, 26, 0, time.UTC),
+			}
+
+			for got, expected := range expectations {
+				if !got.Equal(expected) {
+					t.Logf("Expected %v got %v", expected, got)
+					t.Fail()
+				}
+			}
+		})
+	}
+}
+
+type layoutConfig struct {
+	Birthday time.Time  `envconfig:"layout=02/01/2006"`
+	Release  *time.Time `envconfig:"layout=Jan 2, 2006,default=Mar 14, 2021"`
+	Created  time.Time
+}
+
+type invalidLayoutConfig struct {
+	Port int `envconfig:"layout=2006"`
+}
+
+func TestLoadWithLayout(t *testing.T) {
+	release := time.Date(2021, 3, 14, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		Label       string
+		Env         mapSource
+		Options     []Option
+		Config      interface{}
+		Expectation interface{}
+		Error       ErrorCode
+	}{
+		{
+			"WithFieldLayout",
+			mapSource{"APP_BIRTHDAY": "25/12/1990"},
+			nil,
+			&layoutConfig{},
+			&layoutConfig{Birthday: time.Date(1990, 12, 25, 0, 0, 0, 0, time.UTC), Release: &release},
+			"",
+		},
+		{
+			"WithTimeLayouts",
+			mapSource{"APP_CREATED": "14.03.2021 15:09"},
+			[]Option{WithTimeLayouts(time.RFC1123, "02.01.2006 15:04")},
+			&layoutConfig{},
+			&layoutConfig{Release: &release, Created: time.Date(2021, 3, 14, 15, 9, 0, 0, time.UTC)},
+			"",
+		},
+		{
+			"WithTimeLayoutsAndRFC3339Value",
+			mapSource{"APP_CREATED": "2021-03-14T15:09:00Z"},
+			[]Option{WithTimeLayouts("02.01.2006 15:04")},
+			&layoutConfig{},
+			&layoutConfig{Release: &release, Created: time.Date(2021, 3, 14, 15, 9, 0, 0, time.UTC)},
+			"",
+		},
+		{"WithValueNotMatchingLayout", mapSource{"APP_BIRTHDAY": "1990-12-25"}, nil, &layoutConfig{}, nil, CodeParseFailure},
+		{"WithUnknownLayout", mapSource{"APP_CREATED": "14.03.2021 15:09"}, nil, &layoutConfig{}, nil, CodeParseFailure},
+		{"WithNonTimeField", mapSource{"APP_PORT": "2021"}, nil, &invalidLayoutConfig{}, nil, CodeInvalidConfig},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			opts := append(testCase.Options, WithSource(testCase.Env))
+			err := New("APP", "_", opts...).Load(testCase.Config)
+
+			if testCase.Error != "" {
+				if code := CodeOf(err); code != testCase.Error {
+					t.Logf("Expected code %s, got [%v]", testCase.Error, err)
+					t.Fail()
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Logf("Wasn't expecting an error, got [%v]", err)
+				t.FailNow()
+			}
+
+			if !reflect.DeepEqual(testCase.Config, testCase.Expectation) {
+				t.Logf("Expected %+v got %+v", testCase.Expectation, testCase.Config)
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestDumpWithLayout(t *testing.T) {
+	release := time.Date(2021, 3, 14, 0, 0, 0, 0, time.UTC)
+	config := layoutConfig{Birthday: time.Date(1990, 12, 25, 0, 0, 0, 0, time.UTC), Release: &release}
+
+	res, err := New("APP", "_").(Dumper).Dump(&config)
+
+	if err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	if res["APP_BIRTHDAY"] != "25/12/1990" || res["APP_RELEASE"] != "Mar 14, 2021" {
+		t.Logf("Expected values formatted using layouts, got %v", res)
+		t.Fail()
+	}
+}
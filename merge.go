@@ -0,0 +1,142 @@
+package envconfig
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	mergeTag    = "merge"
+	mergeAppend = "append"
+	mergeDedup  = "dedup"
+)
+
+// appendedSlice is a slice field tagged with `merge:"append"`
+type appendedSlice struct {
+	path  path
+	dedup bool
+}
+
+// remapAppendedValues shifts indexes of values targeting slice fields tagged
+// with `merge:"append"` by the current length of the slice, so values of a
+// source are appended to entries set by previous sources instead of
+// replacing them. Returns the slices concerned.
+func (e *envConfig) remapAppendedValues(configVal reflect.Value, configType reflect.Type, values []*envValue) []appendedSlice {
+	var (
+		res   []appendedSlice
+		bases = map[string]int{}
+	)
+
+	for _, v := range values {
+		valType := configType
+
+		for i, key := range v.Path {
+			valType = indirectedType(valType)
+
+			if valType.Kind() != reflect.Struct {
+				if valType.Kind() == reflect.Slice || valType.Kind() == reflect.Array || valType.Kind() == reflect.Map {
+					valType = valType.Elem()
+				}
+
+				continue
+			}
+
+			field, ok := valType.FieldByName(key)
+
+			if !ok {
+				break
+			}
+
+			valType = field.Type
+
+			options := strings.Split(field.Tag.Get(mergeTag), ",")
+
+			if options[0] != mergeAppend || indirectedType(field.Type).Kind() != reflect.Slice || i+1 >= len(v.Path) {
+				continue
+			}
+
+			index, err := strconv.Atoi(v.Path[i+1])
+
+			if err != nil {
+				// Invalid indexes are reported during assignment
+				continue
+			}
+
+			slicePath := v.Path[:i+1]
+			base, ok := bases[slicePath.key()]
+
+			if !ok {
+				base = e.sliceLen(configVal, slicePath)
+				bases[slicePath.key()] = base
+				res = append(res, appendedSlice{slicePath.clone(), len(options) > 1 && options[1] == mergeDedup})
+			}
+
+			v.Path[i+1] = strconv.Itoa(base + index)
+		}
+	}
+
+	return res
+}
+
+// sliceLen returns the length of the slice at given path, 0 if it isn't set
+func (e *envConfig) sliceLen(configVal reflect.Value, slicePath path) int {
+	val, ok := e.valueAtPath(configVal, slicePath)
+
+	for ok && val.Kind() == reflect.Ptr {
+		ok = !val.IsNil()
+
+		if ok {
+			val = val.Elem()
+		}
+	}
+
+	if !ok {
+		return 0
+	}
+
+	return val.Len()
+}
+
+// dedupSlices removes duplicated entries of given slices tagged with
+// `merge:"append,dedup"`, keeping first occurrences.
+func (e *envConfig) dedupSlices(configVal reflect.Value, slices []appendedSlice) {
+	for _, s := range slices {
+		if !s.dedup {
+			continue
+		}
+
+		val, ok := e.valueAtPath(configVal, s.path)
+
+		for ok && val.Kind() == reflect.Ptr {
+			ok = !val.IsNil()
+
+			if ok {
+				val = val.Elem()
+			}
+		}
+
+		if !ok || !val.CanSet() {
+			continue
+		}
+
+		unique := reflect.MakeSlice(val.Type(), 0, val.Len())
+
+		for i := 0; i < val.Len(); i++ {
+			duplicated := false
+
+			for j := 0; j < unique.Len(); j++ {
+				if reflect.DeepEqual(val.Index(i).Interface(), unique.Index(j).Interface()) {
+					duplicated = true
+					break
+				}
+			}
+
+			if !duplicated {
+				unique = reflect.Append(unique, val.Index(i))
+			}
+		}
+
+		val.Set(unique)
+	}
+}
@@ -0,0 +1,58 @@
+package envconfig
+
+import (
+	"reflect"
+	"testing"
+)
+
+type appendConfig struct {
+	Replaced []string
+	Appended []string `merge:"append"`
+	Unique   []string `merge:"append,dedup"`
+	Nested   *struct {
+		Items []int `merge:"append"`
+	}
+}
+
+func TestLoadWithAppendedSlices(t *testing.T) {
+	result := appendConfig{Appended: []string{"default"}}
+
+	subject := New(
+		"APP",
+		"_",
+		WithSource(mapSource{
+			"APP_REPLACED_0":     "file",
+			"APP_APPENDED_0":     "file",
+			"APP_UNIQUE_0":       "foo",
+			"APP_NESTED_ITEMS_0": "1",
+		}),
+		WithSource(mapSource{
+			"APP_REPLACED_0":     "env",
+			"APP_APPENDED_0":     "env",
+			"APP_UNIQUE_0":       "foo",
+			"APP_NESTED_ITEMS_0": "2",
+		}),
+		WithSource(mapSource{
+			"APP_UNIQUE_0": "bar",
+		}),
+	)
+
+	if err := subject.Load(&result); err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	expectation := appendConfig{
+		Replaced: []string{"env"},
+		Appended: []string{"default", "file", "env"},
+		Unique:   []string{"foo", "bar"},
+	}
+	expectation.Nested = &struct {
+		Items []int `merge:"append"`
+	}{Items: []int{1, 2}}
+
+	if !reflect.DeepEqual(result, expectation) {
+		t.Logf("Invalid assignation, expected %+v got %+v", expectation, result)
+		t.Fail()
+	}
+}
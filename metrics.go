@@ -0,0 +1,99 @@
+package envconfig
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"expvar"
+	"fmt"
+	"io"
+	"reflect"
+	"time"
+)
+
+const (
+	metricSchemaHash    = "schema_hash"
+	metricLastLoadTime  = "last_load_time"
+	metricVariableCount = "variable_count"
+	metricLoadErrors    = "load_errors"
+)
+
+// WithExpvar publishes load metadata as an expvar.Map under given name:
+// schema hash of the loaded struct, last successful load time, count of
+// variables found in the environment and count of failed loads.
+// If a map is already published under this name, it is reused.
+func WithExpvar(name string) Option {
+	return func(e *envConfig) {
+		vars, ok := expvar.Get(name).(*expvar.Map)
+
+		if !ok {
+			vars = expvar.NewMap(name)
+		}
+
+		e.metrics = &loadMetrics{vars}
+	}
+}
+
+// loadMetrics records load metadata into an expvar.Map
+type loadMetrics struct {
+	vars *expvar.Map
+}
+
+func (m *loadMetrics) record(configType reflect.Type, varCount int, err error) {
+	if m == nil {
+		return
+	}
+
+	if err != nil {
+		m.vars.Add(metricLoadErrors, 1)
+		return
+	}
+
+	schemaHash := new(expvar.String)
+	schemaHash.Set(schemaHashOf(configType))
+	m.vars.Set(metricSchemaHash, schemaHash)
+
+	lastLoadTime := new(expvar.String)
+	lastLoadTime.Set(time.Now().Format(time.RFC3339))
+	m.vars.Set(metricLastLoadTime, lastLoadTime)
+
+	count := new(expvar.Int)
+	count.Set(int64(varCount))
+	m.vars.Set(metricVariableCount, count)
+
+	// Make sure the error counter is published even if no error happened yet
+	m.vars.Add(metricLoadErrors, 0)
+}
+
+// schemaHashOf returns a hash identifying the shape of given type: its field
+// names, types and tags.
+func schemaHashOf(configType reflect.Type) string {
+	h := sha256.New()
+	writeSchema(h, configType, map[reflect.Type]struct{}{})
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func writeSchema(w io.Writer, valType reflect.Type, visited map[reflect.Type]struct{}) {
+	fmt.Fprintf(w, "%s;", valType)
+
+	switch valType.Kind() {
+	case reflect.Ptr, reflect.Array, reflect.Slice:
+		writeSchema(w, valType.Elem(), visited)
+	case reflect.Map:
+		writeSchema(w, valType.Key(), visited)
+		writeSchema(w, valType.Elem(), visited)
+	case reflect.Struct:
+		if _, ok := visited[valType]; ok {
+			return
+		}
+
+		visited[valType] = struct{}{}
+
+		fmt.Fprint(w, "{")
+		for i := 0; i < valType.NumField(); i++ {
+			field := valType.Field(i)
+			fmt.Fprintf(w, "%s `%s` ", field.Name, field.Tag)
+			writeSchema(w, field.Type, visited)
+		}
+		fmt.Fprint(w, "}")
+	}
+}
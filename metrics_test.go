@@ -0,0 +1,69 @@
+package envconfig
+
+import (
+	"expvar"
+	"reflect"
+	"testing"
+)
+
+func TestLoadWithExpvar(t *testing.T) {
+	env := map[string]string{
+		"METRICS_STRING_VALUE": "FOO",
+		"METRICS_INT_VALUE":    "10",
+	}
+
+	setupEnv(env)
+	defer cleanupEnv(env)
+
+	subject := New("METRICS", "_", WithExpvar("envconfig_test"))
+	vars := expvar.Get("envconfig_test").(*expvar.Map)
+
+	// The expvar map is global, assert on the error count delta so the test can run several times.
+	errCountBefore := loadErrorCount(vars)
+
+	if err := subject.Load(&basicAppConfig{}); err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	if count := vars.Get(metricVariableCount).String(); count != "2" {
+		t.Logf("Expected variable count of 2, got %s", count)
+		t.Fail()
+	}
+
+	if errCount := loadErrorCount(vars) - errCountBefore; errCount != 0 {
+		t.Logf("Expected load error count to be unchanged, got %d more", errCount)
+		t.Fail()
+	}
+
+	if hash := vars.Get(metricSchemaHash).String(); hash != `"`+schemaHashOf(reflect.TypeOf(basicAppConfig{}))+`"` {
+		t.Logf("Unexpected schema hash, got %s", hash)
+		t.Fail()
+	}
+
+	if vars.Get(metricLastLoadTime) == nil {
+		t.Log("Expected last load time to be published")
+		t.Fail()
+	}
+
+	setupEnv(map[string]string{"METRICS_INT_VALUE": "NOT_AN_INT"})
+
+	if err := subject.Load(&basicAppConfig{}); err == nil {
+		t.Log("Expected an error, got nothing")
+		t.FailNow()
+	}
+
+	if errCount := loadErrorCount(vars) - errCountBefore; errCount != 1 {
+		t.Logf("Expected load error count to increase by 1, got %d", errCount)
+		t.Fail()
+	}
+}
+
+func loadErrorCount(vars *expvar.Map) int64 {
+	count, ok := vars.Get(metricLoadErrors).(*expvar.Int)
+	if !ok {
+		return 0
+	}
+
+	return count.Value()
+}
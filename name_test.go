@@ -0,0 +1,92 @@
+package envconfig
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jlevesy/envconfig/setter"
+)
+
+type namedConfig struct {
+	DatabaseURL string `envconfig:"name=DATABASE_URL"`
+	Server      struct {
+		Port int
+	} `envconfig:"name=HTTP"`
+	Hosts  []string          `envconfig:"name=HOSTS"`
+	Repos  []string          `envconfig:"name=REPO_LIST,noexpand"`
+	Labels map[string]string `envconfig:"name=LABELS"`
+	Debug  bool
+}
+
+func TestLoadWithNameOverride(t *testing.T) {
+	setters := setter.LoadBasicTypes()
+	setters[reflect.TypeOf([]string{})] = setter.SetterFunc(sliceOfStringSetter)
+
+	source := mapSource{
+		"DATABASE_URL": "postgres://localhost",
+		"HTTP_PORT":    "8080",
+		"HOSTS_0":      "foo",
+		"REPO_LIST":    "a,b",
+		"LABELS_TEAM":  "core",
+		"APP_DEBUG":    "true",
+		// Derived names aren't used for overridden fields
+		"APP_DATABASE_URL": "mysql://localhost",
+	}
+
+	var (
+		result namedConfig
+		report *Report
+	)
+
+	loader := NewWithSettersAndDepth(
+		"APP",
+		"_",
+		setters,
+		DefaultDepth,
+		WithSource(source),
+		WithUnprefixedFallback(),
+		WithReport(func(r *Report) { report = r }),
+	)
+
+	if err := loader.Load(&result); err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	var expectation namedConfig
+
+	expectation.DatabaseURL = "postgres://localhost"
+	expectation.Server.Port = 8080
+	expectation.Hosts = []string{"foo"}
+	expectation.Repos = []string{"a", "b"}
+	expectation.Labels = map[string]string{"team": "core"}
+	expectation.Debug = true
+
+	if !reflect.DeepEqual(result, expectation) {
+		t.Logf("Expected %v, got %v", expectation, result)
+		t.Fail()
+	}
+
+	if entry, _ := report.Entry("Server.Port"); entry.Variable != "HTTP_PORT" {
+		t.Logf("Expected Server.Port to be reported from HTTP_PORT, got %v", entry)
+		t.Fail()
+	}
+
+	vars, err := loader.(Describer).Describe(&namedConfig{})
+
+	if err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	names := []string{}
+
+	for _, v := range vars {
+		names = append(names, v.Name)
+	}
+
+	if expectation := []string{"DATABASE_URL", "HTTP_PORT", "REPO_LIST", "APP_DEBUG"}; !reflect.DeepEqual(names, expectation) {
+		t.Logf("Expected variables %v, got %v", expectation, names)
+		t.Fail()
+	}
+}
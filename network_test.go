@@ -0,0 +1,106 @@
+package envconfig
+
+import (
+	"net"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+type networkConfig struct {
+	BindIP     net.IP
+	AllowedNet net.IPNet
+	Upstream   *url.URL
+	Sender     mail.Address
+}
+
+func TestLoadNetworkTypes(t *testing.T) {
+	testCases := []struct {
+		Label       string
+		Env         mapSource
+		Expectation *networkConfig
+		Error       ErrorCode
+	}{
+		{
+			"WithValues",
+			mapSource{
+				"APP_BIND_IP":     "10.0.0.1",
+				"APP_ALLOWED_NET": "192.168.0.0/16",
+				"APP_UPSTREAM":    "https://api.example.com/v1?region=eu",
+				"APP_SENDER":      "Ops <ops@example.com>",
+			},
+			&networkConfig{
+				BindIP: net.ParseIP("10.0.0.1"),
+				AllowedNet: net.IPNet{
+					IP:   net.IP{192, 168, 0, 0},
+					Mask: net.CIDRMask(16, 32),
+				},
+				Upstream: &url.URL{Scheme: "https", Host: "api.example.com", Path: "/v1", RawQuery: "region=eu"},
+				Sender:   mail.Address{Name: "Ops", Address: "ops@example.com"},
+			},
+			"",
+		},
+		{"WithInvalidIP", mapSource{"APP_BIND_IP": "10.0.0"}, nil, CodeParseFailure},
+		{"WithInvalidCIDR", mapSource{"APP_ALLOWED_NET": "10.0.0.1"}, nil, CodeParseFailure},
+		{"WithInvalidURL", mapSource{"APP_UPSTREAM": "http://[::1"}, nil, CodeParseFailure},
+		{"WithInvalidAddress", mapSource{"APP_SENDER": "ops"}, nil, CodeParseFailure},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			var config networkConfig
+
+			err := New("APP", "_", WithSource(testCase.Env)).Load(&config)
+
+			if testCase.Error != "" {
+				if code := CodeOf(err); code != testCase.Error {
+					t.Logf("Expected code %s, got [%v]", testCase.Error, err)
+					t.Fail()
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Logf("Wasn't expecting an error, got [%v]", err)
+				t.FailNow()
+			}
+
+			if !reflect.DeepEqual(&config, testCase.Expectation) {
+				t.Logf("Expected %+v got %+v", testCase.Expectation, config)
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestDumpNetworkTypes(t *testing.T) {
+	env := mapSource{
+		"APP_BIND_IP":     "10.0.0.1",
+		"APP_ALLOWED_NET": "192.168.0.0/16",
+		"APP_UPSTREAM":    "https://api.example.com/v1",
+		"APP_SENDER":      "<ops@example.com>",
+	}
+
+	var config networkConfig
+
+	loader := New("APP", "_", WithSource(env))
+
+	if err := loader.Load(&config); err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	res, err := loader.(Dumper).Dump(&config)
+
+	if err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	if !reflect.DeepEqual(res, map[string]string(env)) {
+		t.Logf("Expected %v got %v", env, res)
+		t.Fail()
+	}
+}
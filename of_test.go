@@ -0,0 +1,98 @@
+package envconfig
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/jlevesy/envconfig/setter"
+)
+
+type semver struct {
+	Major, Minor, Patch int
+}
+
+func parseSemver(value string) (semver, error) {
+	var v semver
+
+	if _, err := fmt.Sscanf(value, "v%d.%d.%d", &v.Major, &v.Minor, &v.Patch); err != nil {
+		return semver{}, fmt.Errorf("Invalid version %s: %v", value, err)
+	}
+
+	return v, nil
+}
+
+type versionsConfig struct {
+	Current semver
+	Minimum *semver
+}
+
+func TestLoadWithSetterOf(t *testing.T) {
+	setters := setter.LoadBasicTypes()
+	setters[reflect.TypeOf(semver{})] = setter.Of(parseSemver)
+
+	testCases := []struct {
+		Label        string
+		Env          mapSource
+		Expectation  versionsConfig
+		ExpectsError bool
+	}{
+		{
+			"WithValidValues",
+			mapSource{"APP_CURRENT": "v1.4.2", "APP_MINIMUM": "v1.0.0"},
+			versionsConfig{Current: semver{1, 4, 2}, Minimum: &semver{1, 0, 0}},
+			false,
+		},
+		{"WithInvalidValue", mapSource{"APP_CURRENT": "latest"}, versionsConfig{}, true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			var result versionsConfig
+
+			err := NewWithSettersAndDepth("APP", "_", setters, DefaultDepth, WithSource(testCase.Env)).Load(&result)
+
+			if testCase.ExpectsError {
+				if err == nil {
+					t.Log("Expected an error, got nothing")
+					t.Fail()
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Logf("Wasn't expecting an error, got [%v]", err)
+				t.FailNow()
+			}
+
+			if !reflect.DeepEqual(result, testCase.Expectation) {
+				t.Logf("Expected %+v got %+v", testCase.Expectation, result)
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestSetterOfTargets(t *testing.T) {
+	s := setter.Of(parseSemver)
+
+	var target *semver
+
+	if err := s.Set("v2.0.1", reflect.ValueOf(&target).Elem()); err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	if target == nil || *target != (semver{2, 0, 1}) {
+		t.Logf("Expected v2.0.1 got %v", target)
+		t.Fail()
+	}
+
+	var mismatch int
+
+	if err := s.Set("v2.0.1", reflect.ValueOf(&mismatch).Elem()); err == nil {
+		t.Log("Expected an error, got nothing")
+		t.Fail()
+	}
+}
@@ -0,0 +1,32 @@
+package envconfig
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jlevesy/envconfig/setter"
+)
+
+// checkOneOf fails if the field is tagged with the oneof option and the value
+// isn't one of the allowed choices, separated by pipes like
+// oneof=debug|info|warn. The value isn't quoted, it may be a secret.
+func checkOneOf(options tagOptions, sc setter.SetContext) error {
+	allowed, ok := options.Get(oneOfOption)
+
+	if !ok {
+		return nil
+	}
+
+	choices := strings.Split(allowed, "|")
+
+	for _, choice := range choices {
+		if sc.RawValue == choice {
+			return nil
+		}
+	}
+
+	return withCode(
+		CodeInvalidValue,
+		fmt.Errorf("Variable [%s] must be one of [%s]", sc.Variable, strings.Join(choices, ", ")),
+	)
+}
@@ -0,0 +1,61 @@
+package envconfig
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type oneOfConfig struct {
+	LogLevel string  `envconfig:"oneof=debug|info|warn|error,default=info"`
+	Mode     *string `envconfig:"oneof=a,b|c"`
+}
+
+func TestLoadWithOneOf(t *testing.T) {
+	mode := "a,b"
+
+	testCases := []struct {
+		Label       string
+		Env         mapSource
+		Expectation *oneOfConfig
+		Error       ErrorCode
+	}{
+		{"WithAllowedValue", mapSource{"APP_LOG_LEVEL": "warn"}, &oneOfConfig{LogLevel: "warn"}, ""},
+		{"WithDefault", mapSource{}, &oneOfConfig{LogLevel: "info"}, ""},
+		{"WithCommaInChoice", mapSource{"APP_MODE": "a,b"}, &oneOfConfig{LogLevel: "info", Mode: &mode}, ""},
+		{"WithUnknownValue", mapSource{"APP_LOG_LEVEL": "trace"}, nil, CodeInvalidValue},
+		{"WithDifferentCase", mapSource{"APP_LOG_LEVEL": "DEBUG"}, nil, CodeInvalidValue},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			var result oneOfConfig
+
+			err := New("APP", "_", WithSource(testCase.Env)).Load(&result)
+
+			if testCase.Error != "" {
+				if code := CodeOf(err); code != testCase.Error {
+					t.Logf("Expected code %s, got [%v]", testCase.Error, err)
+					t.Fail()
+				}
+
+				if err != nil && !strings.Contains(err.Error(), "debug, info, warn, error") {
+					t.Logf("Expected the error to list valid choices, got [%v]", err)
+					t.Fail()
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Logf("Wasn't expecting an error, got [%v]", err)
+				t.FailNow()
+			}
+
+			if !reflect.DeepEqual(&result, testCase.Expectation) {
+				t.Logf("Expected %+v got %+v", testCase.Expectation, result)
+				t.Fail()
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package envconfig
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/jlevesy/envconfig/setter"
+)
+
+// reversedSource lists keys in reverse order
+type reversedSource struct {
+	mapSource
+}
+
+func (r reversedSource) Keys(prefix string) ([]string, error) {
+	keys, err := r.mapSource.Keys(prefix)
+	sort.Sort(sort.Reverse(sort.StringSlice(keys)))
+
+	return keys, err
+}
+
+type orderedItem string
+
+type orderedConfig struct {
+	Items  []orderedItem
+	Labels map[string]orderedItem
+}
+
+func TestLoadAssignsIndexedValuesInOrder(t *testing.T) {
+	var assigned []string
+
+	source := reversedSource{mapSource{
+		"APP_ITEMS_0":    "a",
+		"APP_ITEMS_1":    "b",
+		"APP_ITEMS_2":    "c",
+		"APP_ITEMS_10":   "d",
+		"APP_LABELS_BAR": "e",
+		"APP_LABELS_FOO": "f",
+	}}
+
+	loader := New("APP", "_", WithSource(source))
+	loader.(SetterRegistry).RegisterSetter(reflect.TypeOf(orderedItem("")), setter.SetterFunc(func(value string, val reflect.Value) error {
+		assigned = append(assigned, value)
+		val.SetString(value)
+
+		return nil
+	}))
+
+	var result orderedConfig
+
+	if err := loader.Load(&result); err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	if expectation := []string{"a", "b", "c", "d", "e", "f"}; !reflect.DeepEqual(assigned, expectation) {
+		t.Logf("Expected values to be assigned in order %v, got %v", expectation, assigned)
+		t.Fail()
+	}
+}
@@ -0,0 +1,65 @@
+package envconfig
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jlevesy/envconfig/setter"
+)
+
+type percentageConfig struct {
+	Ratio float64
+	Share float32
+}
+
+func TestLoadPercentage(t *testing.T) {
+	setters := setter.LoadBasicTypes()
+	setters[reflect.TypeOf(float64(0))] = setter.Percentage(64)
+	setters[reflect.TypeOf(float32(0))] = setter.Percentage(32)
+
+	testCases := []struct {
+		Label        string
+		Env          mapSource
+		Expectation  percentageConfig
+		ExpectsError bool
+	}{
+		{"WithPercentage", mapSource{"APP_RATIO": "75%"}, percentageConfig{Ratio: 0.75}, false},
+		{"WithSpacesAndFraction", mapSource{"APP_RATIO": " 12.5% "}, percentageConfig{Ratio: 0.125}, false},
+		{"WithBareFloat", mapSource{"APP_RATIO": "0.3"}, percentageConfig{Ratio: 0.3}, false},
+		{"WithSpacesAndBareFloat", mapSource{"APP_RATIO": " 0.3 "}, percentageConfig{Ratio: 0.3}, false},
+		{"WithFloat32", mapSource{"APP_SHARE": "50%"}, percentageConfig{Share: 0.5}, false},
+		{"WithInvalidPercentage", mapSource{"APP_RATIO": "abc%"}, percentageConfig{}, true},
+		{"WithOnlyPercentSign", mapSource{"APP_RATIO": "%"}, percentageConfig{}, true},
+		{"WithEmptyValue", mapSource{"APP_RATIO": ""}, percentageConfig{}, true},
+		{"WithOutOfRangePercentage", mapSource{"APP_RATIO": "1e400%"}, percentageConfig{}, true},
+		{"WithOutOfRangeBareFloat", mapSource{"APP_RATIO": "1e400"}, percentageConfig{}, true},
+		{"WithOutOfRangeFloat32", mapSource{"APP_SHARE": "1e39%"}, percentageConfig{}, true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			var result percentageConfig
+
+			err := NewWithSettersAndDepth("APP", "_", setters, DefaultDepth, WithSource(testCase.Env)).Load(&result)
+
+			if testCase.ExpectsError {
+				if err == nil {
+					t.Log("Expected an error, got nothing")
+					t.Fail()
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Logf("Wasn't expecting an error, got [%v]", err)
+				t.FailNow()
+			}
+
+			if !reflect.DeepEqual(result, testCase.Expectation) {
+				t.Logf("Expected %+v, got %+v", testCase.Expectation, result)
+				t.Fail()
+			}
+		})
+	}
+}
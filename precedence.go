@@ -0,0 +1,71 @@
+package envconfig
+
+import (
+	"time"
+
+	"github.com/jlevesy/envconfig/sources"
+)
+
+// Precedence declares every layer a configuration is loaded from, from the
+// lowest precedence to the highest, for instance: built-in defaults < profile
+// files < base environment < profile suffixed environment < flags.
+// Each layer is applied as a named source, so the Source of each ReportEntry
+// names the layer which won for this field.
+type Precedence struct {
+	// Profile is the active profile, eg: "prod". When empty, profiled layers
+	// are left out.
+	Profile string
+	// Defaults holds built-in default values, see WithDefaults
+	Defaults interface{}
+	// Layers are applied in order, each one overwriting the previous ones
+	// unless its Policy says otherwise.
+	Layers []PrecedenceLayer
+}
+
+// PrecedenceLayer is a named layer of a Precedence
+type PrecedenceLayer struct {
+	// Name of the layer, it can be used in `source` struct tags
+	Name string
+	// Source serves the values of the layer
+	Source sources.Source
+	// Profiled builds the source of the layer for the active profile, it
+	// replaces Source when set.
+	Profiled func(profile string) sources.Source
+	// Policy is the merge policy of the layer, default is Overwrite
+	Policy MergePolicy
+	// Timeout bounds lookups in the layer, see WithTimeout
+	Timeout time.Duration
+}
+
+// WithPrecedence configures the loader with every layer declared by p, in
+// place of WithDefaults and WithSource calls.
+func WithPrecedence(p Precedence) Option {
+	return func(e *envConfig) {
+		if p.Defaults != nil {
+			WithDefaults(p.Defaults)(e)
+		}
+
+		for _, l := range p.Layers {
+			source := l.Source
+
+			if l.Profiled != nil {
+				if p.Profile == "" {
+					continue
+				}
+
+				source = l.Profiled(p.Profile)
+			}
+
+			if source == nil {
+				continue
+			}
+
+			WithSource(
+				source,
+				WithName(l.Name),
+				WithMergePolicy(l.Policy),
+				WithTimeout(l.Timeout),
+			)(e)
+		}
+	}
+}
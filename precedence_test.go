@@ -0,0 +1,94 @@
+package envconfig
+
+import (
+	"testing"
+
+	"github.com/jlevesy/envconfig/sources"
+)
+
+func TestLoadWithPrecedence(t *testing.T) {
+	files := map[string]sources.Source{
+		"prod": mapSource{
+			"APP_STRING_VALUE": "FROM_FILE",
+			"APP_INT_VALUE":    "1",
+		},
+	}
+	env := mapSource{
+		"APP_INT_VALUE":       "2",
+		"APP_BOOL_VALUE":      "false",
+		"APP_BOOL_VALUE_PROD": "true",
+	}
+	flags := mapSource{
+		"APP_INT_VALUE": "3",
+	}
+
+	testCases := []struct {
+		Label       string
+		Profile     string
+		Expectation basicAppConfig
+		Sources     map[string]string
+	}{
+		{
+			"WithProfile",
+			"prod",
+			basicAppConfig{StringValue: "FROM_FILE", IntValue: 3, BoolValue: true},
+			map[string]string{"StringValue": "file", "IntValue": "flags", "BoolValue": "profileEnv"},
+		},
+		{
+			"WithoutProfile",
+			"",
+			basicAppConfig{StringValue: "DEFAULT", IntValue: 3},
+			map[string]string{"IntValue": "flags", "BoolValue": "env"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			var report *Report
+
+			loader := New(
+				"APP",
+				"_",
+				WithPrecedence(Precedence{
+					Profile:  testCase.Profile,
+					Defaults: basicAppConfig{StringValue: "DEFAULT"},
+					Layers: []PrecedenceLayer{
+						{Name: "file", Profiled: func(profile string) sources.Source { return files[profile] }},
+						{Name: "env", Source: env},
+						{Name: "profileEnv", Profiled: func(profile string) sources.Source {
+							return sources.Suffixed(env, "_PROD")
+						}},
+						{Name: "flags", Source: flags},
+					},
+				}),
+				WithReport(func(r *Report) { report = r }),
+			)
+
+			var result basicAppConfig
+
+			if err := loader.Load(&result); err != nil {
+				t.Logf("Wasn't expecting an error, got [%v]", err)
+				t.FailNow()
+			}
+
+			if result != testCase.Expectation {
+				t.Logf("Expected %v, got %v", testCase.Expectation, result)
+				t.Fail()
+			}
+
+			if len(report.Entries) != len(testCase.Sources) {
+				t.Logf("Expected entries for %v, got %v", testCase.Sources, report.Entries)
+				t.FailNow()
+			}
+
+			for fieldPath, source := range testCase.Sources {
+				entry, ok := report.Entry(fieldPath)
+
+				if !ok || entry.Source != source {
+					t.Logf("Expected %s to be set by layer %s, got %v", fieldPath, source, entry)
+					t.Fail()
+				}
+			}
+		})
+	}
+}
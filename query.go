@@ -0,0 +1,57 @@
+package envconfig
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/fatih/camelcase"
+)
+
+// setStructFromQuery parses a query string like a=1&b=two&c=true and assigns
+// each value to the matching field of given struct, using the setter collection.
+// Keys match field names either as is, or in snake case, case insensitively:
+// both LateralizerMode and lateralizer_mode match the LateralizerMode field.
+func (e *envConfig) setStructFromQuery(structValue reflect.Value, strValue string) error {
+	values, err := url.ParseQuery(strValue)
+
+	if err != nil {
+		return err
+	}
+
+	structType := structValue.Type()
+
+	for key, fieldValues := range values {
+		field, ok := fieldByKey(structType, key)
+
+		if !ok {
+			return fmt.Errorf("Unknown field [%s] in struct [%v]", key, structType)
+		}
+
+		fieldValue, _, err := e.allocate(structValue.FieldByIndex(field.Index), field.Type)
+
+		if err != nil {
+			return err
+		}
+
+		if err := e.setValue(fieldValue, fieldValues[len(fieldValues)-1]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func fieldByKey(structType reflect.Type, key string) (reflect.StructField, bool) {
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+
+		if strings.EqualFold(field.Name, key) ||
+			strings.EqualFold(strings.Join(camelcase.Split(field.Name), "_"), key) {
+			return field, true
+		}
+	}
+
+	return reflect.StructField{}, false
+}
@@ -0,0 +1,89 @@
+package envconfig
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jlevesy/envconfig/setter"
+)
+
+type shardsConfig struct {
+	Shards     []int     `envconfig:"noexpand"`
+	Partitions []uint8   `envconfig:"noexpand"`
+	Offsets    []uint64  `envconfig:"noexpand"`
+	Pointers   []uintptr `envconfig:"noexpand"`
+}
+
+func TestLoadIntRanges(t *testing.T) {
+	setters := setter.LoadBasicTypes()
+	setters[reflect.TypeOf([]int{})] = setter.IntRanges()
+	setters[reflect.TypeOf([]uint8{})] = setter.IntRanges()
+	setters[reflect.TypeOf([]uint64{})] = setter.IntRanges()
+	setters[reflect.TypeOf([]uintptr{})] = setter.IntRanges()
+
+	testCases := []struct {
+		Label        string
+		Source       mapSource
+		Expectation  shardsConfig
+		ExpectsError bool
+	}{
+		{
+			"WithRanges",
+			mapSource{"APP_SHARDS": "0-3,7, 9-10", "APP_PARTITIONS": "254-255"},
+			shardsConfig{Shards: []int{0, 1, 2, 3, 7, 9, 10}, Partitions: []uint8{254, 255}},
+			false,
+		},
+		{
+			"WithNegativeBounds",
+			mapSource{"APP_SHARDS": "-2--1,-5"},
+			shardsConfig{Shards: []int{-2, -1, -5}},
+			false,
+		},
+		{
+			"WithUint64Bounds",
+			mapSource{"APP_OFFSETS": "18446744073709551614-18446744073709551615", "APP_POINTERS": "1-2"},
+			shardsConfig{Offsets: []uint64{18446744073709551614, 18446744073709551615}, Pointers: []uintptr{1, 2}},
+			false,
+		},
+		{
+			"WithRangeAcrossZero",
+			mapSource{"APP_SHARDS": "-1-1"},
+			shardsConfig{Shards: []int{-1, 0, 1}},
+			false,
+		},
+		{"WithReversedRange", mapSource{"APP_SHARDS": "3-1"}, shardsConfig{}, true},
+		{"WithOverflow", mapSource{"APP_PARTITIONS": "255-256"}, shardsConfig{}, true},
+		{"WithHugeRange", mapSource{"APP_SHARDS": "0-1000000000"}, shardsConfig{}, true},
+		{"WithHugeUint64Range", mapSource{"APP_OFFSETS": "0-18446744073709551615"}, shardsConfig{}, true},
+		{"WithHugeTotal", mapSource{"APP_SHARDS": "0-40000,0-40000"}, shardsConfig{}, true},
+		{"WithReversedNegativeRange", mapSource{"APP_SHARDS": "-1--2"}, shardsConfig{}, true},
+		{"WithInvalidBound", mapSource{"APP_SHARDS": "0-foo"}, shardsConfig{}, true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			var result shardsConfig
+
+			err := NewWithSettersAndDepth("APP", "_", setters, DefaultDepth, WithSource(testCase.Source)).Load(&result)
+
+			if testCase.ExpectsError {
+				if err == nil {
+					t.Log("Expected an error, got nothing")
+					t.Fail()
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Logf("Wasn't expecting an error, got [%v]", err)
+				t.FailNow()
+			}
+
+			if !reflect.DeepEqual(result, testCase.Expectation) {
+				t.Logf("Expected %v, got %v", testCase.Expectation, result)
+				t.Fail()
+			}
+		})
+	}
+}
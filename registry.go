@@ -0,0 +1,85 @@
+package envconfig
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/jlevesy/envconfig/setter"
+)
+
+// SetterRegistry is implemented by loaders whose setters can be changed after
+// construction, like loaders returned by New.
+type SetterRegistry interface {
+	RegisterSetter(valType reflect.Type, s setter.Setter)
+	RemoveSetter(valType reflect.Type)
+}
+
+// RegisterSetter makes the loader assign values of given type using s,
+// replacing the setter previously registered for this type. If valType is an
+// interface type, s assigns values of types implementing it.
+// Setters can be registered while loads are running, the setter collection
+// given at construction is left untouched.
+func (e *envConfig) RegisterSetter(valType reflect.Type, s setter.Setter) {
+	e.updateSetters(func(setters map[reflect.Type]setter.Setter) {
+		setters[valType] = s
+	})
+}
+
+// RemoveSetter removes the setter registered for given type
+func (e *envConfig) RemoveSetter(valType reflect.Type) {
+	e.updateSetters(func(setters map[reflect.Type]setter.Setter) {
+		delete(setters, valType)
+	})
+}
+
+// updateSetters applies fn to a copy of the setter collection, which then
+// replaces it. Collections are never mutated, so they can be read without
+// holding the lock.
+func (e *envConfig) updateSetters(fn func(setters map[reflect.Type]setter.Setter)) {
+	e.settersMu.Lock()
+	defer e.settersMu.Unlock()
+
+	setters := make(map[reflect.Type]setter.Setter, len(e.setters)+1)
+
+	for valType, s := range e.setters {
+		setters[valType] = s
+	}
+
+	fn(setters)
+
+	e.setters = setters
+}
+
+// currentSetters returns the current setter collection, which must not be
+// mutated.
+func (e *envConfig) currentSetters() map[reflect.Type]setter.Setter {
+	e.settersMu.RLock()
+	defer e.settersMu.RUnlock()
+
+	return e.setters
+}
+
+// WithNamedSetter registers s under given name. Fields tagged with
+// envconfig:"setter=name" are assigned from a single variable using it, so
+// fields of the same type can be parsed differently.
+func WithNamedSetter(name string, s setter.Setter) Option {
+	return func(e *envConfig) {
+		if e.namedSetters == nil {
+			e.namedSetters = map[string]setter.Setter{}
+		}
+
+		e.namedSetters[name] = s
+	}
+}
+
+// setWithNamedSetter assigns the value using the setter registered under
+// given name.
+func (e *envConfig) setWithNamedSetter(name string, val reflect.Value, sc setter.SetContext) error {
+	s, ok := e.namedSetters[name]
+
+	if !ok {
+		return withCode(CodeInvalidConfig, fmt.Errorf("Setter [%s] is not registered", name))
+	}
+
+	return setter.WithContext(s).SetWithContext(sc, val)
+}
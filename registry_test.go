@@ -0,0 +1,113 @@
+package envconfig
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jlevesy/envconfig/setter"
+)
+
+// upperString is a string type lacking a setter
+type upperString string
+
+type registryConfig struct {
+	Name upperString
+}
+
+func TestRegisterSetter(t *testing.T) {
+	setters := setter.LoadBasicTypes()
+	loader := NewWithSettersAndDepth("APP", "_", setters, DefaultDepth, WithSource(mapSource{"APP_NAME": "groot"}))
+
+	var result registryConfig
+
+	if err := loader.Load(&result); CodeOf(err) != CodeUnsupportedType {
+		t.Logf("Expected code %s, got [%v]", CodeUnsupportedType, err)
+		t.Fail()
+	}
+
+	loader.(SetterRegistry).RegisterSetter(reflect.TypeOf(upperString("")), setter.SetterFunc(func(value string, val reflect.Value) error {
+		val.SetString(strings.ToUpper(value))
+		return nil
+	}))
+
+	if err := loader.Load(&result); err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	if result.Name != "GROOT" {
+		t.Logf("Expected GROOT got %s", result.Name)
+		t.Fail()
+	}
+
+	if _, ok := setters[reflect.TypeOf(upperString(""))]; ok {
+		t.Log("Expected the setter collection given at construction to be left untouched")
+		t.Fail()
+	}
+
+	loader.(SetterRegistry).RemoveSetter(reflect.TypeOf(upperString("")))
+
+	if err := loader.Load(&result); CodeOf(err) != CodeUnsupportedType {
+		t.Logf("Expected code %s, got [%v]", CodeUnsupportedType, err)
+		t.Fail()
+	}
+}
+
+type namedSetterConfig struct {
+	Hosts   []string `envconfig:"setter=csv"`
+	Paths   []string `envconfig:"setter=semicolons,name=SEARCH_PATH"`
+	Servers struct {
+		Primary []string `envconfig:"setter=csv"`
+	}
+}
+
+func splitSetter(sep string) setter.Setter {
+	return setter.SetterFunc(func(value string, val reflect.Value) error {
+		val.Set(reflect.ValueOf(strings.Split(value, sep)))
+		return nil
+	})
+}
+
+func TestLoadWithNamedSetter(t *testing.T) {
+	source := mapSource{
+		"APP_HOSTS":           "a,b",
+		"SEARCH_PATH":         "/bin;/usr/bin",
+		"APP_SERVERS_PRIMARY": "c,d",
+	}
+
+	var result namedSetterConfig
+
+	err := New(
+		"APP",
+		"_",
+		WithSource(source),
+		WithNamedSetter("csv", splitSetter(",")),
+		WithNamedSetter("semicolons", splitSetter(";")),
+	).Load(&result)
+
+	if err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	var expectation namedSetterConfig
+
+	expectation.Hosts = []string{"a", "b"}
+	expectation.Paths = []string{"/bin", "/usr/bin"}
+	expectation.Servers.Primary = []string{"c", "d"}
+
+	if !reflect.DeepEqual(result, expectation) {
+		t.Logf("Expected %+v got %+v", expectation, result)
+		t.Fail()
+	}
+}
+
+func TestLoadWithUnknownNamedSetter(t *testing.T) {
+	err := New("APP", "_", WithSource(mapSource{"APP_HOSTS": "a,b"})).Load(&namedSetterConfig{})
+
+	if code := CodeOf(err); code != CodeInvalidConfig {
+		t.Logf("Expected code %s, got [%v]", CodeInvalidConfig, err)
+		t.Fail()
+	}
+}
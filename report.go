@@ -0,0 +1,197 @@
+package envconfig
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ReportEntry describes how a value of the configuration struct was set
+type ReportEntry struct {
+	// Path is the dot separated path of the value, eg: Database.Host or
+	// Routes.foo.Port for a map entry
+	Path string
+	// Variable is the name of the variable which set the value
+	Variable string
+	// Source is the name of the source which served the variable
+	Source string
+	// RawValue is the string served by the source, redacted if the field is
+	// tagged secret:"true"
+	RawValue string
+	// Value is the resolved value, as assigned to the configuration struct,
+	// redacted if the field is tagged secret:"true"
+	Value interface{}
+}
+
+// Report describes the outcome of a Load
+type Report struct {
+	// Entries are sorted by path
+	Entries []ReportEntry
+	// Warnings are issues which didn't fail the load, like fields skipped
+	// because of WithSkipUnsupported
+	Warnings []string
+	// Stats are statistics about the load
+	Stats LoadStats
+	// Unused lists variables defined under the prefix which don't map to any
+	// field, like typos, sorted. It is empty for loaders without prefix.
+	Unused []string
+}
+
+// Entry returns the entry of the value at given dot separated path
+func (r *Report) Entry(fieldPath string) (ReportEntry, bool) {
+	i := sort.Search(len(r.Entries), func(i int) bool {
+		return r.Entries[i].Path >= fieldPath
+	})
+
+	if i < len(r.Entries) && r.Entries[i].Path == fieldPath {
+		return r.Entries[i], true
+	}
+
+	return ReportEntry{}, false
+}
+
+// Get returns the resolved value at given dot separated path of the report.
+// The second result is false if no value was set at this path, or if the
+// value is not a T.
+func Get[T any](r *Report, fieldPath string) (T, bool) {
+	var zero T
+
+	entry, ok := r.Entry(fieldPath)
+
+	if !ok {
+		return zero, false
+	}
+
+	value, ok := entry.Value.(T)
+
+	if !ok {
+		return zero, false
+	}
+
+	return value, true
+}
+
+// WithReport makes the loader call given function with a Report after each
+// successful Load.
+func WithReport(fn func(*Report)) Option {
+	return func(e *envConfig) {
+		e.reportCallbacks = append(e.reportCallbacks, fn)
+	}
+}
+
+// report builds the report of a Load from its state, resolving assigned
+// values in configVal.
+func (e *envConfig) report(configVal reflect.Value, state *loadState) *Report {
+	res := &Report{
+		Entries:  make([]ReportEntry, 0, len(state.assigned)),
+		Warnings: state.warnings,
+		Stats:    state.stats,
+		Unused:   state.unused,
+	}
+
+	for key, entry := range state.assigned {
+		if val, ok := e.valueAtPath(configVal, path(strings.Split(key, "\x00"))); ok {
+			entry.Value = val.Interface()
+		}
+
+		res.Entries = append(res.Entries, entry)
+	}
+
+	sort.Slice(res.Entries, func(i, j int) bool {
+		return res.Entries[i].Path < res.Entries[j].Path
+	})
+
+	res.Stats.Assigned = len(res.Entries)
+
+	// Variables of the struct which weren't assigned kept their default value
+	if vars, err := e.Describe(configVal.Addr().Interface()); err == nil {
+		for _, v := range vars {
+			if _, ok := state.assigned[path(v.Path).key()]; !ok {
+				res.Stats.Defaulted++
+			}
+		}
+	}
+
+	return res
+}
+
+func (e *envConfig) notifyReport(configVal reflect.Value, state *loadState) {
+	if len(e.reportCallbacks) == 0 {
+		return
+	}
+
+	r := e.report(configVal, state)
+
+	// Callbacks may log or export the report, secrets must not leak from it
+	for i, entry := range r.Entries {
+		if isSecretPath(configVal.Type(), strings.Split(entry.Path, ".")) {
+			r.Entries[i].RawValue = redacted
+			r.Entries[i].Value = redacted
+		}
+	}
+
+	for _, fn := range e.reportCallbacks {
+		fn(r)
+	}
+}
+
+// reportEntry returns the entry describing given value, served by given layer
+func (e *envConfig) reportEntry(l layer, v *envValue) ReportEntry {
+	source := l.name
+
+	if source == "" {
+		source = fmt.Sprintf("%T", l.source)
+	}
+
+	return ReportEntry{
+		Path:     strings.Join(v.Path, "."),
+		Variable: v.Variable,
+		Source:   source,
+		RawValue: v.StrValue,
+	}
+}
+
+// valueAtPath returns the value at given path, following pointers, struct
+// fields, indexes and map keys.
+func (e *envConfig) valueAtPath(val reflect.Value, valuePath path) (reflect.Value, bool) {
+	for _, key := range valuePath {
+		for val.Kind() == reflect.Ptr {
+			if val.IsNil() {
+				return reflect.Value{}, false
+			}
+
+			val = val.Elem()
+		}
+
+		switch val.Kind() {
+		case reflect.Struct:
+			val = val.FieldByName(key)
+		case reflect.Slice, reflect.Array:
+			index, err := strconv.Atoi(key)
+
+			if err != nil || index >= val.Len() {
+				return reflect.Value{}, false
+			}
+
+			val = val.Index(index)
+		case reflect.Map:
+			keyValue := reflect.New(val.Type().Key()).Elem()
+
+			if err := e.setValue(keyValue, key); err != nil {
+				return reflect.Value{}, false
+			}
+
+			val = val.MapIndex(keyValue)
+		default:
+			return reflect.Value{}, false
+		}
+
+		if !val.IsValid() {
+			return reflect.Value{}, false
+		}
+	}
+
+	return val, true
+}
@@ -0,0 +1,214 @@
+package envconfig
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jlevesy/envconfig/sources"
+)
+
+type reportedConfig struct {
+	Timeout time.Duration
+	Nested  *basicAppConfig
+	Routes  map[string]basicAppConfig
+	Ports   []int
+}
+
+func TestLoadWithReport(t *testing.T) {
+	env := map[string]string{
+		"APP_TIMEOUT":                 "5s",
+		"APP_NESTED_STRING_VALUE":     "FOO",
+		"APP_ROUTES_FOO_INT_VALUE":    "10",
+		"APP_PORTS_0":                 "8080",
+		"APP_NESTED_BOOL_VALUE":       "true",
+		"APP_ROUTES_BAR_STRING_VALUE": "BAR",
+	}
+
+	setupEnv(env)
+	defer cleanupEnv(env)
+
+	var report *Report
+
+	subject := New("APP", "_", WithReport(func(r *Report) { report = r }))
+
+	if err := subject.Load(&reportedConfig{}); err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	if report == nil {
+		t.Log("Expected a report")
+		t.FailNow()
+	}
+
+	if len(report.Entries) != len(env) {
+		t.Logf("Expected %d entries, got %v", len(env), report.Entries)
+		t.Fail()
+	}
+
+	entry, ok := report.Entry("Nested.StringValue")
+
+	if !ok || entry.Variable != "APP_NESTED_STRING_VALUE" || entry.Source != defaultSourceName || entry.RawValue != "FOO" {
+		t.Logf("Unexpected entry %+v", entry)
+		t.Fail()
+	}
+
+	if timeout, ok := Get[time.Duration](report, "Timeout"); !ok || timeout != 5*time.Second {
+		t.Logf("Expected timeout of 5s, got %v", timeout)
+		t.Fail()
+	}
+
+	if value, ok := Get[int](report, "Routes.foo.IntValue"); !ok || value != 10 {
+		t.Logf("Expected map entry value of 10, got %v", value)
+		t.Fail()
+	}
+
+	if port, ok := Get[int](report, "Ports.0"); !ok || port != 8080 {
+		t.Logf("Expected port 8080, got %v", port)
+		t.Fail()
+	}
+
+	if _, ok := Get[string](report, "Timeout"); ok {
+		t.Log("Expected type mismatch to be reported")
+		t.Fail()
+	}
+
+	if _, ok := Get[string](report, "Nested.IntValue"); ok {
+		t.Log("Expected unset value not to be reported")
+		t.Fail()
+	}
+}
+
+func TestReportRedactsSecrets(t *testing.T) {
+	env := map[string]string{
+		"APP_PASSWORD": "enc:test:terces",
+		"APP_TOKEN":    "hunter2",
+		"APP_USER":     "admin",
+	}
+
+	setupEnv(env)
+	defer cleanupEnv(env)
+
+	reverse := DecryptorFunc(func(ctx context.Context, ciphertext string) (string, error) {
+		runes := []rune(ciphertext)
+		for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+			runes[i], runes[j] = runes[j], runes[i]
+		}
+		return string(runes), nil
+	})
+
+	var (
+		report *Report
+		result struct {
+			Password string `secret:"true"`
+			Token    string `secret:"true"`
+			User     string
+		}
+	)
+
+	subject := New("APP", "_", WithDecryptor("enc:test:", reverse), WithReport(func(r *Report) { report = r }))
+
+	if err := subject.Load(&result); err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	if result.Password != "secret" {
+		t.Logf("Expected decrypted password, got [%s]", result.Password)
+		t.Fail()
+	}
+
+	for _, fieldPath := range []string{"Password", "Token"} {
+		entry, ok := report.Entry(fieldPath)
+
+		if !ok || entry.RawValue != redacted || entry.Value != redacted {
+			t.Logf("Expected %s to be redacted, got %+v", fieldPath, entry)
+			t.Fail()
+		}
+	}
+
+	if user, ok := Get[string](report, "User"); !ok || user != "admin" {
+		t.Logf("Expected user admin, got %v", user)
+		t.Fail()
+	}
+}
+
+func TestLoadStats(t *testing.T) {
+	env := map[string]string{
+		"APP_STRING_VALUE": "FOO",
+		"APP_INT_VALUE":    "10",
+	}
+
+	setupEnv(env)
+	defer cleanupEnv(env)
+
+	var report *Report
+
+	subject := New(
+		"APP",
+		"_",
+		WithSource(mapSource{"APP_INT_VALUE": "20"}),
+		WithSource(sources.Env(), WithMergePolicy(FillOnly)),
+		WithReport(func(r *Report) { report = r }),
+	)
+
+	if err := subject.Load(&basicAppConfig{}); err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	stats := report.Stats
+
+	// Each source is looked up for the 3 fields
+	if stats.Scanned != 6 || stats.Matched != 3 || stats.Assigned != 2 || stats.Defaulted != 1 {
+		t.Logf("Unexpected stats %+v", stats)
+		t.Fail()
+	}
+
+	if stats.AnalysisDuration <= 0 || stats.AssignmentDuration <= 0 {
+		t.Logf("Expected durations to be measured, got %+v", stats)
+		t.Fail()
+	}
+}
+
+func TestReportProvenance(t *testing.T) {
+	type provenanceConfig struct {
+		Host     string
+		Port     int
+		Password string `secret:"true"`
+	}
+
+	expected := []ReportEntry{
+		{Path: "Host", Variable: "APP_HOST", Source: "defaults", RawValue: "localhost", Value: "localhost"},
+		{Path: "Password", Variable: "APP_PASSWORD", Source: "vault", RawValue: redacted, Value: redacted},
+		{Path: "Port", Variable: "APP_PORT", Source: "vault", RawValue: "8080", Value: 8080},
+	}
+
+	var report *Report
+
+	subject := New(
+		"APP",
+		"_",
+		WithSource(mapSource{"APP_HOST": "localhost", "APP_PORT": "80"}, WithName("defaults")),
+		WithSource(mapSource{"APP_PORT": "8080", "APP_PASSWORD": "hunter2"}, WithName("vault")),
+		WithReport(func(r *Report) { report = r }),
+	)
+
+	if err := subject.Load(&provenanceConfig{}); err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	if len(report.Entries) != len(expected) {
+		t.Logf("Expected %v, got %v", expected, report.Entries)
+		t.FailNow()
+	}
+
+	for i, entry := range report.Entries {
+		if entry != expected[i] {
+			t.Logf("Expected %+v, got %+v", expected[i], entry)
+			t.Fail()
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package envconfig
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/jlevesy/envconfig/setter"
+)
+
+const requiredIfTag = "requiredIf"
+
+// checkRequired assigns the value of the default tag option to fields which
+// aren't set, then fails if a field tagged with the required option is not
+// set, or if a field tagged with requiredIf is not set while its condition
+// holds. A field is set if a source defined its variable, or if it isn't zero,
+// for instance thanks to WithDefaults.
+// The condition references either a bool field by its dot separated path,
+// from the enclosing struct then from the root struct, or a variable.
+func (e *envConfig) checkRequired(ctx context.Context, root, val reflect.Value, currentPath path, assigned map[string]ReportEntry) error {
+	valType := val.Type()
+
+	for i := 0; i < valType.NumField(); i++ {
+		field := valType.Field(i)
+		fieldVal := val.Field(i)
+		fieldPath := currentPath
+
+		if !field.Anonymous {
+			fieldPath = append(currentPath.clone(), field.Name)
+		}
+
+		options := fieldOptions(field)
+
+		if e.skipsField(field) {
+			continue
+		}
+
+		_, set := assigned[fieldPath.key()]
+		set = set || !fieldVal.IsZero()
+
+		if value, ok := options.Get(defaultOption); ok && !set {
+			if err := e.assignDefault(root, field, fieldPath, value, assigned); err != nil {
+				return err
+			}
+
+			set = true
+		}
+
+		if options.Has(requiredOption) && !set {
+			variable := e.variableName(root.Type(), fieldPath)
+
+			return newLoadError(
+				root.Type(),
+				variable,
+				fieldPath,
+				"",
+				withCode(CodeMissingRequired, fmt.Errorf("Variable [%s] is required", variable)),
+			)
+		}
+
+		if ref, ok := field.Tag.Lookup(requiredIfTag); ok {
+			required, err := e.conditionHolds(ctx, root, val, ref)
+
+			if err != nil {
+				return err
+			}
+
+			if required && !set {
+				variable := e.variableName(root.Type(), fieldPath)
+
+				return newLoadError(
+					root.Type(),
+					variable,
+					fieldPath,
+					"",
+					withCode(CodeMissingRequired, fmt.Errorf("Variable [%s] is required when [%s] is true", variable, ref)),
+				)
+			}
+		}
+
+		for fieldVal.Kind() == reflect.Ptr && !fieldVal.IsNil() {
+			fieldVal = fieldVal.Elem()
+		}
+
+		if fieldVal.Kind() != reflect.Struct || isLazy(fieldVal.Type()) {
+			continue
+		}
+
+		if _, ok := e.setterOf(fieldVal.Type()); ok {
+			continue
+		}
+
+		if err := e.checkRequired(ctx, root, fieldVal, fieldPath, assigned); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// assignDefault assigns given default value to the field at given path of
+// root, and records it as assigned.
+func (e *envConfig) assignDefault(root reflect.Value, field reflect.StructField, fieldPath path, value string, assigned map[string]ReportEntry) error {
+	variable := e.variableName(root.Type(), fieldPath)
+	options := fieldOptions(field)
+
+	switch indirectedType(field.Type).Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		if !options.Whole() {
+			return withCode(CodeInvalidConfig, fmt.Errorf("Field [%s] needs the noexpand option to have a default value", strings.Join(fieldPath, ".")))
+		}
+	}
+
+	err := e.assignValue(root, root.Type(), fieldPath, setter.SetContext{
+		RawValue: value,
+		Path:     fieldPath.clone(),
+		Variable: variable,
+		Tag:      field.Tag,
+	})
+
+	if err != nil {
+		return newLoadError(root.Type(), variable, fieldPath, value, err)
+	}
+
+	assigned[fieldPath.key()] = ReportEntry{
+		Path:     strings.Join(fieldPath, "."),
+		Variable: variable,
+		Source:   defaultOption,
+		RawValue: value,
+	}
+
+	return nil
+}
+
+// conditionHolds reports if the bool field at given path of structVal, or
+// else of root, is true. If neither has such field, it reports if the
+// variable named ref is true.
+func (e *envConfig) conditionHolds(ctx context.Context, root, structVal reflect.Value, ref string) (bool, error) {
+	fieldVal, ok := fieldAtPath(structVal, strings.Split(ref, "."))
+
+	if !ok {
+		fieldVal, ok = fieldAtPath(root, strings.Split(ref, "."))
+	}
+
+	if ok {
+		if !fieldVal.IsValid() {
+			return false, nil
+		}
+
+		if fieldVal.Kind() != reflect.Bool {
+			return false, withCode(
+				CodeInvalidConfig,
+				fmt.Errorf("Field [%s] referenced by requiredIf is a %v, expected a bool", ref, fieldVal.Type()),
+			)
+		}
+
+		return fieldVal.Bool(), nil
+	}
+
+	value, found, err := e.lookupVariable(ctx, ref, "", false)
+
+	if err != nil || !found {
+		return false, err
+	}
+
+	res, err := e.parseBool(value)
+
+	if err != nil {
+		return false, withCode(CodeParseFailure, fmt.Errorf("Variable [%s] referenced by requiredIf: %v", ref, err))
+	}
+
+	return res, nil
+}
+
+// fieldAtPath returns the field at given path of structVal, following
+// pointers. The returned value is invalid if a nil pointer is met.
+func fieldAtPath(structVal reflect.Value, fieldPath []string) (reflect.Value, bool) {
+	val := structVal
+
+	for _, name := range fieldPath {
+		if val.Kind() == reflect.Ptr {
+			if val.IsNil() {
+				return reflect.Value{}, true
+			}
+
+			val = val.Elem()
+		}
+
+		if val.Kind() != reflect.Struct {
+			return reflect.Value{}, false
+		}
+
+		if _, ok := val.Type().FieldByName(name); !ok {
+			return reflect.Value{}, false
+		}
+
+		val = val.FieldByName(name)
+	}
+
+	if val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return reflect.Value{}, true
+		}
+
+		val = val.Elem()
+	}
+
+	return val, true
+}
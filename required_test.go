@@ -0,0 +1,112 @@
+package envconfig
+
+import (
+	"testing"
+)
+
+type requiredConfig struct {
+	TLSEnabled bool
+	TLS        struct {
+		Cert string `requiredIf:"TLSEnabled"`
+	}
+	Server struct {
+		Metrics bool
+		Port    int `requiredIf:"Metrics"`
+	}
+	Token string `requiredIf:"APP_AUTH_ENABLED"`
+}
+
+type invalidRequiredConfig struct {
+	Mode string
+	Port int `requiredIf:"Mode"`
+}
+
+func TestLoadWithRequiredIf(t *testing.T) {
+	testCases := []struct {
+		Label       string
+		Env         mapSource
+		Config      interface{}
+		Expectation ErrorCode
+	}{
+		{"WithConditionsUnmet", mapSource{}, &requiredConfig{}, ""},
+		{
+			"WithMissingField",
+			mapSource{"APP_SERVER_METRICS": "true"},
+			&requiredConfig{},
+			CodeMissingRequired,
+		},
+		{
+			"WithFieldSet",
+			mapSource{"APP_SERVER_METRICS": "true", "APP_SERVER_PORT": "9090"},
+			&requiredConfig{},
+			"",
+		},
+		{
+			"WithRootFieldReference",
+			mapSource{"APP_TLS_ENABLED": "true"},
+			&requiredConfig{},
+			CodeMissingRequired,
+		},
+		{
+			"WithRootFieldSet",
+			mapSource{"APP_TLS_ENABLED": "true", "APP_TLS_CERT": "cert.pem"},
+			&requiredConfig{},
+			"",
+		},
+		{
+			"WithVariableReference",
+			mapSource{"APP_AUTH_ENABLED": "true"},
+			&requiredConfig{},
+			CodeMissingRequired,
+		},
+		{
+			"WithInvalidVariableReference",
+			mapSource{"APP_AUTH_ENABLED": "groot"},
+			&requiredConfig{},
+			CodeParseFailure,
+		},
+		{
+			"WithNonBoolReference",
+			mapSource{},
+			&invalidRequiredConfig{},
+			CodeInvalidConfig,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			err := New("APP", "_", WithSource(testCase.Env)).Load(testCase.Config)
+
+			if testCase.Expectation == "" {
+				if err != nil {
+					t.Logf("Wasn't expecting an error, got [%v]", err)
+					t.Fail()
+				}
+
+				return
+			}
+
+			if code := CodeOf(err); code != testCase.Expectation {
+				t.Logf("Expected code %s got %q, error [%v]", testCase.Expectation, code, err)
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestLoadWithRequiredIfSatisfiedByDefaults(t *testing.T) {
+	defaults := requiredConfig{}
+	defaults.Server.Port = 8080
+
+	err := New(
+		"APP",
+		"_",
+		WithDefaults(defaults),
+		WithSource(mapSource{"APP_SERVER_METRICS": "true"}),
+	).Load(&requiredConfig{})
+
+	if err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.Fail()
+	}
+}
@@ -0,0 +1,104 @@
+package envconfig
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// SecretRotation describes the change of a secret field value during a reload
+type SecretRotation struct {
+	// Path is the dot separated path of the field, eg: Database.Password
+	Path string
+	Old  interface{}
+	New  interface{}
+}
+
+type rotationCallback struct {
+	path     string
+	callback func(SecretRotation)
+}
+
+// WithRotationCallback registers a callback called by Watch when the value of
+// the field at given path changes during a reload. The field must be tagged
+// with `secret:"true"`, and fieldPath is the dot separated path of the field
+// in the config struct, eg: Database.Password.
+func WithRotationCallback(fieldPath string, callback func(SecretRotation)) Option {
+	return func(e *envConfig) {
+		e.rotationCallbacks = append(e.rotationCallbacks, rotationCallback{fieldPath, callback})
+	}
+}
+
+// checkRotationCallbacks ensures that every rotation callback refers to a
+// secret field of configType.
+func (e *envConfig) checkRotationCallbacks(configType reflect.Type) error {
+	for _, r := range e.rotationCallbacks {
+		field, ok := structFieldByPath(configType, strings.Split(r.path, "."))
+
+		if !ok {
+			return fmt.Errorf("Rotation callback registered for unknown field %s in %v", r.path, configType)
+		}
+
+		if field.Tag.Get(secretTag) != "true" {
+			return fmt.Errorf("Rotation callback registered for field %s which is not tagged as secret", r.path)
+		}
+	}
+
+	return nil
+}
+
+// notifyRotations calls rotation callbacks of fields which value differ
+// between previous and current configs.
+func (e *envConfig) notifyRotations(previous, current interface{}) {
+	for _, r := range e.rotationCallbacks {
+		fieldPath := strings.Split(r.path, ".")
+
+		oldValue := fieldValueByPath(reflect.ValueOf(previous), fieldPath)
+		newValue := fieldValueByPath(reflect.ValueOf(current), fieldPath)
+
+		if reflect.DeepEqual(oldValue, newValue) {
+			continue
+		}
+
+		r.callback(SecretRotation{Path: r.path, Old: oldValue, New: newValue})
+	}
+}
+
+// structFieldByPath returns the struct field at given path, following pointers
+func structFieldByPath(valType reflect.Type, fieldPath path) (reflect.StructField, bool) {
+	valType = indirectedType(valType)
+
+	if valType.Kind() != reflect.Struct {
+		return reflect.StructField{}, false
+	}
+
+	fieldName, next := fieldPath.popBack()
+	field, ok := valType.FieldByName(fieldName)
+
+	if !ok || len(next) == 0 {
+		return field, ok
+	}
+
+	return structFieldByPath(field.Type, next)
+}
+
+// fieldValueByPath returns the value of the field at given path, following
+// pointers. Returns nil if a nil pointer is met along the path.
+func fieldValueByPath(val reflect.Value, fieldPath path) interface{} {
+	for val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return nil
+		}
+
+		val = val.Elem()
+	}
+
+	fieldName, next := fieldPath.popBack()
+	val = val.FieldByName(fieldName)
+
+	if len(next) == 0 {
+		return val.Interface()
+	}
+
+	return fieldValueByPath(val, next)
+}
@@ -0,0 +1,95 @@
+package envconfig
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jlevesy/envconfig/setter"
+)
+
+// delimitedList is split using the delimiter given by its field's tag
+type delimitedList struct {
+	Items []string
+}
+
+type setContextConfig struct {
+	Hosts  delimitedList   `delimiter:";"`
+	Groups []delimitedList `delimiter:"|"`
+}
+
+func TestLoadWithContextSetter(t *testing.T) {
+	var contexts []setter.SetContext
+
+	setters := setter.LoadBasicTypes()
+	setters[reflect.TypeOf(delimitedList{})] = setter.ContextSetterFunc(
+		func(ctx setter.SetContext, val reflect.Value) error {
+			contexts = append(contexts, ctx)
+			val.Set(reflect.ValueOf(delimitedList{strings.Split(ctx.RawValue, ctx.Tag.Get("delimiter"))}))
+			return nil
+		},
+	)
+
+	source := mapSource{
+		"APP_HOSTS":    "foo;bar",
+		"APP_GROUPS_0": "a|b",
+	}
+
+	var result setContextConfig
+
+	if err := NewWithSettersAndDepth("APP", "_", setters, DefaultDepth, WithSource(source)).Load(&result); err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	expectation := setContextConfig{
+		Hosts:  delimitedList{[]string{"foo", "bar"}},
+		Groups: []delimitedList{{[]string{"a", "b"}}},
+	}
+
+	if !reflect.DeepEqual(result, expectation) {
+		t.Logf("Expected %v got %v", expectation, result)
+		t.Fail()
+	}
+
+	expectedContexts := map[string]setter.SetContext{
+		"APP_HOSTS": {
+			RawValue: "foo;bar",
+			Path:     []string{"Hosts"},
+			Variable: "APP_HOSTS",
+			Tag:      `delimiter:";"`,
+		},
+		"APP_GROUPS_0": {
+			RawValue: "a|b",
+			Path:     []string{"Groups", "0"},
+			Variable: "APP_GROUPS_0",
+			Tag:      `delimiter:"|"`,
+		},
+	}
+
+	if len(contexts) != len(expectedContexts) {
+		t.Logf("Expected %d calls, got %v", len(expectedContexts), contexts)
+		t.FailNow()
+	}
+
+	for _, ctx := range contexts {
+		if !reflect.DeepEqual(ctx, expectedContexts[ctx.Variable]) {
+			t.Logf("Expected %+v got %+v", expectedContexts[ctx.Variable], ctx)
+			t.Fail()
+		}
+	}
+}
+
+func TestSetterWithContextAdapter(t *testing.T) {
+	var value int
+
+	err := setter.WithContext(setter.LoadBasicTypes()[reflect.TypeOf(0)]).SetWithContext(
+		setter.SetContext{RawValue: "42"},
+		reflect.ValueOf(&value).Elem(),
+	)
+
+	if err != nil || value != 42 {
+		t.Logf("Expected [42, nil] got [%d, %v]", value, err)
+		t.Fail()
+	}
+}
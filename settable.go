@@ -0,0 +1,97 @@
+package envconfig
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// WithSkipUnexported makes the loader silently skip unexported fields, which
+// can't be set, instead of failing with CodeUnsettableField when variables
+// are defined for them. Exported fields promoted from unexported embedded
+// structs are still loaded.
+func WithSkipUnexported() Option {
+	return func(e *envConfig) {
+		e.skipUnexported = true
+	}
+}
+
+// checkSettable reports fields of configType which can never be set although
+// values were found for them, before any value is assigned.
+func (e *envConfig) checkSettable(configType reflect.Type, values []*envValue) error {
+	var (
+		fields []string
+		seen   = map[string]struct{}{}
+	)
+
+	for _, v := range values {
+		fieldPath, hint := unsettableHint(configType, v.Path)
+
+		if hint == "" {
+			continue
+		}
+
+		if _, ok := seen[fieldPath]; ok {
+			continue
+		}
+
+		seen[fieldPath] = struct{}{}
+		fields = append(fields, fmt.Sprintf("[%s] %s", fieldPath, hint))
+	}
+
+	if len(fields) == 0 {
+		return nil
+	}
+
+	return withCode(
+		CodeUnsettableField,
+		fmt.Errorf("Fields can't be set: %s", strings.Join(fields, ", ")),
+	)
+}
+
+// unsettableHint follows given path from configType and returns the path of
+// the first field which can't be set, along with a hint on how to fix it.
+func unsettableHint(configType reflect.Type, valPath path) (string, string) {
+	valType := configType
+
+	for i, key := range valPath {
+		valType = indirectedType(valType)
+
+		switch valType.Kind() {
+		case reflect.Struct:
+			field, ok := valType.FieldByName(key)
+
+			if !ok {
+				return "", ""
+			}
+
+			fieldPath := strings.Join(valPath[:i+1], ".")
+
+			// Promoted fields are reached through embedded structs, which
+			// can't be allocated if they are unexported pointers.
+			embedding := valType
+
+			for _, index := range field.Index[:len(field.Index)-1] {
+				embedded := embedding.Field(index)
+
+				if embedded.PkgPath != "" && embedded.Type.Kind() == reflect.Ptr {
+					return fieldPath, fmt.Sprintf("is promoted from an unexported embedded pointer, embed %s by value or export it", embedded.Name)
+				}
+
+				embedding = indirectedType(embedded.Type)
+			}
+
+			if field.PkgPath != "" {
+				return fieldPath, "is unexported, field must be exported"
+			}
+
+			valType = field.Type
+		case reflect.Map, reflect.Slice, reflect.Array:
+			valType = valType.Elem()
+		default:
+			return "", ""
+		}
+	}
+
+	return "", ""
+}
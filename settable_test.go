@@ -0,0 +1,138 @@
+package envconfig
+
+import (
+	"strings"
+	"testing"
+)
+
+type embeddedSettings struct {
+	Timeout int
+}
+
+type unexportedFieldConfig struct {
+	Host  string
+	port  int
+	token string
+}
+
+type unexportedEmbeddedConfig struct {
+	*embeddedSettings
+}
+
+func TestLoadUnsettableFields(t *testing.T) {
+	testCases := []struct {
+		Label       string
+		Env         mapSource
+		Config      interface{}
+		Expectation string
+	}{
+		{
+			"WithUnexportedFieldsNotDefined",
+			mapSource{"APP_HOST": "localhost"},
+			&unexportedFieldConfig{},
+			"",
+		},
+		{
+			"WithUnexportedFields",
+			mapSource{"APP_HOST": "localhost", "APP_PORT": "80", "APP_TOKEN": "secret"},
+			&unexportedFieldConfig{},
+			"Fields can't be set: [port] is unexported, field must be exported, [token] is unexported, field must be exported",
+		},
+		{
+			"WithUnexportedEmbeddedPointer",
+			mapSource{"APP_TIMEOUT": "10"},
+			&unexportedEmbeddedConfig{},
+			"Fields can't be set: [Timeout] is promoted from an unexported embedded pointer, embed embeddedSettings by value or export it",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			err := New("APP", "_", WithSource(testCase.Env)).Load(testCase.Config)
+
+			if testCase.Expectation == "" {
+				if err != nil {
+					t.Logf("Wasn't expecting an error, got [%v]", err)
+					t.Fail()
+				}
+
+				return
+			}
+
+			if CodeOf(err) != CodeUnsettableField || !strings.HasPrefix(err.Error(), "Fields can't be set") {
+				t.Logf("Expected an unsettable field error, got [%v]", err)
+				t.FailNow()
+			}
+
+			if err.Error() != testCase.Expectation {
+				t.Logf("Expected [%s] got [%v]", testCase.Expectation, err)
+				t.Fail()
+			}
+		})
+	}
+}
+
+type EmbeddedSettings struct {
+	Timeout int
+}
+
+type exportedEmbeddedConfig struct {
+	*EmbeddedSettings
+}
+
+func TestLoadEmbeddedPointer(t *testing.T) {
+	var result exportedEmbeddedConfig
+
+	if err := New("APP", "_", WithSource(mapSource{"APP_TIMEOUT": "10"})).Load(&result); err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	if result.EmbeddedSettings == nil || result.Timeout != 10 {
+		t.Logf("Expected embedded struct to be allocated, got %+v", result.EmbeddedSettings)
+		t.Fail()
+	}
+}
+
+type skippedFieldsConfig struct {
+	unexportedFieldConfig
+	Database struct {
+		Name     string
+		password string `envconfig:"required"`
+	}
+}
+
+func TestLoadWithSkipUnexported(t *testing.T) {
+	env := mapSource{
+		"APP_HOST":              "localhost",
+		"APP_PORT":              "80",
+		"APP_DATABASE_NAME":     "app",
+		"APP_DATABASE_PASSWORD": "secret",
+	}
+
+	var result skippedFieldsConfig
+
+	loader := New("APP", "_", WithSource(env), WithSkipUnexported())
+
+	if err := loader.Load(&result); err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	if result.Host != "localhost" || result.port != 0 || result.Database.Name != "app" || result.Database.password != "" {
+		t.Logf("Expected unexported fields to be skipped, got %+v", result)
+		t.Fail()
+	}
+
+	vars, err := loader.(Describer).Describe(&skippedFieldsConfig{})
+
+	if err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	if len(vars) != 2 {
+		t.Logf("Expected unexported fields not to be described, got %v", vars)
+		t.Fail()
+	}
+}
@@ -1,6 +1,9 @@
 package setter
 
 import (
+	"net"
+	"net/mail"
+	"net/url"
 	"reflect"
 	"strconv"
 	"time"
@@ -64,18 +67,6 @@ func setBool(strValue string, value reflect.Value) error {
 	return nil
 }
 
-func setTime(strValue string, value reflect.Value) error {
-	v, err := time.Parse(time.RFC3339, strValue)
-
-	if err != nil {
-		return err
-	}
-
-	value.Set(reflect.ValueOf(v))
-
-	return nil
-}
-
 func setDuration(strValue string, value reflect.Value) error {
 	v, err := time.ParseDuration(strValue)
 
@@ -114,8 +105,16 @@ func LoadBasicTypes() map[reflect.Type]Setter {
 	// Misc
 	res[reflect.TypeOf("")] = SetterFunc(setString)
 	res[reflect.TypeOf(true)] = SetterFunc(setBool)
-	res[reflect.TypeOf(time.Time{})] = SetterFunc(setTime)
+	res[reflect.TypeOf(time.Time{})] = Time(time.UTC)
 	res[reflect.TypeOf(time.Duration(0))] = SetterFunc(setDuration)
+	res[reflect.TypeOf(Window{})] = SetterFunc(setWindow)
+	res[reflect.TypeOf(ByteSize(0))] = SetterFunc(setByteSize)
+
+	// Network
+	res[reflect.TypeOf(net.IP{})] = SetterFunc(setIP)
+	res[reflect.TypeOf(net.IPNet{})] = SetterFunc(setIPNet)
+	res[reflect.TypeOf(url.URL{})] = SetterFunc(setURL)
+	res[reflect.TypeOf(mail.Address{})] = SetterFunc(setMailAddress)
 
 	return res
 }
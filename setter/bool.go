@@ -0,0 +1,50 @@
+package setter
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// relaxedBools are the values accepted by RelaxedBool on top of the ones
+// accepted by strconv.ParseBool, matched case insensitively.
+var relaxedBools = map[string]bool{
+	"yes":      true,
+	"no":       false,
+	"on":       true,
+	"off":      false,
+	"enabled":  true,
+	"disabled": false,
+}
+
+// RelaxedBool returns a Setter for bools accepting yes/no, on/off and
+// enabled/disabled, case insensitively, in addition to the values accepted by
+// strconv.ParseBool.
+// It is opt-in: register it for the bool type.
+func RelaxedBool() SetterFunc {
+	return SetterFunc(func(strValue string, value reflect.Value) error {
+		v, err := ParseRelaxedBool(strValue)
+
+		if err != nil {
+			return err
+		}
+
+		value.SetBool(v)
+
+		return nil
+	})
+}
+
+// ParseRelaxedBool parses given value the way RelaxedBool does
+func ParseRelaxedBool(strValue string) (bool, error) {
+	if v, err := strconv.ParseBool(strValue); err == nil {
+		return v, nil
+	}
+
+	if v, ok := relaxedBools[strings.ToLower(strings.TrimSpace(strValue))]; ok {
+		return v, nil
+	}
+
+	return false, fmt.Errorf("invalid boolean %s", strValue)
+}
@@ -0,0 +1,109 @@
+package setter
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ByteSize is a number of bytes, parsed from human readable sizes like
+// "512", "10MB" or "1.5GiB". Units are case insensitive, decimal ones (KB, MB,
+// GB, TB, PB) are powers of 1000 while binary ones (KiB, MiB, GiB, TiB, PiB)
+// are powers of 1024.
+type ByteSize uint64
+
+// byteUnit is a unit sizes can be expressed in
+type byteUnit struct {
+	name string
+	size uint64
+}
+
+// byteUnits are sorted by decreasing size, binary units first so sizes are
+// formatted using them when both match.
+var byteUnits = []byteUnit{
+	{"PiB", 1 << 50},
+	{"PB", 1e15},
+	{"TiB", 1 << 40},
+	{"TB", 1e12},
+	{"GiB", 1 << 30},
+	{"GB", 1e9},
+	{"MiB", 1 << 20},
+	{"MB", 1e6},
+	{"KiB", 1 << 10},
+	{"KB", 1e3},
+	{"B", 1},
+}
+
+// String formats the size using the largest unit it is a whole multiple of,
+// so it can be parsed back.
+func (b ByteSize) String() string {
+	for _, unit := range byteUnits {
+		if uint64(b)%unit.size == 0 && uint64(b) >= unit.size {
+			return strconv.FormatUint(uint64(b)/unit.size, 10) + unit.name
+		}
+	}
+
+	return "0B"
+}
+
+func setByteSize(strValue string, value reflect.Value) error {
+	size, err := parseByteSize(strValue)
+
+	if err != nil {
+		return err
+	}
+
+	value.SetUint(size)
+
+	return nil
+}
+
+func parseByteSize(strValue string) (uint64, error) {
+	trimmed := strings.TrimSpace(strValue)
+	number := strings.TrimRightFunc(trimmed, func(r rune) bool {
+		return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z'
+	})
+	unitName := trimmed[len(number):]
+	multiplier := uint64(1)
+
+	if unitName != "" {
+		found := false
+
+		for _, unit := range byteUnits {
+			if strings.EqualFold(unitName, unit.name) {
+				multiplier, found = unit.size, true
+				break
+			}
+		}
+
+		if !found {
+			return 0, fmt.Errorf("invalid size %s, unknown unit %s", strValue, unitName)
+		}
+	}
+
+	number = strings.TrimSpace(number)
+
+	if v, err := strconv.ParseUint(number, 10, 64); err == nil {
+		if v > math.MaxUint64/multiplier {
+			return 0, fmt.Errorf("invalid size %s, out of range", strValue)
+		}
+
+		return v * multiplier, nil
+	}
+
+	v, err := strconv.ParseFloat(number, 64)
+
+	if err != nil || v < 0 {
+		return 0, fmt.Errorf("invalid size %s", strValue)
+	}
+
+	size := v * float64(multiplier)
+
+	if size >= math.MaxUint64 {
+		return 0, fmt.Errorf("invalid size %s, out of range", strValue)
+	}
+
+	return uint64(size), nil
+}
@@ -0,0 +1,56 @@
+package setter
+
+import (
+	"reflect"
+)
+
+// SetContext describes the value a setter assigns
+type SetContext struct {
+	// RawValue is the string to assign
+	RawValue string
+	// Path of the value in the configuration struct, like [Database Host], or
+	// [Routes foo Port] for a map entry. It is empty for values which are not
+	// set from a variable, like map keys or values nested in a JSON object.
+	Path []string
+	// Variable is the name of the variable the value comes from
+	Variable string
+	// Tag is the tag of the struct field holding the value, for collections
+	// elements it is the tag of the collection field.
+	Tag reflect.StructTag
+}
+
+// ContextSetter is a Setter receiving a description of the value it assigns,
+// enabling setters to behave differently per field, for instance according to
+// a struct tag.
+// A ContextSetter registered in a setter collection must also implement
+// Setter, the simplest way being using ContextSetterFunc.
+type ContextSetter interface {
+	SetWithContext(ctx SetContext, val reflect.Value) error
+}
+
+// ContextSetterFunc is a sugar enabling to define a ContextSetter as a function
+type ContextSetterFunc func(SetContext, reflect.Value) error
+
+// SetWithContext calls the ContextSetterFunc function
+func (f ContextSetterFunc) SetWithContext(ctx SetContext, val reflect.Value) error {
+	return f(ctx, val)
+}
+
+// Set calls the ContextSetterFunc function with a context only holding the
+// raw value.
+func (f ContextSetterFunc) Set(strValue string, val reflect.Value) error {
+	return f(SetContext{RawValue: strValue}, val)
+}
+
+// WithContext adapts given Setter into a ContextSetter. Setters which
+// already implement ContextSetter are returned as is, others are called with
+// the raw value.
+func WithContext(s Setter) ContextSetter {
+	if cs, ok := s.(ContextSetter); ok {
+		return cs
+	}
+
+	return ContextSetterFunc(func(ctx SetContext, val reflect.Value) error {
+		return s.Set(ctx.RawValue, val)
+	})
+}
@@ -0,0 +1,77 @@
+package setter
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Flags returns a Setter for integer types used as bitmasks, which parses
+// comma separated flag names like "tracing,compression" into the union of
+// their values, as declared by names. Names are matched case insensitively,
+// unknown names are rejected.
+// It is opt-in: register it for your bitmask type.
+//
+//	setters[reflect.TypeOf(Features(0))] = setter.Flags(map[string]uint64{
+//		"tracing":     uint64(Tracing),
+//		"compression": uint64(Compression),
+//	})
+func Flags(names map[string]uint64) SetterFunc {
+	table := make(map[string]uint64, len(names))
+
+	for name, v := range names {
+		table[strings.ToLower(name)] = v
+	}
+
+	return SetterFunc(func(strValue string, value reflect.Value) error {
+		var mask uint64
+
+		for _, name := range strings.Split(strValue, ",") {
+			name = strings.TrimSpace(name)
+
+			if name == "" {
+				continue
+			}
+
+			v, ok := table[strings.ToLower(name)]
+
+			if !ok {
+				return fmt.Errorf("unknown flag %s, expected one of %s", name, strings.Join(flagNames(names), ", "))
+			}
+
+			mask |= v
+		}
+
+		switch value.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if value.OverflowInt(int64(mask)) || int64(mask) < 0 {
+				return fmt.Errorf("flags %s overflow %v", strValue, value.Type())
+			}
+
+			value.SetInt(int64(mask))
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			if value.OverflowUint(mask) {
+				return fmt.Errorf("flags %s overflow %v", strValue, value.Type())
+			}
+
+			value.SetUint(mask)
+		default:
+			return fmt.Errorf("can't set flags into a %v", value.Type())
+		}
+
+		return nil
+	})
+}
+
+func flagNames(names map[string]uint64) []string {
+	res := make([]string, 0, len(names))
+
+	for name := range names {
+		res = append(res, name)
+	}
+
+	sort.Strings(res)
+
+	return res
+}
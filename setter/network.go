@@ -0,0 +1,59 @@
+package setter
+
+import (
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"reflect"
+)
+
+func setIP(strValue string, value reflect.Value) error {
+	ip := net.ParseIP(strValue)
+
+	if ip == nil {
+		return fmt.Errorf("Invalid IP address [%s]", strValue)
+	}
+
+	value.Set(reflect.ValueOf(ip))
+
+	return nil
+}
+
+// setIPNet parses CIDR notations, like "10.0.0.0/8"
+func setIPNet(strValue string, value reflect.Value) error {
+	_, ipNet, err := net.ParseCIDR(strValue)
+
+	if err != nil {
+		return err
+	}
+
+	value.Set(reflect.ValueOf(*ipNet))
+
+	return nil
+}
+
+func setURL(strValue string, value reflect.Value) error {
+	u, err := url.Parse(strValue)
+
+	if err != nil {
+		return err
+	}
+
+	value.Set(reflect.ValueOf(*u))
+
+	return nil
+}
+
+// setMailAddress parses RFC 5322 addresses, like "Alice <alice@example.com>"
+func setMailAddress(strValue string, value reflect.Value) error {
+	addr, err := mail.ParseAddress(strValue)
+
+	if err != nil {
+		return err
+	}
+
+	value.Set(reflect.ValueOf(*addr))
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package setter
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Of returns a Setter assigning values parsed by given function, sparing the
+// reflect.Value plumbing. Values can be assigned to T and *T targets, pointers
+// being allocated if nil.
+func Of[T any](parse func(string) (T, error)) Setter {
+	return SetterFunc(func(strValue string, val reflect.Value) error {
+		parsed, err := parse(strValue)
+
+		if err != nil {
+			return err
+		}
+
+		res := reflect.ValueOf(&parsed).Elem()
+
+		switch {
+		case val.Type() == res.Type():
+			val.Set(res)
+		case val.Kind() == reflect.Ptr && val.Type().Elem() == res.Type():
+			if val.IsNil() {
+				val.Set(reflect.New(res.Type()))
+			}
+
+			val.Elem().Set(res)
+		default:
+			return fmt.Errorf("Can't assign a %v to a %v", res.Type(), val.Type())
+		}
+
+		return nil
+	})
+}
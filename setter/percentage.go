@@ -0,0 +1,31 @@
+package setter
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Percentage returns a Setter for floats of given bit size, which parses
+// percentages like "75%" into 0.75. Values without a trailing % are parsed
+// as regular floats.
+// It is opt-in: register it for the float types you want to support.
+func Percentage(bitSize int) SetterFunc {
+	return SetterFunc(func(strValue string, value reflect.Value) error {
+		trimmed := strings.TrimSpace(strValue)
+
+		if !strings.HasSuffix(trimmed, "%") {
+			return setFloat(bitSize)(trimmed, value)
+		}
+
+		v, err := strconv.ParseFloat(strings.TrimSuffix(trimmed, "%"), bitSize)
+
+		if err != nil {
+			return err
+		}
+
+		value.SetFloat(v / 100)
+
+		return nil
+	})
+}
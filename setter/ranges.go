@@ -0,0 +1,109 @@
+package setter
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// maxRangeLength bounds the count of integers a value expands into, all its
+// ranges included
+const maxRangeLength = 1 << 16
+
+// IntRanges returns a Setter for slices of integers, which expands compact
+// lists of ranges like "0-3,7,9-10" into []int{0, 1, 2, 3, 7, 9, 10}.
+// It is opt-in: register it for the slice types you want to support.
+func IntRanges() SetterFunc {
+	return SetterFunc(func(strValue string, value reflect.Value) error {
+		elemType := value.Type().Elem()
+		unsigned := isUnsigned(elemType)
+		res := reflect.MakeSlice(value.Type(), 0, 0)
+
+		for _, item := range strings.Split(strValue, ",") {
+			item = strings.TrimSpace(item)
+
+			if item == "" {
+				continue
+			}
+
+			low, length, err := parseRange(item, elemType)
+
+			if err != nil {
+				return err
+			}
+
+			if length >= uint64(maxRangeLength-res.Len()) {
+				return fmt.Errorf("value expands into more than %d integers", maxRangeLength)
+			}
+
+			for i := uint64(0); i <= length; i++ {
+				elem := reflect.New(elemType).Elem()
+
+				// Bounds are two's complement encoded, so this holds for
+				// negative ones too
+				if unsigned {
+					elem.SetUint(low + i)
+				} else {
+					elem.SetInt(int64(low + i))
+				}
+
+				res = reflect.Append(res, elem)
+			}
+		}
+
+		value.Set(res)
+
+		return nil
+	})
+}
+
+// parseRange parses a single integer, or a range of integers like "9-10",
+// returning its lower bound and the count of integers following it.
+// A leading minus is the sign of the lower bound.
+func parseRange(item string, elemType reflect.Type) (uint64, uint64, error) {
+	sep := strings.IndexByte(item[1:], '-') + 1
+
+	if sep == 0 {
+		v, err := parseRangeBound(item, elemType)
+		return v, 0, err
+	}
+
+	low, err := parseRangeBound(item[:sep], elemType)
+
+	if err != nil {
+		return 0, 0, err
+	}
+
+	high, err := parseRangeBound(item[sep+1:], elemType)
+
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if isUnsigned(elemType) && low > high || !isUnsigned(elemType) && int64(low) > int64(high) {
+		return 0, 0, fmt.Errorf("range %s is reversed", item)
+	}
+
+	return low, high - low, nil
+}
+
+// parseRangeBound parses an integer of a range, signed ones being returned
+// two's complement encoded.
+func parseRangeBound(bound string, elemType reflect.Type) (uint64, error) {
+	bound = strings.TrimSpace(bound)
+
+	switch elemType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, err := strconv.ParseInt(bound, 10, elemType.Bits())
+		return uint64(v), err
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.ParseUint(bound, 10, elemType.Bits())
+	default:
+		return 0, fmt.Errorf("can't expand ranges into a slice of %v", elemType)
+	}
+}
+
+func isUnsigned(elemType reflect.Type) bool {
+	return elemType.Kind() >= reflect.Uint && elemType.Kind() <= reflect.Uintptr
+}
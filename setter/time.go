@@ -0,0 +1,57 @@
+package setter
+
+import (
+	"reflect"
+	"time"
+)
+
+// naiveTimeLayouts are layouts without zone information, tried after RFC3339
+var naiveTimeLayouts = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// Time returns a Setter for time.Time, which parses RFC3339 timestamps.
+// Date only and local time values, like "2021-03-14" or
+// "2021-03-14 15:09:26", are parsed in given location.
+func Time(loc *time.Location) SetterFunc {
+	return TimeLayouts(loc)
+}
+
+// TimeLayouts returns a Setter for time.Time like Time, which also tries given
+// layouts in order, after RFC3339 and before the date only and local time
+// ones. Values lacking zone information are parsed in given location.
+func TimeLayouts(loc *time.Location, layouts ...string) SetterFunc {
+	return SetterFunc(func(strValue string, value reflect.Value) error {
+		v, err := time.Parse(time.RFC3339, strValue)
+
+		if err != nil {
+			v, err = parseTimeLayouts(strValue, loc, layouts, err)
+		}
+
+		if err != nil {
+			v, err = parseTimeLayouts(strValue, loc, naiveTimeLayouts, err)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		value.Set(reflect.ValueOf(v))
+
+		return nil
+	})
+}
+
+// parseTimeLayouts parses given value using given layouts, rfcErr is
+// returned if none of them matches.
+func parseTimeLayouts(strValue string, loc *time.Location, layouts []string, rfcErr error) (time.Time, error) {
+	for _, layout := range layouts {
+		if v, err := time.ParseInLocation(layout, strValue, loc); err == nil {
+			return v, nil
+		}
+	}
+
+	return time.Time{}, rfcErr
+}
@@ -0,0 +1,105 @@
+package setter
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+const day = 24 * time.Hour
+
+// Window is a pair of bounds, parsed either from times of day like
+// "09:00-17:00", bounds being offsets since midnight, or from durations like
+// "5m-1h".
+// Windows of times of day may span midnight, like "22:00-06:00", their End
+// is then before their Start.
+type Window struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+// Contains reports if d is within the window, bounds included
+func (w Window) Contains(d time.Duration) bool {
+	if w.End < w.Start {
+		return d >= w.Start || d <= w.End
+	}
+
+	return d >= w.Start && d <= w.End
+}
+
+// ContainsTime reports if the time of day of t is within the window
+func (w Window) ContainsTime(t time.Time) bool {
+	hour, min, sec := t.Clock()
+
+	return w.Contains(time.Duration(hour)*time.Hour + time.Duration(min)*time.Minute + time.Duration(sec)*time.Second)
+}
+
+// String formats the window so it can be parsed back: windows spanning
+// midnight as times of day, others as durations.
+func (w Window) String() string {
+	if w.End < w.Start {
+		return formatTimeOfDay(w.Start) + "-" + formatTimeOfDay(w.End)
+	}
+
+	return w.Start.String() + "-" + w.End.String()
+}
+
+func formatTimeOfDay(d time.Duration) string {
+	return time.Time{}.Add(d).Format("15:04:05")
+}
+
+func setWindow(strValue string, value reflect.Value) error {
+	bounds := strings.SplitN(strValue, "-", 2)
+
+	if len(bounds) != 2 {
+		return fmt.Errorf("invalid window %s, expected start-end", strValue)
+	}
+
+	start, startClock, err := parseWindowBound(bounds[0])
+
+	if err != nil {
+		return err
+	}
+
+	end, endClock, err := parseWindowBound(bounds[1])
+
+	if err != nil {
+		return err
+	}
+
+	if startClock != endClock {
+		return fmt.Errorf("invalid window %s, bounds must both be times of day or durations", strValue)
+	}
+
+	if !startClock && end < start {
+		return fmt.Errorf("invalid window %s, end is before start", strValue)
+	}
+
+	value.Set(reflect.ValueOf(Window{start, end}))
+
+	return nil
+}
+
+// parseWindowBound parses a time of day like "09:00" or "09:00:30" into an
+// offset since midnight, or a duration. Reports if the bound is a time of day.
+func parseWindowBound(bound string) (time.Duration, bool, error) {
+	bound = strings.TrimSpace(bound)
+
+	if !strings.Contains(bound, ":") {
+		d, err := time.ParseDuration(bound)
+		return d, false, err
+	}
+
+	if bound == "24:00" {
+		return day, true, nil
+	}
+
+	for _, layout := range []string{"15:04", "15:04:05"} {
+		if t, err := time.Parse(layout, bound); err == nil {
+			return t.Sub(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())), true, nil
+		}
+	}
+
+	return 0, true, fmt.Errorf("invalid time of day %s, expected HH:MM or HH:MM:SS", bound)
+}
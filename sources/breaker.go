@@ -0,0 +1,209 @@
+package sources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultBreakerThreshold is the default count of consecutive failures
+	// opening a CircuitBreaker
+	DefaultBreakerThreshold = 5
+
+	// DefaultBreakerCooldown is the default duration a CircuitBreaker stays open
+	DefaultBreakerCooldown = 30 * time.Second
+)
+
+// ErrCircuitOpen is reported when a call is not forwarded to the wrapped source
+// because the circuit is open.
+var ErrCircuitOpen = errors.New("Circuit is open, source is skipped")
+
+// BreakerOption customizes a CircuitBreaker
+type BreakerOption func(*CircuitBreaker)
+
+// WithThreshold sets the count of consecutive failures opening the circuit
+func WithThreshold(threshold int) BreakerOption {
+	return func(b *CircuitBreaker) {
+		b.threshold = threshold
+	}
+}
+
+// WithCooldown sets the duration the circuit stays open before a call is
+// forwarded again to the wrapped source.
+func WithCooldown(cooldown time.Duration) BreakerOption {
+	return func(b *CircuitBreaker) {
+		b.cooldown = cooldown
+	}
+}
+
+// WithCachedValues allows the CircuitBreaker to serve the last values
+// successfully fetched from the wrapped source while the circuit is open.
+func WithCachedValues() BreakerOption {
+	return func(b *CircuitBreaker) {
+		b.serveCache = true
+	}
+}
+
+// WithWarnFunc sets the function called when a call is skipped or served from
+// cache because the circuit is open, by default warnings are written to the
+// standard logger. Pass a function doing nothing to discard them.
+func WithWarnFunc(warn func(err error)) BreakerOption {
+	return func(b *CircuitBreaker) {
+		b.warn = warn
+	}
+}
+
+type cachedLookup struct {
+	value string
+	ok    bool
+}
+
+// CircuitBreaker is a Source which stops calling a wrapped source after
+// repeated failures. While the circuit is open, calls either are served from
+// cache if allowed or skip the source, as if it didn't define any key.
+type CircuitBreaker struct {
+	source     Source
+	threshold  int
+	cooldown   time.Duration
+	serveCache bool
+	warn       func(err error)
+	now        func() time.Time
+
+	mu       sync.Mutex
+	failures int
+	openedAt time.Time
+	lookups  map[string]cachedLookup
+	keys     map[string][]string
+}
+
+// Breaker wraps given source into a CircuitBreaker
+func Breaker(source Source, opts ...BreakerOption) *CircuitBreaker {
+	b := &CircuitBreaker{
+		source:    source,
+		threshold: DefaultBreakerThreshold,
+		cooldown:  DefaultBreakerCooldown,
+		warn: func(err error) {
+			log.Println("envconfig:", err)
+		},
+		now:     time.Now,
+		lookups: map[string]cachedLookup{},
+		keys:    map[string][]string{},
+	}
+
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	return b
+}
+
+// Lookup forwards the call to the wrapped source if the circuit is closed
+func (b *CircuitBreaker) Lookup(key string) (string, bool, error) {
+	return b.LookupContext(context.Background(), key)
+}
+
+// LookupContext forwards the call to the wrapped source if the circuit is closed
+func (b *CircuitBreaker) LookupContext(ctx context.Context, key string) (string, bool, error) {
+	if b.isOpen() {
+		b.mu.Lock()
+		cached, ok := b.lookups[key]
+		b.mu.Unlock()
+
+		if ok && b.serveCache {
+			b.warn(fmt.Errorf("%v, serving cached value of key %s", ErrCircuitOpen, key))
+			return cached.value, cached.ok, nil
+		}
+
+		b.warn(fmt.Errorf("%v, skipping lookup of key %s", ErrCircuitOpen, key))
+		return "", false, nil
+	}
+
+	value, ok, err := WithContext(ctx, b.source).Lookup(key)
+
+	if b.record(err) {
+		b.mu.Lock()
+		b.lookups[key] = cachedLookup{value, ok}
+		b.mu.Unlock()
+	}
+
+	return value, ok, err
+}
+
+// Keys forwards the call to the wrapped source if the circuit is closed
+func (b *CircuitBreaker) Keys(prefix string) ([]string, error) {
+	return b.KeysContext(context.Background(), prefix)
+}
+
+// KeysContext forwards the call to the wrapped source if the circuit is closed
+func (b *CircuitBreaker) KeysContext(ctx context.Context, prefix string) ([]string, error) {
+	if b.isOpen() {
+		b.mu.Lock()
+		cached, ok := b.keys[prefix]
+		b.mu.Unlock()
+
+		if ok && b.serveCache {
+			b.warn(fmt.Errorf("%v, serving cached keys with prefix %s", ErrCircuitOpen, prefix))
+			return cached, nil
+		}
+
+		b.warn(fmt.Errorf("%v, skipping listing of keys with prefix %s", ErrCircuitOpen, prefix))
+		return []string{}, nil
+	}
+
+	keys, err := WithContext(ctx, b.source).Keys(prefix)
+
+	if b.record(err) {
+		b.mu.Lock()
+		b.keys[prefix] = keys
+		b.mu.Unlock()
+	}
+
+	return keys, err
+}
+
+// Unwrap returns the wrapped source
+func (b *CircuitBreaker) Unwrap() Source {
+	return b.source
+}
+
+// Health reports ErrCircuitOpen if the circuit is open, otherwise it checks the
+// health of the wrapped source.
+func (b *CircuitBreaker) Health(ctx context.Context) error {
+	if b.isOpen() {
+		return ErrCircuitOpen
+	}
+
+	return CheckHealth(ctx, b.source)
+}
+
+// isOpen reports if calls should not be forwarded to the wrapped source.
+// Once the cooldown is elapsed, calls are forwarded until the next failure.
+func (b *CircuitBreaker) isOpen() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.failures >= b.threshold && b.now().Sub(b.openedAt) < b.cooldown
+}
+
+// record records the outcome of a call, and reports if the call succeeded
+func (b *CircuitBreaker) record(err error) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err == nil {
+		b.failures = 0
+		return true
+	}
+
+	b.failures++
+
+	if b.failures >= b.threshold {
+		b.openedAt = b.now()
+	}
+
+	return false
+}
@@ -0,0 +1,125 @@
+package sources
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	testCases := []struct {
+		Label         string
+		Options       []BreakerOption
+		ExpectedValue string
+		ExpectedOk    bool
+	}{
+		{"WithoutCache", nil, "", false},
+		{"WithCache", []BreakerOption{WithCachedValues()}, "FOO", true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			var (
+				now      = time.Now()
+				warnings int
+				source   = &failingSource{}
+			)
+
+			opts := append(
+				[]BreakerOption{
+					WithThreshold(2),
+					WithCooldown(time.Minute),
+					WithWarnFunc(func(error) { warnings++ }),
+				},
+				testCase.Options...,
+			)
+
+			subject := Breaker(source, opts...)
+			subject.now = func() time.Time { return now }
+
+			// First call succeeds and populates cache
+			if _, _, err := subject.Lookup("APP_FOO"); err != nil {
+				t.Logf("Wasn't expecting an error, got [%v]", err)
+				t.FailNow()
+			}
+
+			// Then two failures open the circuit
+			source.errors = []error{errTransient, errTransient}
+			subject.Lookup("APP_FOO")
+			subject.Lookup("APP_FOO")
+
+			value, ok, err := subject.Lookup("APP_FOO")
+
+			if err != nil || value != testCase.ExpectedValue || ok != testCase.ExpectedOk {
+				t.Logf(
+					"Expected [%s, %t, nil] got [%s, %t, %v]",
+					testCase.ExpectedValue,
+					testCase.ExpectedOk,
+					value,
+					ok,
+					err,
+				)
+				t.Fail()
+			}
+
+			if source.calls != 3 {
+				t.Logf("Expected source to be called 3 times, got %d", source.calls)
+				t.Fail()
+			}
+
+			if warnings != 1 {
+				t.Logf("Expected 1 warning, got %d", warnings)
+				t.Fail()
+			}
+
+			// Once cooldown is elapsed calls are forwarded again
+			now = now.Add(time.Minute)
+
+			if value, ok, err := subject.Lookup("APP_FOO"); err != nil || !ok || value != "FOO" {
+				t.Logf("Expected [FOO, true, nil] got [%s, %t, %v]", value, ok, err)
+				t.Fail()
+			}
+
+			if source.calls != 4 {
+				t.Logf("Expected source to be called 4 times, got %d", source.calls)
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestCircuitBreakerWarnings(t *testing.T) {
+	var output bytes.Buffer
+
+	log.SetOutput(&output)
+	defer log.SetOutput(os.Stderr)
+
+	testCases := []struct {
+		Label       string
+		Options     []BreakerOption
+		Expectation bool
+	}{
+		{"WithDefaults", nil, true},
+		{"WithDiscardedWarnings", []BreakerOption{WithWarnFunc(func(error) {})}, false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			output.Reset()
+
+			source := &failingSource{errors: []error{errTransient}}
+			subject := Breaker(source, append([]BreakerOption{WithThreshold(1)}, testCase.Options...)...)
+
+			// First failure opens the circuit, then the lookup is skipped
+			subject.Lookup("APP_FOO")
+			subject.Lookup("APP_FOO")
+
+			if logged := output.Len() > 0; logged != testCase.Expectation {
+				t.Logf("Expected warnings to be logged: %t, got output %q", testCase.Expectation, output.String())
+				t.Fail()
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package sources
+
+import (
+	"context"
+)
+
+// BulkSource is implemented by sources able to look many keys up in a single
+// call, typically remote stores exposing a batch API.
+type BulkSource interface {
+	Source
+
+	// BulkLookup retrieves values stored at given keys, undefined keys are
+	// left out of the result.
+	BulkLookup(keys []string) (map[string]string, error)
+}
+
+// Prefetch looks given keys up in a single call if source implements
+// BulkSource, until ctx is done. The returned source serves prefetched keys
+// from the result and forwards other calls to source.
+// Sources which do not implement BulkSource are returned as is.
+func Prefetch(ctx context.Context, source Source, keys []string) (Source, error) {
+	bulk, ok := source.(BulkSource)
+
+	if !ok || len(keys) == 0 {
+		return source, nil
+	}
+
+	var (
+		values map[string]string
+		err    error
+	)
+
+	runErr := (&boundSource{ctx, source}).run(func() {
+		values, err = bulk.BulkLookup(keys)
+	})
+
+	if runErr != nil {
+		return nil, runErr
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	prefetched := make(map[string]struct{}, len(keys))
+
+	for _, key := range keys {
+		prefetched[key] = struct{}{}
+	}
+
+	return &prefetchedSource{source, values, prefetched}, nil
+}
+
+type prefetchedSource struct {
+	source     Source
+	values     map[string]string
+	prefetched map[string]struct{}
+}
+
+func (p *prefetchedSource) Lookup(key string) (string, bool, error) {
+	return p.LookupContext(context.Background(), key)
+}
+
+func (p *prefetchedSource) LookupContext(ctx context.Context, key string) (string, bool, error) {
+	if _, ok := p.prefetched[key]; !ok {
+		return WithContext(ctx, p.source).Lookup(key)
+	}
+
+	value, ok := p.values[key]
+
+	return value, ok, nil
+}
+
+func (p *prefetchedSource) Keys(prefix string) ([]string, error) {
+	return p.KeysContext(context.Background(), prefix)
+}
+
+func (p *prefetchedSource) KeysContext(ctx context.Context, prefix string) ([]string, error) {
+	return WithContext(ctx, p.source).Keys(prefix)
+}
+
+func (p *prefetchedSource) Unwrap() Source {
+	return p.source
+}
+
+func (p *prefetchedSource) Health(ctx context.Context) error {
+	return CheckHealth(ctx, p.source)
+}
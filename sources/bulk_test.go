@@ -0,0 +1,86 @@
+package sources
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+// bulkSource serves FOO for any key, and records lookups
+type bulkSource struct {
+	lookups []string
+	bulks   [][]string
+}
+
+func (b *bulkSource) Lookup(key string) (string, bool, error) {
+	b.lookups = append(b.lookups, key)
+	return "FOO", true, nil
+}
+
+func (b *bulkSource) Keys(prefix string) ([]string, error) {
+	return nil, nil
+}
+
+func (b *bulkSource) BulkLookup(keys []string) (map[string]string, error) {
+	b.bulks = append(b.bulks, keys)
+	return map[string]string{"APP_FOO": "BULK"}, nil
+}
+
+func TestPrefetch(t *testing.T) {
+	source := &bulkSource{}
+
+	subject, err := Prefetch(context.Background(), source, []string{"APP_FOO", "APP_BAR"})
+
+	if err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	testCases := []struct {
+		Key           string
+		ExpectedValue string
+		ExpectedOk    bool
+	}{
+		{"APP_FOO", "BULK", true},
+		{"APP_BAR", "", false},
+		{"APP_BIZ", "FOO", true},
+	}
+
+	for _, testCase := range testCases {
+		value, ok, err := subject.Lookup(testCase.Key)
+
+		if err != nil || value != testCase.ExpectedValue || ok != testCase.ExpectedOk {
+			t.Logf(
+				"Expected [%s, %t, nil] for key %s got [%s, %t, %v]",
+				testCase.ExpectedValue,
+				testCase.ExpectedOk,
+				testCase.Key,
+				value,
+				ok,
+				err,
+			)
+			t.Fail()
+		}
+	}
+
+	if !reflect.DeepEqual(source.bulks, [][]string{{"APP_FOO", "APP_BAR"}}) {
+		t.Logf("Expected a single bulk lookup, got %v", source.bulks)
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual(source.lookups, []string{"APP_BIZ"}) {
+		t.Logf("Expected only APP_BIZ to be looked up, got %v", source.lookups)
+		t.Fail()
+	}
+}
+
+func TestPrefetchWithoutBulkSource(t *testing.T) {
+	source := &failingSource{}
+
+	subject, err := Prefetch(context.Background(), source, []string{"APP_FOO"})
+
+	if err != nil || subject != Source(source) {
+		t.Logf("Expected source to be returned as is, got %v, %v", subject, err)
+		t.Fail()
+	}
+}
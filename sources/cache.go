@@ -0,0 +1,195 @@
+package sources
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultCacheTTL is the default duration a CachingSource serves a value
+// without looking it up again.
+const DefaultCacheTTL = time.Minute
+
+// CacheOption customizes a CachingSource
+type CacheOption func(*CachingSource)
+
+// WithTTL sets the duration values and keys listings are cached for
+func WithTTL(ttl time.Duration) CacheOption {
+	return func(c *CachingSource) {
+		c.ttl = func(string) time.Duration { return ttl }
+	}
+}
+
+// WithTTLFunc sets the function giving the duration a key, or a keys listing
+// prefix, is cached for. Returning 0 disables caching for this key.
+func WithTTLFunc(ttl func(key string) time.Duration) CacheOption {
+	return func(c *CachingSource) {
+		c.ttl = ttl
+	}
+}
+
+// WithStaleWhileRevalidate allows the CachingSource to serve expired entries
+// for given duration after their expiration, while they are looked up again in
+// background.
+func WithStaleWhileRevalidate(window time.Duration) CacheOption {
+	return func(c *CachingSource) {
+		c.staleWindow = window
+	}
+}
+
+type cacheKey struct {
+	listing bool
+	key     string
+}
+
+type cacheEntry struct {
+	value      string
+	ok         bool
+	keys       []string
+	expiresAt  time.Time
+	refreshing bool
+}
+
+// CachingSource is a Source caching calls to a wrapped source, so frequent
+// reloads don't hammer remote stores. Failed calls are never cached.
+type CachingSource struct {
+	source      Source
+	ttl         func(key string) time.Duration
+	staleWindow time.Duration
+	now         func() time.Time
+	// background runs refreshes of stale entries
+	background func(func())
+
+	mu      sync.Mutex
+	entries map[cacheKey]*cacheEntry
+}
+
+// Cache wraps given source into a CachingSource
+func Cache(source Source, opts ...CacheOption) *CachingSource {
+	c := &CachingSource{
+		source:  source,
+		ttl:     func(string) time.Duration { return DefaultCacheTTL },
+		now:     time.Now,
+		entries: map[cacheKey]*cacheEntry{},
+		background: func(refresh func()) {
+			go refresh()
+		},
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Lookup serves the value of key from cache, or from the wrapped source
+func (c *CachingSource) Lookup(key string) (string, bool, error) {
+	return c.LookupContext(context.Background(), key)
+}
+
+// LookupContext serves the value of key from cache, or from the wrapped
+// source until ctx is done
+func (c *CachingSource) LookupContext(ctx context.Context, key string) (string, bool, error) {
+	entry, err := c.get(ctx, cacheKey{false, key}, func(source Source) (*cacheEntry, error) {
+		value, ok, err := source.Lookup(key)
+		return &cacheEntry{value: value, ok: ok}, err
+	})
+
+	if err != nil {
+		return "", false, err
+	}
+
+	return entry.value, entry.ok, nil
+}
+
+// Keys serves keys starting with prefix from cache, or from the wrapped source
+func (c *CachingSource) Keys(prefix string) ([]string, error) {
+	return c.KeysContext(context.Background(), prefix)
+}
+
+// KeysContext serves keys starting with prefix from cache, or from the
+// wrapped source until ctx is done
+func (c *CachingSource) KeysContext(ctx context.Context, prefix string) ([]string, error) {
+	entry, err := c.get(ctx, cacheKey{true, prefix}, func(source Source) (*cacheEntry, error) {
+		keys, err := source.Keys(prefix)
+		return &cacheEntry{keys: keys}, err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return entry.keys, nil
+}
+
+// Unwrap returns the wrapped source
+func (c *CachingSource) Unwrap() Source {
+	return c.source
+}
+
+// Health checks the health of the wrapped source
+func (c *CachingSource) Health(ctx context.Context) error {
+	return CheckHealth(ctx, c.source)
+}
+
+// Invalidate drops every cached entry
+func (c *CachingSource) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = map[cacheKey]*cacheEntry{}
+}
+
+// get serves the entry of key from cache if it is fresh, or stale while being
+// refreshed in background. Otherwise fetch is called synchronously.
+func (c *CachingSource) get(ctx context.Context, key cacheKey, fetch func(Source) (*cacheEntry, error)) (*cacheEntry, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	now := c.now()
+
+	switch {
+	case ok && now.Before(entry.expiresAt):
+		c.mu.Unlock()
+		return entry, nil
+	case ok && now.Before(entry.expiresAt.Add(c.staleWindow)):
+		refresh := !entry.refreshing
+		entry.refreshing = true
+		c.mu.Unlock()
+
+		if refresh {
+			c.background(func() {
+				c.fetch(context.Background(), key, fetch)
+			})
+		}
+
+		return entry, nil
+	}
+
+	c.mu.Unlock()
+
+	return c.fetch(ctx, key, fetch)
+}
+
+// fetch calls the wrapped source, then caches the result if the call succeeded
+func (c *CachingSource) fetch(ctx context.Context, key cacheKey, fetch func(Source) (*cacheEntry, error)) (*cacheEntry, error) {
+	entry, err := fetch(WithContext(ctx, c.source))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err != nil {
+		if previous, ok := c.entries[key]; ok {
+			previous.refreshing = false
+		}
+
+		return nil, err
+	}
+
+	if ttl := c.ttl(key.key); ttl > 0 {
+		entry.expiresAt = c.now().Add(ttl)
+		c.entries[key] = entry
+	}
+
+	return entry, nil
+}
@@ -0,0 +1,124 @@
+package sources
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCachingSource(t *testing.T) {
+	testCases := []struct {
+		Label         string
+		Options       []CacheOption
+		Elapsed       time.Duration
+		ExpectedCalls int
+	}{
+		{"WithFreshEntry", []CacheOption{WithTTL(time.Minute)}, 30 * time.Second, 1},
+		{"WithExpiredEntry", []CacheOption{WithTTL(time.Minute)}, 2 * time.Minute, 2},
+		{
+			"WithStaleEntry",
+			[]CacheOption{WithTTL(time.Minute), WithStaleWhileRevalidate(time.Minute)},
+			90 * time.Second,
+			2,
+		},
+		{
+			"WithUncachedKey",
+			[]CacheOption{WithTTLFunc(func(string) time.Duration { return 0 })},
+			0,
+			2,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			var (
+				now    = time.Now()
+				source = &failingSource{}
+			)
+
+			subject := Cache(source, testCase.Options...)
+			subject.now = func() time.Time { return now }
+			subject.background = func(refresh func()) { refresh() }
+
+			if _, _, err := subject.Lookup("APP_FOO"); err != nil {
+				t.Logf("Wasn't expecting an error, got [%v]", err)
+				t.FailNow()
+			}
+
+			now = now.Add(testCase.Elapsed)
+
+			value, ok, err := subject.Lookup("APP_FOO")
+
+			if err != nil || value != "FOO" || !ok {
+				t.Logf("Expected [FOO, true, nil] got [%s, %t, %v]", value, ok, err)
+				t.Fail()
+			}
+
+			if source.calls != testCase.ExpectedCalls {
+				t.Logf("Expected %d calls, got %d", testCase.ExpectedCalls, source.calls)
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestCachingSourceServesStaleEntry(t *testing.T) {
+	var (
+		now    = time.Now()
+		source = &failingSource{}
+	)
+
+	subject := Cache(source, WithTTL(time.Minute), WithStaleWhileRevalidate(time.Minute))
+	subject.now = func() time.Time { return now }
+	// Refreshes run before stale entries are served, so calls can be counted
+	subject.background = func(refresh func()) { refresh() }
+
+	subject.Keys("APP_")
+
+	// Refresh fails, stale entry is still served
+	now = now.Add(90 * time.Second)
+	source.errors = []error{errTransient}
+
+	if keys, err := subject.Keys("APP_"); err != nil || len(keys) != 1 {
+		t.Logf("Expected stale keys, got %v, %v", keys, err)
+		t.Fail()
+	}
+
+	// Next call triggers another refresh
+	subject.Keys("APP_")
+
+	if source.calls != 3 {
+		t.Logf("Expected 3 calls, got %d", source.calls)
+		t.Fail()
+	}
+
+	// Once refreshed, entry is fresh again
+	subject.Keys("APP_")
+
+	if source.calls != 3 {
+		t.Logf("Expected refreshed entry to be served, got %d calls", source.calls)
+		t.Fail()
+	}
+}
+
+func TestCachingSourceDoesNotCacheErrors(t *testing.T) {
+	source := &failingSource{errors: []error{errTransient}}
+	subject := Cache(source)
+
+	if _, _, err := subject.Lookup("APP_FOO"); err != errTransient {
+		t.Logf("Expected [%v] got [%v]", errTransient, err)
+		t.Fail()
+	}
+
+	if value, _, err := subject.Lookup("APP_FOO"); err != nil || value != "FOO" {
+		t.Logf("Expected [FOO, nil] got [%s, %v]", value, err)
+		t.Fail()
+	}
+
+	subject.Invalidate()
+	subject.Lookup("APP_FOO")
+
+	if source.calls != 3 {
+		t.Logf("Expected 3 calls, got %d", source.calls)
+		t.Fail()
+	}
+}
@@ -0,0 +1,114 @@
+package sources
+
+import (
+	"context"
+)
+
+// ContextSource is implemented by sources supporting cancellation, typically
+// sources backed by a remote store.
+type ContextSource interface {
+	Source
+
+	LookupContext(ctx context.Context, key string) (string, bool, error)
+	KeysContext(ctx context.Context, prefix string) ([]string, error)
+}
+
+// WithContext binds given source to ctx: its calls return ctx's error as soon as
+// ctx is done. Sources which do not implement ContextSource keep running in
+// background until their call completes, but the result is discarded.
+func WithContext(ctx context.Context, source Source) Source {
+	return &boundSource{ctx, source}
+}
+
+type boundSource struct {
+	ctx    context.Context
+	source Source
+}
+
+func (b *boundSource) Lookup(key string) (string, bool, error) {
+	if source, ok := b.source.(ContextSource); ok {
+		return source.LookupContext(b.ctx, key)
+	}
+
+	type result struct {
+		value string
+		ok    bool
+		err   error
+	}
+
+	res := make(chan result, 1)
+
+	err := b.run(func() {
+		value, ok, err := b.source.Lookup(key)
+		res <- result{value, ok, err}
+	})
+
+	if err != nil {
+		return "", false, err
+	}
+
+	r := <-res
+
+	return r.value, r.ok, r.err
+}
+
+func (b *boundSource) Keys(prefix string) ([]string, error) {
+	if source, ok := b.source.(ContextSource); ok {
+		return source.KeysContext(b.ctx, prefix)
+	}
+
+	type result struct {
+		keys []string
+		err  error
+	}
+
+	res := make(chan result, 1)
+
+	err := b.run(func() {
+		keys, err := b.source.Keys(prefix)
+		res <- result{keys, err}
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	r := <-res
+
+	return r.keys, r.err
+}
+
+func (b *boundSource) Unwrap() Source {
+	return b.source
+}
+
+func (b *boundSource) Health(ctx context.Context) error {
+	return CheckHealth(ctx, b.source)
+}
+
+// run runs call until it completes or ctx is done
+func (b *boundSource) run(call func()) error {
+	if err := b.ctx.Err(); err != nil {
+		return err
+	}
+
+	// Context can never be done, avoid spawning a goroutine
+	if b.ctx.Done() == nil {
+		call()
+		return nil
+	}
+
+	done := make(chan struct{})
+
+	go func() {
+		call()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-b.ctx.Done():
+		return b.ctx.Err()
+	}
+}
@@ -0,0 +1,79 @@
+package sources
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// slowSource answers after given delay
+type slowSource struct {
+	delay time.Duration
+}
+
+func (s slowSource) Lookup(key string) (string, bool, error) {
+	time.Sleep(s.delay)
+	return "FOO", true, nil
+}
+
+func (s slowSource) Keys(prefix string) ([]string, error) {
+	time.Sleep(s.delay)
+	return []string{prefix}, nil
+}
+
+func TestWithContext(t *testing.T) {
+	testCases := []struct {
+		Label       string
+		Delay       time.Duration
+		Timeout     time.Duration
+		Expectation error
+	}{
+		{"WithFastSource", 0, time.Second, nil},
+		{"WithSlowSource", time.Second, 10 * time.Millisecond, context.DeadlineExceeded},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), testCase.Timeout)
+			defer cancel()
+
+			subject := WithContext(ctx, slowSource{testCase.Delay})
+
+			if _, _, err := subject.Lookup("APP_FOO"); err != testCase.Expectation {
+				t.Logf("Expected [%v] got [%v]", testCase.Expectation, err)
+				t.Fail()
+			}
+
+			if _, err := subject.Keys("APP"); err != testCase.Expectation {
+				t.Logf("Expected [%v] got [%v]", testCase.Expectation, err)
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestWithContextOnInMemorySources(t *testing.T) {
+	for _, testCase := range []struct {
+		Label  string
+		Source Source
+	}{
+		{"WithMap", Map(map[string]string{"APP_FOO": "FOO"})},
+		{"WithEnv", Env()},
+	} {
+		t.Run(testCase.Label, func(t *testing.T) {
+			// ContextSources are called directly, without a goroutine per call
+			if _, ok := testCase.Source.(ContextSource); !ok {
+				t.Logf("Expected %T to implement ContextSource", testCase.Source)
+				t.FailNow()
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			if _, _, err := WithContext(ctx, testCase.Source).Lookup("APP_FOO"); err != context.Canceled {
+				t.Logf("Expected [%v] got [%v]", context.Canceled, err)
+				t.Fail()
+			}
+		})
+	}
+}
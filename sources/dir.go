@@ -0,0 +1,84 @@
+package sources
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Dir returns a Source serving the content of the files of given directory,
+// each file being named after its key, eg: /run/secrets/GROOT_DB_PASSWORD.
+// It matches Docker and Kubernetes mounted secrets. Files are read on each
+// lookup, a single trailing newline is trimmed. Hidden files and
+// subdirectories are ignored.
+func Dir(dir string) Source {
+	return &dirSource{dir}
+}
+
+type dirSource struct {
+	dir string
+}
+
+func (d *dirSource) Lookup(key string) (string, bool, error) {
+	if !isFileKey(key) {
+		return "", false, nil
+	}
+
+	content, err := os.ReadFile(filepath.Join(d.dir, key))
+
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return "", false, nil
+	case err != nil:
+		// Directories named after the key aren't values
+		if info, statErr := os.Stat(filepath.Join(d.dir, key)); statErr == nil && info.IsDir() {
+			return "", false, nil
+		}
+
+		return "", false, err
+	}
+
+	value := strings.TrimSuffix(string(content), "\n")
+
+	return strings.TrimSuffix(value, "\r"), true, nil
+}
+
+func (d *dirSource) Keys(prefix string) ([]string, error) {
+	entries, err := os.ReadDir(d.dir)
+
+	if errors.Is(err, os.ErrNotExist) {
+		return []string{}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := []string{}
+
+	for _, entry := range entries {
+		name := entry.Name()
+
+		if !isFileKey(name) || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+
+		// Follows symlinks, which is how Kubernetes mounts keys
+		if info, err := os.Stat(filepath.Join(d.dir, name)); err != nil || info.IsDir() {
+			continue
+		}
+
+		res = append(res, name)
+	}
+
+	return res, nil
+}
+
+// isFileKey reports if key can be served by a file of the directory, keys
+// holding path separators or naming hidden files can't.
+func isFileKey(key string) bool {
+	return key != "" &&
+		!strings.HasPrefix(key, ".") &&
+		!strings.ContainsAny(key, `/\`)
+}
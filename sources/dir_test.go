@@ -0,0 +1,94 @@
+package sources
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestDir(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"GROOT_DB_PASSWORD": "secret\n",
+		"GROOT_DB_USER":     "groot",
+		"GROOT_MULTILINE":   "a\nb\r\n",
+		"OTHER_TOKEN":       "token",
+		".hidden":           "hidden",
+	}
+
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+			t.Logf("Wasn't expecting an error, got [%v]", err)
+			t.FailNow()
+		}
+	}
+
+	if err := os.Mkdir(filepath.Join(dir, "GROOT_DIR"), 0700); err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	source := Dir(dir)
+
+	testCases := []struct {
+		Label       string
+		Key         string
+		Expectation string
+		Found       bool
+	}{
+		{"WithTrailingNewline", "GROOT_DB_PASSWORD", "secret", true},
+		{"WithoutTrailingNewline", "GROOT_DB_USER", "groot", true},
+		{"WithMultipleLines", "GROOT_MULTILINE", "a\nb", true},
+		{"WithMissingFile", "GROOT_DB_HOST", "", false},
+		{"WithDirectory", "GROOT_DIR", "", false},
+		{"WithHiddenFile", ".hidden", "", false},
+		{"WithPath", "../GROOT_DB_USER", "", false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			value, found, err := source.Lookup(testCase.Key)
+
+			if err != nil {
+				t.Logf("Wasn't expecting an error, got [%v]", err)
+				t.FailNow()
+			}
+
+			if value != testCase.Expectation || found != testCase.Found {
+				t.Logf("Expected %q %t, got %q %t", testCase.Expectation, testCase.Found, value, found)
+				t.Fail()
+			}
+		})
+	}
+
+	keys, err := source.Keys("GROOT_DB_")
+
+	if err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	sort.Strings(keys)
+
+	if expectation := []string{"GROOT_DB_PASSWORD", "GROOT_DB_USER"}; !reflect.DeepEqual(keys, expectation) {
+		t.Logf("Expected %v got %v", expectation, keys)
+		t.Fail()
+	}
+}
+
+func TestDirWithMissingDirectory(t *testing.T) {
+	source := Dir(filepath.Join(t.TempDir(), "missing"))
+
+	if _, found, err := source.Lookup("GROOT"); found || err != nil {
+		t.Logf("Expected [false, nil] got [%t, %v]", found, err)
+		t.Fail()
+	}
+
+	if keys, err := source.Keys(""); len(keys) != 0 || err != nil {
+		t.Logf("Expected [[], nil] got [%v, %v]", keys, err)
+		t.Fail()
+	}
+}
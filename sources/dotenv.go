@@ -0,0 +1,191 @@
+package sources
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Dotenv returns a Source serving variables defined in given .env document.
+// Lines are KEY=value assignments, optionally prefixed by export, blank lines
+// and lines starting with # are ignored.
+// Single quoted values are taken literally. Double quoted values understand
+// \n, \t, \" and \\ escapes. Unquoted values are trimmed and end at the first
+// " #" comment. References like $KEY or ${KEY} are expanded in double quoted
+// and unquoted values, using previously defined variables then the process
+// environment.
+func Dotenv(r io.Reader) (Source, error) {
+	res := mapSource{}
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+
+	for scanner.Scan() {
+		lineNumber++
+
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || line[0] == '#' {
+			continue
+		}
+
+		key, value, err := parseDotenvLine(line, res.expand)
+
+		if err != nil {
+			return nil, fmt.Errorf("Invalid assignment at line %d: %v", lineNumber, err)
+		}
+
+		res[key] = value
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// DotenvFile returns a Source serving variables defined in .env file at given
+// path
+func DotenvFile(path string) (Source, error) {
+	f, err := os.Open(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	return Dotenv(f)
+}
+
+// parseDotenvLine parses a [export ]KEY=value line
+func parseDotenvLine(line string, expand func(string) string) (string, string, error) {
+	if strings.HasPrefix(line, "export ") || strings.HasPrefix(line, "export\t") {
+		line = strings.TrimSpace(line[len("export"):])
+	}
+
+	eq := strings.IndexByte(line, '=')
+
+	if eq <= 0 {
+		return "", "", fmt.Errorf("expected KEY=value, got %s", line)
+	}
+
+	key := strings.TrimSpace(line[:eq])
+
+	if !isShellName(key) {
+		return "", "", fmt.Errorf("invalid variable name %s", key)
+	}
+
+	value, err := parseDotenvValue(strings.TrimSpace(line[eq+1:]), expand)
+
+	if err != nil {
+		return "", "", err
+	}
+
+	return key, value, nil
+}
+
+// parseDotenvValue parses a single quoted, double quoted or unquoted value,
+// which may be followed by a comment.
+func parseDotenvValue(raw string, expand func(string) string) (string, error) {
+	if raw == "" {
+		return "", nil
+	}
+
+	switch raw[0] {
+	case '\'':
+		end := strings.IndexByte(raw[1:], '\'')
+
+		if end < 0 {
+			return "", fmt.Errorf("unterminated single quote in %s", raw)
+		}
+
+		return raw[1 : end+1], checkTrailing(raw[end+2:])
+	case '"':
+		var b strings.Builder
+
+		for i := 1; i < len(raw); i++ {
+			switch c := raw[i]; {
+			case c == '"':
+				return b.String(), checkTrailing(raw[i+1:])
+			case c == '\\' && i+1 < len(raw):
+				i++
+
+				switch raw[i] {
+				case 'n':
+					b.WriteByte('\n')
+				case 't':
+					b.WriteByte('\t')
+				case 'r':
+					b.WriteByte('\r')
+				case '"', '\\', '$':
+					b.WriteByte(raw[i])
+				default:
+					b.WriteByte('\\')
+					b.WriteByte(raw[i])
+				}
+			case c == '$':
+				name, width := shellReference(raw[i+1:])
+
+				if width == 0 {
+					b.WriteByte(c)
+					continue
+				}
+
+				b.WriteString(expand(name))
+				i += width
+			default:
+				b.WriteByte(c)
+			}
+		}
+
+		return "", fmt.Errorf("unterminated double quote in %s", raw)
+	}
+
+	if i := strings.Index(raw, " #"); i >= 0 {
+		raw = strings.TrimSpace(raw[:i])
+	}
+
+	return expandReferences(raw, expand), nil
+}
+
+// checkTrailing fails if anything but a comment follows a quoted value
+func checkTrailing(rest string) error {
+	rest = strings.TrimSpace(rest)
+
+	if rest != "" && rest[0] != '#' {
+		return fmt.Errorf("unexpected %s after quoted value", rest)
+	}
+
+	return nil
+}
+
+// expandReferences expands $KEY and ${KEY} references of s
+func expandReferences(s string, expand func(string) string) string {
+	if !strings.Contains(s, "$") {
+		return s
+	}
+
+	var b strings.Builder
+
+	for i := 0; i < len(s); i++ {
+		if s[i] != '$' {
+			b.WriteByte(s[i])
+			continue
+		}
+
+		name, width := shellReference(s[i+1:])
+
+		if width == 0 {
+			b.WriteByte(s[i])
+			continue
+		}
+
+		b.WriteString(expand(name))
+		i += width
+	}
+
+	return b.String()
+}
@@ -0,0 +1,77 @@
+package sources
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDotenv(t *testing.T) {
+	os.Setenv("DOTENV_TEST_HOME", "/home/groot")
+	defer os.Unsetenv("DOTENV_TEST_HOME")
+
+	testCases := []struct {
+		Label        string
+		Document     string
+		Expectation  mapSource
+		ExpectsError bool
+	}{
+		{
+			"WithAssignments",
+			`
+# A comment
+
+GROOT_MODE=extended
+export GROOT_EXPORTED=1
+GROOT_SPACES = hello world # Inline comment
+GROOT_HASH=foo#bar
+GROOT_SINGLE='$NOT_EXPANDED # not a comment'
+GROOT_DIR=$DOTENV_TEST_HOME/config
+GROOT_FILE="${GROOT_DIR}/groot.hcl" # Inline comment
+GROOT_ESCAPED="say \"hi\"\nbye"
+GROOT_EMPTY=
+`,
+			mapSource{
+				"GROOT_MODE":     "extended",
+				"GROOT_EXPORTED": "1",
+				"GROOT_SPACES":   "hello world",
+				"GROOT_HASH":     "foo#bar",
+				"GROOT_SINGLE":   "$NOT_EXPANDED # not a comment",
+				"GROOT_DIR":      "/home/groot/config",
+				"GROOT_FILE":     "/home/groot/config/groot.hcl",
+				"GROOT_ESCAPED":  "say \"hi\"\nbye",
+				"GROOT_EMPTY":    "",
+			},
+			false,
+		},
+		{"WithMissingAssignment", "GROOT", nil, true},
+		{"WithInvalidName", "1GROOT=foo", nil, true},
+		{"WithUnterminatedQuote", `GROOT="foo`, nil, true},
+		{"WithTrailingGarbage", `GROOT="foo" bar`, nil, true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			result, err := Dotenv(strings.NewReader(testCase.Document))
+
+			if testCase.ExpectsError {
+				if err == nil {
+					t.Log("Expected an error, got nothing")
+					t.Fail()
+				}
+				return
+			}
+
+			if err != nil {
+				t.Logf("Wasn't expecting an error, got [%v]", err)
+				t.FailNow()
+			}
+
+			if !reflect.DeepEqual(result, testCase.Expectation) {
+				t.Logf("Expected %v got %v", testCase.Expectation, result)
+				t.Fail()
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package sources
+
+import (
+	"context"
+	"os"
+	"strings"
+)
+
+// Env returns a Source backed by the process environment
+func Env() Source {
+	return env{}
+}
+
+// env never blocks, so it implements ContextSource to be bound to a context
+// without a goroutine per call.
+type env struct{}
+
+func (e env) LookupContext(ctx context.Context, key string) (string, bool, error) {
+	if err := ctx.Err(); err != nil {
+		return "", false, err
+	}
+
+	return e.Lookup(key)
+}
+
+func (e env) KeysContext(ctx context.Context, prefix string) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return e.Keys(prefix)
+}
+
+func (env) Lookup(key string) (string, bool, error) {
+	value, ok := os.LookupEnv(key)
+	return value, ok, nil
+}
+
+func (env) Keys(prefix string) ([]string, error) {
+	res := []string{}
+
+	for _, rawVar := range os.Environ() {
+		varName := strings.Split(rawVar, "=")[0]
+		if strings.HasPrefix(varName, prefix) {
+			res = append(res, varName)
+		}
+	}
+
+	return res, nil
+}
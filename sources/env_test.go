@@ -0,0 +1,97 @@
+package sources
+
+import (
+	"os"
+	"sort"
+	"testing"
+)
+
+func TestEnvKeys(t *testing.T) {
+	subject := Env()
+
+	testCases := []struct {
+		Label       string
+		Prefix      string
+		Env         map[string]string
+		Expectation []string
+	}{
+		{
+			"WithPrefix",
+			"APP",
+			map[string]string{
+				"STRING_VALUE":   "FOOO",
+				"INT_VALUE":      "10",
+				"BOOL_VALUE":     "true",
+				"APP_BOOL_VALUE": "true",
+				"APP_BAR_VALUE":  "true",
+			},
+			[]string{"APP_BAR_VALUE", "APP_BOOL_VALUE"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			for k, v := range testCase.Env {
+				os.Setenv(k, v)
+			}
+
+			defer func() {
+				for k := range testCase.Env {
+					os.Unsetenv(k)
+				}
+			}()
+
+			res, err := subject.Keys(testCase.Prefix)
+
+			if err != nil {
+				t.Logf("Wasn't expecting an error, got [%v]", err)
+				t.FailNow()
+			}
+
+			if len(res) != len(testCase.Expectation) {
+				t.Logf("Expected %d keys got %d", len(testCase.Expectation), len(res))
+				t.FailNow()
+			}
+
+			sort.Strings(res)
+
+			for i, envVar := range testCase.Expectation {
+				if envVar != res[i] {
+					t.Logf("Invalid env variableName, expected [%s] got [%s]", envVar, res[i])
+					t.Fail()
+				}
+			}
+		})
+	}
+}
+
+func TestEnvLookup(t *testing.T) {
+	os.Setenv("APP_DEFINED", "FOO")
+	defer os.Unsetenv("APP_DEFINED")
+
+	testCases := []struct {
+		Label     string
+		Key       string
+		Value     string
+		IsDefined bool
+	}{
+		{"WithDefinedKey", "APP_DEFINED", "FOO", true},
+		{"WithUndefinedKey", "APP_UNDEFINED", "", false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			value, ok, err := Env().Lookup(testCase.Key)
+
+			if err != nil {
+				t.Logf("Wasn't expecting an error, got [%v]", err)
+				t.FailNow()
+			}
+
+			if value != testCase.Value || ok != testCase.IsDefined {
+				t.Logf("Expected [%s, %t] got [%s, %t]", testCase.Value, testCase.IsDefined, value, ok)
+				t.Fail()
+			}
+		})
+	}
+}
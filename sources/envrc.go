@@ -0,0 +1,204 @@
+package sources
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Envrc returns a Source serving variables exported by given direnv style
+// .envrc document. Only `export KEY=value` lines are understood, other lines
+// (direnv stdlib calls, shell logic...) are ignored.
+// Values can be single quoted, double quoted or unquoted, and variables
+// references like $KEY or ${KEY} are expanded in double quoted and unquoted
+// values, using previously exported variables then the process environment.
+func Envrc(r io.Reader) (Source, error) {
+	res := mapSource{}
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+
+	for scanner.Scan() {
+		lineNumber++
+
+		line := strings.TrimSpace(scanner.Text())
+
+		if !strings.HasPrefix(line, "export ") {
+			continue
+		}
+
+		key, value, err := parseAssignment(strings.TrimSpace(strings.TrimPrefix(line, "export ")), res.expand)
+
+		if err != nil {
+			return nil, fmt.Errorf("Invalid export at line %d: %v", lineNumber, err)
+		}
+
+		res[key] = value
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// EnvrcFile returns a Source serving variables exported by .envrc file at given
+// path
+func EnvrcFile(path string) (Source, error) {
+	f, err := os.Open(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	return Envrc(f)
+}
+
+// expand returns the value of a referenced variable, looked up in m then in the
+// process environment.
+func (m mapSource) expand(key string) string {
+	if value, ok := m[key]; ok {
+		return value
+	}
+
+	return os.Getenv(key)
+}
+
+// parseAssignment parses a KEY=value shell assignment
+func parseAssignment(assignment string, expand func(string) string) (string, string, error) {
+	eq := strings.IndexByte(assignment, '=')
+
+	if eq <= 0 {
+		return "", "", fmt.Errorf("expected KEY=value, got %s", assignment)
+	}
+
+	key := assignment[:eq]
+
+	if !isShellName(key) {
+		return "", "", fmt.Errorf("invalid variable name %s", key)
+	}
+
+	value, err := parseShellValue(assignment[eq+1:], expand)
+
+	if err != nil {
+		return "", "", err
+	}
+
+	return key, value, nil
+}
+
+// parseShellValue parses a shell value, which may be made of single quoted,
+// double quoted and unquoted parts. Unquoted whitespace ends the value.
+func parseShellValue(raw string, expand func(string) string) (string, error) {
+	var b strings.Builder
+
+	for i := 0; i < len(raw); i++ {
+		switch c := raw[i]; {
+		case c == '\'':
+			end := strings.IndexByte(raw[i+1:], '\'')
+
+			if end < 0 {
+				return "", fmt.Errorf("unterminated single quote in %s", raw)
+			}
+
+			b.WriteString(raw[i+1 : i+1+end])
+			i += end + 1
+		case c == '"':
+			j := i + 1
+
+			for ; j < len(raw) && raw[j] != '"'; j++ {
+				if raw[j] == '\\' && j+1 < len(raw) && strings.IndexByte("\"\\$`", raw[j+1]) >= 0 {
+					b.WriteByte(raw[j+1])
+					j++
+					continue
+				}
+
+				if raw[j] == '$' {
+					name, width := shellReference(raw[j+1:])
+
+					if width > 0 {
+						b.WriteString(expand(name))
+						j += width
+						continue
+					}
+				}
+
+				b.WriteByte(raw[j])
+			}
+
+			if j >= len(raw) {
+				return "", fmt.Errorf("unterminated double quote in %s", raw)
+			}
+
+			i = j
+		case c == '\\' && i+1 < len(raw):
+			b.WriteByte(raw[i+1])
+			i++
+		case c == '$':
+			name, width := shellReference(raw[i+1:])
+
+			if width == 0 {
+				b.WriteByte(c)
+				continue
+			}
+
+			b.WriteString(expand(name))
+			i += width
+		case c == ' ' || c == '\t':
+			// Unquoted whitespace ends the value, anything after is either a
+			// comment or another command.
+			return b.String(), nil
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return b.String(), nil
+}
+
+// shellReference parses a variable reference following a $, either NAME or
+// {NAME}. Returns the name and the count of bytes consumed.
+func shellReference(s string) (string, int) {
+	if strings.HasPrefix(s, "{") {
+		end := strings.IndexByte(s, '}')
+
+		if end < 0 || !isShellName(s[1:end]) {
+			return "", 0
+		}
+
+		return s[1:end], end + 1
+	}
+
+	end := 0
+
+	for end < len(s) && isShellNameChar(s[end], end == 0) {
+		end++
+	}
+
+	return s[:end], end
+}
+
+func isShellName(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for i := 0; i < len(s); i++ {
+		if !isShellNameChar(s[i], i == 0) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isShellNameChar(c byte, first bool) bool {
+	return c == '_' ||
+		(c >= 'a' && c <= 'z') ||
+		(c >= 'A' && c <= 'Z') ||
+		(!first && c >= '0' && c <= '9')
+}
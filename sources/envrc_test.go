@@ -0,0 +1,71 @@
+package sources
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEnvrc(t *testing.T) {
+	os.Setenv("ENVRC_TEST_HOME", "/home/groot")
+	defer os.Unsetenv("ENVRC_TEST_HOME")
+
+	testCases := []struct {
+		Label        string
+		Document     string
+		Expectation  mapSource
+		ExpectsError bool
+	}{
+		{
+			"WithExports",
+			`
+# A comment
+source_up
+export GROOT_MODE=extended
+export GROOT_REAL="1" # Inline comment
+export GROOT_SINGLE='$NOT_EXPANDED'
+export GROOT_DIR=$ENVRC_TEST_HOME/config
+export GROOT_FILE="${GROOT_DIR}/groot.hcl"
+export GROOT_ESCAPED="say \"hi\""
+PATH_add bin
+GROOT_NOT_EXPORTED=1
+`,
+			mapSource{
+				"GROOT_MODE":    "extended",
+				"GROOT_REAL":    "1",
+				"GROOT_SINGLE":  "$NOT_EXPANDED",
+				"GROOT_DIR":     "/home/groot/config",
+				"GROOT_FILE":    "/home/groot/config/groot.hcl",
+				"GROOT_ESCAPED": `say "hi"`,
+			},
+			false,
+		},
+		{"WithInvalidName", "export 1GROOT=foo", nil, true},
+		{"WithUnterminatedQuote", `export GROOT="foo`, nil, true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			result, err := Envrc(strings.NewReader(testCase.Document))
+
+			if testCase.ExpectsError {
+				if err == nil {
+					t.Log("Expected an error, got nothing")
+					t.Fail()
+				}
+				return
+			}
+
+			if err != nil {
+				t.Logf("Wasn't expecting an error, got [%v]", err)
+				t.FailNow()
+			}
+
+			if !reflect.DeepEqual(result, testCase.Expectation) {
+				t.Logf("Expected %v got %v", testCase.Expectation, result)
+				t.Fail()
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package sources
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/jlevesy/envconfig/internal/tags"
+)
+
+// flatten turns a tree of map[string]interface{}, []interface{} and string
+// leaves into a map of keys named like the loader names variables: words of
+// path segments are upper cased and joined using separator, list items are
+// indexed.
+func flatten(prefix, separator string, tree map[string]interface{}) mapSource {
+	res := mapSource{}
+	root := []string{}
+
+	if prefix != "" {
+		root = append(root, prefix)
+	}
+
+	flattenNode(res, root, separator, tree)
+
+	return res
+}
+
+func flattenNode(res mapSource, keyPath []string, separator string, node interface{}) {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		for key, child := range n {
+			flattenNode(res, append(keyPath[:len(keyPath):len(keyPath)], key), separator, child)
+		}
+	case []interface{}:
+		for i, child := range n {
+			flattenNode(res, append(keyPath[:len(keyPath):len(keyPath)], strconv.Itoa(i)), separator, child)
+		}
+	case string:
+		res[flatKey(keyPath, separator)] = n
+	}
+}
+
+// flatKey names a key after its path like the loader names fields: dashes
+// separate words, and so do case changes, so both max-conns and maxConns are
+// named MAX_CONNS.
+func flatKey(keyPath []string, separator string) string {
+	words := make([]string, 0, len(keyPath))
+
+	for _, key := range keyPath {
+		words = append(words, strings.Split(key, "-")...)
+	}
+
+	return tags.VariableName(separator, words...)
+}
@@ -0,0 +1,427 @@
+package sources
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// HCL returns a Source serving values defined in given HCL document.
+// Keys are flattened the same way the loader names variables: attributes
+// nested into blocks or objects are named after their path, list items after
+// their index, and labels of blocks are part of the path. Words of keys are
+// split on dashes and case changes, like the loader splits field names.
+//
+//	database {
+//	  host = "localhost" // => PREFIX_DATABASE_HOST
+//	  max-conns = 10     // => PREFIX_DATABASE_MAX_CONNS
+//	}
+//	spliners "0" {
+//	  red = 3.2          // => PREFIX_SPLINERS_0_RED
+//	}
+//	repos = ["a", "b"]   // => PREFIX_REPOS_0, PREFIX_REPOS_1
+//
+// Only a subset of HCL is supported: attributes, blocks, objects, lists,
+// strings, heredocs, numbers and booleans. Expressions are not evaluated.
+// Blocks repeated with the same labels are rejected, as they would be served
+// under the same keys: label them to tell them apart.
+func HCL(r io.Reader, prefix, separator string) (Source, error) {
+	content, err := ioutil.ReadAll(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	p := &hclParser{src: string(content)}
+
+	body, err := p.parseBody(false)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return flatten(prefix, separator, body), nil
+}
+
+// HCLFile returns a Source serving values defined in HCL file at given path
+func HCLFile(path, prefix, separator string) (Source, error) {
+	f, err := os.Open(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	return HCL(f, prefix, separator)
+}
+
+type hclParser struct {
+	src string
+	pos int
+}
+
+func (p *hclParser) errorf(format string, args ...interface{}) error {
+	line := strings.Count(p.src[:p.pos], "\n") + 1
+	return fmt.Errorf("Invalid HCL at line %d: %s", line, fmt.Sprintf(format, args...))
+}
+
+func (p *hclParser) eof() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *hclParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+
+	return p.src[p.pos]
+}
+
+// skipSpace skips whitespaces, newlines and comments
+func (p *hclParser) skipSpace() {
+	for !p.eof() {
+		rest := p.src[p.pos:]
+
+		switch {
+		case unicode.IsSpace(rune(rest[0])):
+			p.pos++
+		case rest[0] == '#' || strings.HasPrefix(rest, "//"):
+			if end := strings.IndexByte(rest, '\n'); end >= 0 {
+				p.pos += end + 1
+			} else {
+				p.pos = len(p.src)
+			}
+		case strings.HasPrefix(rest, "/*"):
+			if end := strings.Index(rest, "*/"); end >= 0 {
+				p.pos += end + 2
+			} else {
+				p.pos = len(p.src)
+			}
+		default:
+			return
+		}
+	}
+}
+
+// parseBody parses attributes and blocks until EOF, or until a closing brace
+// if nested.
+func (p *hclParser) parseBody(nested bool) (map[string]interface{}, error) {
+	body := map[string]interface{}{}
+
+	for {
+		p.skipSpace()
+
+		if p.eof() {
+			if nested {
+				return nil, p.errorf("unexpected end of document, missing }")
+			}
+
+			return body, nil
+		}
+
+		if p.peek() == '}' {
+			if !nested {
+				return nil, p.errorf("unexpected }")
+			}
+
+			p.pos++
+			return body, nil
+		}
+
+		name, err := p.parseKey()
+
+		if err != nil {
+			return nil, err
+		}
+
+		p.skipSpace()
+
+		if c := p.peek(); c == '=' || c == ':' {
+			p.pos++
+
+			value, err := p.parseValue()
+
+			if err != nil {
+				return nil, err
+			}
+
+			body[name] = value
+
+			p.skipSpace()
+
+			if p.peek() == ',' {
+				p.pos++
+			}
+
+			continue
+		}
+
+		// Otherwise we're facing a block, which might be labeled
+		keys := []string{name}
+
+		for p.peek() != '{' {
+			if p.eof() {
+				return nil, p.errorf("unexpected end of document in block %s", name)
+			}
+
+			label, err := p.parseKey()
+
+			if err != nil {
+				return nil, err
+			}
+
+			keys = append(keys, label)
+			p.skipSpace()
+		}
+
+		p.pos++
+
+		block, err := p.parseBody(true)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.insertBlock(body, keys, block); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// parseKey parses an identifier or a quoted string
+func (p *hclParser) parseKey() (string, error) {
+	if p.peek() == '"' {
+		return p.parseString()
+	}
+
+	start := p.pos
+
+	for !p.eof() && isHCLIdentChar(p.peek()) {
+		p.pos++
+	}
+
+	if start == p.pos {
+		return "", p.errorf("unexpected character %q", p.peek())
+	}
+
+	return p.src[start:p.pos], nil
+}
+
+func (p *hclParser) parseValue() (interface{}, error) {
+	p.skipSpace()
+
+	switch {
+	case p.eof():
+		return nil, p.errorf("unexpected end of document, missing value")
+	case p.peek() == '"':
+		return p.parseString()
+	case p.peek() == '[':
+		p.pos++
+		return p.parseList()
+	case p.peek() == '{':
+		p.pos++
+		return p.parseBody(true)
+	case strings.HasPrefix(p.src[p.pos:], "<<"):
+		return p.parseHeredoc()
+	}
+
+	start := p.pos
+
+	for !p.eof() && (isHCLIdentChar(p.peek()) || p.peek() == '+') {
+		p.pos++
+	}
+
+	literal := p.src[start:p.pos]
+
+	switch literal {
+	case "":
+		return nil, p.errorf("unexpected character %q", p.peek())
+	case "null":
+		return nil, nil
+	}
+
+	return literal, nil
+}
+
+func (p *hclParser) parseString() (string, error) {
+	start := p.pos
+	p.pos++
+
+	for !p.eof() && p.peek() != '"' {
+		if p.peek() == '\\' {
+			p.pos++
+		}
+
+		p.pos++
+	}
+
+	if p.eof() {
+		return "", p.errorf("unterminated string")
+	}
+
+	p.pos++
+
+	res, err := strconv.Unquote(p.src[start:p.pos])
+
+	if err != nil {
+		return "", p.errorf("invalid string %s", p.src[start:p.pos])
+	}
+
+	return res, nil
+}
+
+func (p *hclParser) parseList() ([]interface{}, error) {
+	res := []interface{}{}
+
+	for {
+		p.skipSpace()
+
+		if p.peek() == ']' {
+			p.pos++
+			return res, nil
+		}
+
+		value, err := p.parseValue()
+
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, value)
+
+		p.skipSpace()
+
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case ']':
+		default:
+			return nil, p.errorf("unexpected character %q in list", p.peek())
+		}
+	}
+}
+
+// parseHeredoc parses <<MARKER and <<-MARKER strings, the latter strips the
+// common indentation of lines.
+func (p *hclParser) parseHeredoc() (string, error) {
+	p.pos += 2
+
+	indented := p.peek() == '-'
+	if indented {
+		p.pos++
+	}
+
+	end := strings.IndexByte(p.src[p.pos:], '\n')
+
+	if end < 0 {
+		return "", p.errorf("unterminated heredoc")
+	}
+
+	marker := strings.TrimSpace(p.src[p.pos : p.pos+end])
+	p.pos += end + 1
+
+	var lines []string
+
+	for {
+		if p.eof() {
+			return "", p.errorf("unterminated heredoc, missing %s", marker)
+		}
+
+		end := strings.IndexByte(p.src[p.pos:], '\n')
+		if end < 0 {
+			end = len(p.src) - p.pos
+		}
+
+		line := p.src[p.pos : p.pos+end]
+		p.pos += end
+
+		if !p.eof() {
+			p.pos++
+		}
+
+		if strings.TrimSpace(line) == marker {
+			break
+		}
+
+		lines = append(lines, line)
+	}
+
+	if indented {
+		lines = unindent(lines)
+	}
+
+	return strings.Join(lines, "\n") + "\n", nil
+}
+
+func unindent(lines []string) []string {
+	indent := -1
+
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		width := len(line) - len(strings.TrimLeft(line, " \t"))
+
+		if indent < 0 || width < indent {
+			indent = width
+		}
+	}
+
+	res := make([]string, len(lines))
+
+	for i, line := range lines {
+		if len(line) >= indent && indent > 0 {
+			line = line[indent:]
+		}
+
+		res[i] = line
+	}
+
+	return res
+}
+
+// insertBlock inserts a block under the given keys, next to blocks of the same
+// type with different labels. It fails if a value is already defined under
+// these keys.
+func (p *hclParser) insertBlock(body map[string]interface{}, keys []string, block map[string]interface{}) error {
+	for i, key := range keys {
+		existing, ok := body[key]
+
+		if i == len(keys)-1 {
+			if ok {
+				return p.errorf("block %s is defined twice", strings.Join(keys, " "))
+			}
+
+			body[key] = block
+			break
+		}
+
+		child, isMap := existing.(map[string]interface{})
+
+		if ok && !isMap {
+			return p.errorf("block %s conflicts with attribute %s", strings.Join(keys, " "), key)
+		}
+
+		if !ok {
+			child = map[string]interface{}{}
+			body[key] = child
+		}
+
+		body = child
+	}
+
+	return nil
+}
+
+func isHCLIdentChar(c byte) bool {
+	return c == '_' || c == '-' || c == '.' ||
+		(c >= 'a' && c <= 'z') ||
+		(c >= 'A' && c <= 'Z') ||
+		(c >= '0' && c <= '9')
+}
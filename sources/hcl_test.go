@@ -0,0 +1,123 @@
+package sources
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestHCL(t *testing.T) {
+	testCases := []struct {
+		Label        string
+		Document     string
+		Expectation  mapSource
+		ExpectsError bool
+	}{
+		{
+			"WithAttributes",
+			`
+# A comment
+lateralizer_mode = "extended" // Another comment
+real = true
+streamlining_ratio = 543
+/* A multi
+line comment */
+timeout: "2s"
+`,
+			mapSource{
+				"GROOT_LATERALIZER_MODE":   "extended",
+				"GROOT_REAL":               "true",
+				"GROOT_STREAMLINING_RATIO": "543",
+				"GROOT_TIMEOUT":            "2s",
+			},
+			false,
+		},
+		{
+			"WithBlocksAndObjects",
+			`
+database {
+  host = "localhost"
+  credentials = { user = "groot", password = "i\"am" }
+}
+
+spliners "0" {
+  red = 3.29395
+}
+
+spliners "100" {
+  white = -3.29394
+}
+`,
+			mapSource{
+				"GROOT_DATABASE_HOST":                 "localhost",
+				"GROOT_DATABASE_CREDENTIALS_USER":     "groot",
+				"GROOT_DATABASE_CREDENTIALS_PASSWORD": `i"am`,
+				"GROOT_SPLINERS_0_RED":                "3.29395",
+				"GROOT_SPLINERS_100_WHITE":            "-3.29394",
+			},
+			false,
+		},
+		{
+			"WithListsAndHeredoc",
+			`
+repos = ["foo", "bar",]
+policy = <<-EOF
+  line one
+    line two
+  EOF
+`,
+			mapSource{
+				"GROOT_REPOS_0": "foo",
+				"GROOT_REPOS_1": "bar",
+				"GROOT_POLICY":  "line one\n  line two\n",
+			},
+			false,
+		},
+		{
+			"WithKebabAndCamelCaseKeys",
+			`
+max-conns = 10
+maxIdleConns = 2
+pool "read-only" {
+  queueSize = 5
+}
+`,
+			mapSource{
+				"GROOT_MAX_CONNS":                 "10",
+				"GROOT_MAX_IDLE_CONNS":            "2",
+				"GROOT_POOL_READ_ONLY_QUEUE_SIZE": "5",
+			},
+			false,
+		},
+		{"WithRepeatedBlock", "database {\n  host = \"a\"\n}\ndatabase {\n  port = 5432\n}", nil, true},
+		{"WithRepeatedLabeledBlock", "pool \"a\" {\n}\npool \"a\" {\n}", nil, true},
+		{"WithBlockOverAttribute", "pool = \"a\"\npool \"b\" {\n}", nil, true},
+		{"WithUnclosedBlock", `database {`, nil, true},
+		{"WithUnterminatedString", `host = "localhost`, nil, true},
+		{"WithMissingValue", `host =`, nil, true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			result, err := HCL(strings.NewReader(testCase.Document), "GROOT", "_")
+
+			if testCase.ExpectsError {
+				if err == nil {
+					t.Log("Expected an error, got nothing")
+					t.Fail()
+				}
+				return
+			}
+
+			if err != nil {
+				t.Logf("Wasn't expecting an error, got [%v]", err)
+				t.FailNow()
+			}
+
+			if !reflect.DeepEqual(result, testCase.Expectation) {
+				t.Logf("Expected %v got %v", testCase.Expectation, result)
+				t.Fail()
+			}
+		})
+	}
+}
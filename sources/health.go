@@ -0,0 +1,23 @@
+package sources
+
+import (
+	"context"
+)
+
+// HealthChecker is implemented by sources able to report their health, for
+// instance the connectivity to their backend.
+type HealthChecker interface {
+	Health(ctx context.Context) error
+}
+
+// CheckHealth checks the health of given source, sources which don't implement
+// HealthChecker are considered healthy.
+func CheckHealth(ctx context.Context, source Source) error {
+	checker, ok := source.(HealthChecker)
+
+	if !ok {
+		return nil
+	}
+
+	return checker.Health(ctx)
+}
@@ -0,0 +1,71 @@
+package sources
+
+import (
+	"context"
+)
+
+// Layered returns a Source combining given layers, earlier layers overriding
+// later ones: a key is looked up in each layer until one defines it. For
+// instance Layered(Env(), dotenv, defaults) lets the process environment
+// override a .env file, which overrides defaults.
+func Layered(layers ...Source) Source {
+	return layeredSource(layers)
+}
+
+type layeredSource []Source
+
+func (l layeredSource) Lookup(key string) (string, bool, error) {
+	return l.LookupContext(context.Background(), key)
+}
+
+func (l layeredSource) LookupContext(ctx context.Context, key string) (string, bool, error) {
+	for _, layer := range l {
+		value, ok, err := WithContext(ctx, layer).Lookup(key)
+
+		if err != nil {
+			return "", false, err
+		}
+
+		if ok {
+			return value, true, nil
+		}
+	}
+
+	return "", false, nil
+}
+
+func (l layeredSource) Keys(prefix string) ([]string, error) {
+	return l.KeysContext(context.Background(), prefix)
+}
+
+func (l layeredSource) KeysContext(ctx context.Context, prefix string) ([]string, error) {
+	res := []string{}
+	seen := map[string]bool{}
+
+	for _, layer := range l {
+		keys, err := WithContext(ctx, layer).Keys(prefix)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, key := range keys {
+			if !seen[key] {
+				seen[key] = true
+				res = append(res, key)
+			}
+		}
+	}
+
+	return res, nil
+}
+
+func (l layeredSource) Health(ctx context.Context) error {
+	for _, layer := range l {
+		if err := CheckHealth(ctx, layer); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
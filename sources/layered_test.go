@@ -0,0 +1,76 @@
+package sources
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestLayered(t *testing.T) {
+	source := Layered(
+		mapSource{"APP_PORT": "80"},
+		mapSource{"APP_PORT": "8080", "APP_HOST": "localhost"},
+		mapSource{"APP_HOST": "0.0.0.0", "APP_DEBUG": "false"},
+	)
+
+	testCases := []struct {
+		Label       string
+		Key         string
+		Expectation string
+		Found       bool
+	}{
+		{"FirstLayer", "APP_PORT", "80", true},
+		{"SecondLayer", "APP_HOST", "localhost", true},
+		{"LastLayer", "APP_DEBUG", "false", true},
+		{"Undefined", "APP_NAME", "", false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			value, found, err := source.Lookup(testCase.Key)
+
+			if err != nil {
+				t.Logf("Wasn't expecting an error, got [%v]", err)
+				t.FailNow()
+			}
+
+			if value != testCase.Expectation || found != testCase.Found {
+				t.Logf("Expected %q %t, got %q %t", testCase.Expectation, testCase.Found, value, found)
+				t.Fail()
+			}
+		})
+	}
+
+	keys, err := source.Keys("APP_")
+
+	if err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	sort.Strings(keys)
+
+	if expectation := []string{"APP_DEBUG", "APP_HOST", "APP_PORT"}; !reflect.DeepEqual(keys, expectation) {
+		t.Logf("Expected %v got %v", expectation, keys)
+		t.Fail()
+	}
+}
+
+func TestLayeredWithFailingLayer(t *testing.T) {
+	source := Layered(mapSource{"APP_PORT": "80"}, &failingSource{errors: []error{errFatal, errFatal}})
+
+	if value, found, err := source.Lookup("APP_PORT"); value != "80" || !found || err != nil {
+		t.Logf("Expected [80, true, nil] got [%s, %t, %v]", value, found, err)
+		t.Fail()
+	}
+
+	if _, _, err := source.Lookup("APP_HOST"); err == nil {
+		t.Log("Expected an error, got nothing")
+		t.Fail()
+	}
+
+	if _, err := source.Keys("APP_"); err == nil {
+		t.Log("Expected an error, got nothing")
+		t.Fail()
+	}
+}
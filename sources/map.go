@@ -0,0 +1,56 @@
+package sources
+
+import (
+	"context"
+	"strings"
+)
+
+// Map returns a Source serving given values, to load configuration structs in
+// tests without touching the process environment. Values are copied, later
+// changes of the map aren't seen by the source.
+func Map(values map[string]string) Source {
+	res := make(mapSource, len(values))
+
+	for key, value := range values {
+		res[key] = value
+	}
+
+	return res
+}
+
+// mapSource is a Source backed by a map. It never blocks, so it implements
+// ContextSource to be bound to a context without a goroutine per call.
+type mapSource map[string]string
+
+func (m mapSource) LookupContext(ctx context.Context, key string) (string, bool, error) {
+	if err := ctx.Err(); err != nil {
+		return "", false, err
+	}
+
+	return m.Lookup(key)
+}
+
+func (m mapSource) KeysContext(ctx context.Context, prefix string) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return m.Keys(prefix)
+}
+
+func (m mapSource) Lookup(key string) (string, bool, error) {
+	value, ok := m[key]
+	return value, ok, nil
+}
+
+func (m mapSource) Keys(prefix string) ([]string, error) {
+	res := []string{}
+
+	for key := range m {
+		if strings.HasPrefix(key, prefix) {
+			res = append(res, key)
+		}
+	}
+
+	return res, nil
+}
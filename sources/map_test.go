@@ -0,0 +1,46 @@
+package sources
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestMap(t *testing.T) {
+	values := map[string]string{
+		"APP_PORT":  "8080",
+		"APP_HOST":  "localhost",
+		"REDIS_URL": "redis://localhost",
+	}
+
+	source := Map(values)
+
+	// Changes of the map aren't seen by the source
+	values["APP_PORT"] = "9090"
+
+	value, found, err := source.Lookup("APP_PORT")
+
+	if value != "8080" || !found || err != nil {
+		t.Logf("Expected [8080, true, nil] got [%s, %t, %v]", value, found, err)
+		t.Fail()
+	}
+
+	if _, found, _ = source.Lookup("APP_DEBUG"); found {
+		t.Logf("Wasn't expecting APP_DEBUG to be found")
+		t.Fail()
+	}
+
+	keys, err := source.Keys("APP_")
+
+	if err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	sort.Strings(keys)
+
+	if expectation := []string{"APP_HOST", "APP_PORT"}; !reflect.DeepEqual(keys, expectation) {
+		t.Logf("Expected %v got %v", expectation, keys)
+		t.Fail()
+	}
+}
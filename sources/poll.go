@@ -0,0 +1,227 @@
+package sources
+
+import (
+	"context"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MinPollInterval is the minimum interval allowed between two refreshes of a Poller
+const MinPollInterval = time.Second
+
+// Notifier is implemented by sources able to report changes of their content
+type Notifier interface {
+	Changes() <-chan struct{}
+}
+
+// PollOption customizes a Poller
+type PollOption func(*Poller)
+
+// WithJitter adds a random delay, up to given fraction of the interval, between
+// two refreshes. It avoids having a fleet of instances hitting a remote
+// backend at the same time.
+func WithJitter(fraction float64) PollOption {
+	return func(p *Poller) {
+		p.jitter = fraction
+	}
+}
+
+// Poller is a Source serving a snapshot of a wrapped source, refreshed on an
+// interval. It is meant to wrap remote sources: lookups never hit the
+// backend, and changes between two snapshots are reported through Changes.
+type Poller struct {
+	source   Source
+	interval time.Duration
+	jitter   float64
+	changes  chan struct{}
+
+	mu       sync.RWMutex
+	snapshot map[string]string
+	err      error
+}
+
+// Poll wraps given source into a Poller refreshing every interval.
+// Intervals lower than MinPollInterval are raised to MinPollInterval.
+func Poll(source Source, interval time.Duration, opts ...PollOption) *Poller {
+	if interval < MinPollInterval {
+		interval = MinPollInterval
+	}
+
+	p := &Poller{
+		source:   source,
+		interval: interval,
+		changes:  make(chan struct{}, 1),
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// Run refreshes the snapshot on every interval until ctx is done.
+// Failed refreshes keep serving the previous snapshot.
+func (p *Poller) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(p.nextInterval()):
+			p.Refresh()
+		}
+	}
+}
+
+// Refresh fetches all keys and values from the wrapped source and replaces the
+// current snapshot, a change is reported if the snapshot differs.
+func (p *Poller) Refresh() error {
+	snapshot, err := p.fetch()
+
+	p.mu.Lock()
+	p.err = err
+
+	if err != nil {
+		p.mu.Unlock()
+		return err
+	}
+
+	changed := p.snapshot != nil && !sameSnapshot(p.snapshot, snapshot)
+	p.snapshot = snapshot
+	p.mu.Unlock()
+
+	if changed {
+		// Do not block if a change is already pending
+		select {
+		case p.changes <- struct{}{}:
+		default:
+		}
+	}
+
+	return nil
+}
+
+func (p *Poller) fetch() (map[string]string, error) {
+	keys, err := p.source.Keys("")
+
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := make(map[string]string, len(keys))
+
+	for _, key := range keys {
+		value, ok, err := p.source.Lookup(key)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			snapshot[key] = value
+		}
+	}
+
+	return snapshot, nil
+}
+
+// Unwrap returns the wrapped source
+func (p *Poller) Unwrap() Source {
+	return p.source
+}
+
+// Health reports the error of the last refresh if it failed, otherwise it
+// checks the health of the wrapped source.
+func (p *Poller) Health(ctx context.Context) error {
+	p.mu.RLock()
+	err := p.err
+	p.mu.RUnlock()
+
+	if err != nil {
+		return err
+	}
+
+	return CheckHealth(ctx, p.source)
+}
+
+// Changes reports snapshot changes
+func (p *Poller) Changes() <-chan struct{} {
+	return p.changes
+}
+
+// Lookup looks given key up in the current snapshot
+func (p *Poller) Lookup(key string) (string, bool, error) {
+	if err := p.ensureSnapshot(); err != nil {
+		return "", false, err
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	value, ok := p.snapshot[key]
+
+	return value, ok, nil
+}
+
+// Keys lists keys starting with given prefix in the current snapshot
+func (p *Poller) Keys(prefix string) ([]string, error) {
+	if err := p.ensureSnapshot(); err != nil {
+		return nil, err
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	res := []string{}
+
+	for key := range p.snapshot {
+		if strings.HasPrefix(key, prefix) {
+			res = append(res, key)
+		}
+	}
+
+	return res, nil
+}
+
+// ensureSnapshot performs a first refresh if none has been performed yet
+func (p *Poller) ensureSnapshot() error {
+	p.mu.RLock()
+	ready := p.snapshot != nil
+	p.mu.RUnlock()
+
+	if ready {
+		return nil
+	}
+
+	return p.Refresh()
+}
+
+func (p *Poller) nextInterval() time.Duration {
+	if p.jitter <= 0 {
+		return p.interval
+	}
+
+	maxJitter := int64(float64(p.interval) * p.jitter)
+
+	if maxJitter <= 0 {
+		return p.interval
+	}
+
+	return p.interval + time.Duration(rand.Int63n(maxJitter))
+}
+
+func sameSnapshot(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for key, value := range a {
+		if other, ok := b[key]; !ok || other != value {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,123 @@
+package sources
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// mutableSource is a Source backed by a map which can be updated concurrently
+type mutableSource struct {
+	sync.Mutex
+	values  map[string]string
+	lookups int
+}
+
+func (m *mutableSource) set(key, value string) {
+	m.Lock()
+	defer m.Unlock()
+	m.values[key] = value
+}
+
+func (m *mutableSource) Lookup(key string) (string, bool, error) {
+	m.Lock()
+	defer m.Unlock()
+	m.lookups++
+	value, ok := m.values[key]
+	return value, ok, nil
+}
+
+func (m *mutableSource) Keys(prefix string) ([]string, error) {
+	m.Lock()
+	defer m.Unlock()
+
+	res := []string{}
+	for key := range m.values {
+		if strings.HasPrefix(key, prefix) {
+			res = append(res, key)
+		}
+	}
+
+	return res, nil
+}
+
+func TestPollerServesSnapshot(t *testing.T) {
+	backend := &mutableSource{values: map[string]string{"APP_FOO": "BAR"}}
+	subject := Poll(backend, time.Minute)
+
+	value, ok, err := subject.Lookup("APP_FOO")
+
+	if err != nil || !ok || value != "BAR" {
+		t.Logf("Expected [BAR, true, nil] got [%s, %t, %v]", value, ok, err)
+		t.FailNow()
+	}
+
+	backend.set("APP_FOO", "BIZ")
+
+	if value, _, _ := subject.Lookup("APP_FOO"); value != "BAR" {
+		t.Logf("Expected snapshot value [BAR] got [%s]", value)
+		t.Fail()
+	}
+
+	if backend.lookups != 1 {
+		t.Logf("Expected backend to be hit once, got %d", backend.lookups)
+		t.Fail()
+	}
+
+	if err := subject.Refresh(); err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	if value, _, _ := subject.Lookup("APP_FOO"); value != "BIZ" {
+		t.Logf("Expected refreshed value [BIZ] got [%s]", value)
+		t.Fail()
+	}
+
+	select {
+	case <-subject.Changes():
+	default:
+		t.Log("Expected a change to be reported")
+		t.Fail()
+	}
+}
+
+func TestPollerDoesNotReportUnchangedSnapshot(t *testing.T) {
+	subject := Poll(&mutableSource{values: map[string]string{"APP_FOO": "BAR"}}, time.Minute)
+
+	subject.Refresh()
+	subject.Refresh()
+
+	select {
+	case <-subject.Changes():
+		t.Log("Wasn't expecting a change to be reported")
+		t.Fail()
+	default:
+	}
+}
+
+func TestPollerIntervalGuard(t *testing.T) {
+	testCases := []struct {
+		Label    string
+		Interval time.Duration
+		Jitter   float64
+		Min      time.Duration
+		Max      time.Duration
+	}{
+		{"WithTooShortInterval", time.Millisecond, 0, MinPollInterval, MinPollInterval},
+		{"WithInterval", time.Minute, 0, time.Minute, time.Minute},
+		{"WithJitter", time.Minute, 0.5, time.Minute, time.Minute + 30*time.Second},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			subject := Poll(&mutableSource{}, testCase.Interval, WithJitter(testCase.Jitter))
+
+			if next := subject.nextInterval(); next < testCase.Min || next > testCase.Max {
+				t.Logf("Expected interval between %s and %s, got %s", testCase.Min, testCase.Max, next)
+				t.Fail()
+			}
+		})
+	}
+}
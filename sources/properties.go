@@ -0,0 +1,162 @@
+package sources
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/jlevesy/envconfig/internal/tags"
+)
+
+// Properties returns a Source serving values defined in given Java style
+// .properties document. Keys are named like the loader names variables, so
+// database.maxConns=10 is served as PREFIX_DATABASE_MAX_CONNS.
+func Properties(r io.Reader, prefix, separator string) (Source, error) {
+	res := mapSource{}
+	scanner := bufio.NewScanner(r)
+
+	var logicalLine string
+
+	for scanner.Scan() {
+		line := strings.TrimLeft(scanner.Text(), " \t\f")
+
+		// Continuation of previous line
+		if logicalLine != "" {
+			line = logicalLine + line
+			logicalLine = ""
+		} else if line == "" || line[0] == '#' || line[0] == '!' {
+			continue
+		}
+
+		if hasContinuation(line) {
+			logicalLine = line[:len(line)-1]
+			continue
+		}
+
+		key, value := splitProperty(line)
+		res[propertyKey(prefix, separator, key)] = value
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if logicalLine != "" {
+		key, value := splitProperty(logicalLine)
+		res[propertyKey(prefix, separator, key)] = value
+	}
+
+	return res, nil
+}
+
+// propertyKey names the variable of given key like the loader names fields:
+// dots, dashes and spaces separate words, and so do case changes, so both
+// server.maxConnections and server.max-connections are served as
+// PREFIX_SERVER_MAX_CONNECTIONS.
+func propertyKey(prefix, separator, key string) string {
+	keyPath := strings.FieldsFunc(key, func(r rune) bool {
+		return r == '.' || r == '-' || unicode.IsSpace(r)
+	})
+
+	if prefix != "" {
+		keyPath = append([]string{prefix}, keyPath...)
+	}
+
+	return tags.VariableName(separator, keyPath...)
+}
+
+// PropertiesFile returns a Source serving values defined in .properties file at
+// given path
+func PropertiesFile(path, prefix, separator string) (Source, error) {
+	f, err := os.Open(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	return Properties(f, prefix, separator)
+}
+
+// hasContinuation reports if line ends with an odd count of backslashes
+func hasContinuation(line string) bool {
+	count := 0
+
+	for i := len(line) - 1; i >= 0 && line[i] == '\\'; i-- {
+		count++
+	}
+
+	return count%2 == 1
+}
+
+// splitProperty splits a line into an unescaped key and value. Key ends at the
+// first unescaped '=', ':' or whitespace.
+func splitProperty(line string) (string, string) {
+	end := len(line)
+
+	for i := 0; i < len(line); i++ {
+		if line[i] == '\\' {
+			i++
+			continue
+		}
+
+		if line[i] == '=' || line[i] == ':' || line[i] == ' ' || line[i] == '\t' || line[i] == '\f' {
+			end = i
+			break
+		}
+	}
+
+	key := line[:end]
+	rest := strings.TrimLeft(line[end:], " \t\f")
+
+	if rest != "" && (rest[0] == '=' || rest[0] == ':') {
+		rest = strings.TrimLeft(rest[1:], " \t\f")
+	}
+
+	return unescapeProperty(key), unescapeProperty(rest)
+}
+
+func unescapeProperty(s string) string {
+	if !strings.Contains(s, "\\") {
+		return s
+	}
+
+	var b strings.Builder
+
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i == len(s)-1 {
+			b.WriteByte(s[i])
+			continue
+		}
+
+		i++
+
+		switch s[i] {
+		case 't':
+			b.WriteByte('\t')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 'f':
+			b.WriteByte('\f')
+		case 'u':
+			if i+4 < len(s) {
+				if r, err := strconv.ParseUint(s[i+1:i+5], 16, 32); err == nil {
+					b.WriteRune(rune(r))
+					i += 4
+					continue
+				}
+			}
+			b.WriteByte('u')
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+
+	return b.String()
+}
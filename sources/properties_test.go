@@ -0,0 +1,50 @@
+package sources
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestProperties(t *testing.T) {
+	document := `
+# A comment
+! Another comment
+lateralizer.mode=extended
+real: true
+streamlining.ratio 543
+spliners.0.red = 3.29395
+message = Hello \
+          World
+path=C:\\groot\\config
+key\ with\ spaces=value
+unicode=\u0047root
+server.maxConnections=10
+pool.max-active=5
+`
+
+	expectation := mapSource{
+		"GROOT_LATERALIZER_MODE":       "extended",
+		"GROOT_REAL":                   "true",
+		"GROOT_STREAMLINING_RATIO":     "543",
+		"GROOT_SPLINERS_0_RED":         "3.29395",
+		"GROOT_MESSAGE":                "Hello World",
+		"GROOT_PATH":                   `C:\groot\config`,
+		"GROOT_KEY_WITH_SPACES":        "value",
+		"GROOT_UNICODE":                "Groot",
+		"GROOT_SERVER_MAX_CONNECTIONS": "10",
+		"GROOT_POOL_MAX_ACTIVE":        "5",
+	}
+
+	result, err := Properties(strings.NewReader(document), "GROOT", "_")
+
+	if err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	if !reflect.DeepEqual(result, expectation) {
+		t.Logf("Expected %v got %v", expectation, result)
+		t.Fail()
+	}
+}
@@ -0,0 +1,141 @@
+package sources
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// DefaultRetryAttempts is the default count of attempts performed by a Retrier
+	DefaultRetryAttempts = 3
+
+	// DefaultRetryBackoff is the default delay before the first retry
+	DefaultRetryBackoff = 100 * time.Millisecond
+
+	// DefaultRetryMaxBackoff is the default maximum delay between two retries
+	DefaultRetryMaxBackoff = 5 * time.Second
+)
+
+// RetryOption customizes a Retrier
+type RetryOption func(*Retrier)
+
+// WithAttempts sets the maximum count of attempts performed for a single call,
+// including the first one.
+func WithAttempts(attempts int) RetryOption {
+	return func(r *Retrier) {
+		r.attempts = attempts
+	}
+}
+
+// WithBackoff sets the delay before the first retry, doubled on each retry
+// up to max.
+func WithBackoff(initial, max time.Duration) RetryOption {
+	return func(r *Retrier) {
+		r.backoff = initial
+		r.maxBackoff = max
+	}
+}
+
+// WithRetryIf sets the function classifying errors as retryable, by default
+// every error is retried.
+func WithRetryIf(isRetryable func(error) bool) RetryOption {
+	return func(r *Retrier) {
+		r.isRetryable = isRetryable
+	}
+}
+
+// Retrier is a Source retrying failed calls to a wrapped source with an
+// exponential backoff.
+type Retrier struct {
+	source      Source
+	attempts    int
+	backoff     time.Duration
+	maxBackoff  time.Duration
+	isRetryable func(error) bool
+}
+
+// Retry wraps given source into a Retrier
+func Retry(source Source, opts ...RetryOption) *Retrier {
+	r := &Retrier{
+		source:      source,
+		attempts:    DefaultRetryAttempts,
+		backoff:     DefaultRetryBackoff,
+		maxBackoff:  DefaultRetryMaxBackoff,
+		isRetryable: func(error) bool { return true },
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// Lookup calls Lookup on the wrapped source, retrying on failure
+func (r *Retrier) Lookup(key string) (string, bool, error) {
+	return r.LookupContext(context.Background(), key)
+}
+
+// LookupContext calls Lookup on the wrapped source, retrying on failure until
+// ctx is done
+func (r *Retrier) LookupContext(ctx context.Context, key string) (value string, ok bool, err error) {
+	source := WithContext(ctx, r.source)
+
+	err = r.do(ctx, func() error {
+		value, ok, err = source.Lookup(key)
+		return err
+	})
+
+	return value, ok, err
+}
+
+// Keys calls Keys on the wrapped source, retrying on failure
+func (r *Retrier) Keys(prefix string) ([]string, error) {
+	return r.KeysContext(context.Background(), prefix)
+}
+
+// KeysContext calls Keys on the wrapped source, retrying on failure until ctx
+// is done
+func (r *Retrier) KeysContext(ctx context.Context, prefix string) (keys []string, err error) {
+	source := WithContext(ctx, r.source)
+
+	err = r.do(ctx, func() error {
+		keys, err = source.Keys(prefix)
+		return err
+	})
+
+	return keys, err
+}
+
+// Unwrap returns the wrapped source
+func (r *Retrier) Unwrap() Source {
+	return r.source
+}
+
+// Health checks the health of the wrapped source
+func (r *Retrier) Health(ctx context.Context) error {
+	return CheckHealth(ctx, r.source)
+}
+
+func (r *Retrier) do(ctx context.Context, call func() error) error {
+	backoff := r.backoff
+
+	for attempt := 1; ; attempt++ {
+		err := call()
+
+		if err == nil || attempt >= r.attempts || !r.isRetryable(err) || ctx.Err() != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > r.maxBackoff {
+			backoff = r.maxBackoff
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package sources
+
+import (
+	"errors"
+	"testing"
+)
+
+var (
+	errTransient = errors.New("transient")
+	errFatal     = errors.New("fatal")
+)
+
+// failingSource fails with given errors, then serves FOO for any key
+type failingSource struct {
+	errors []error
+	calls  int
+}
+
+func (f *failingSource) fail() error {
+	f.calls++
+
+	if len(f.errors) == 0 {
+		return nil
+	}
+
+	err := f.errors[0]
+	f.errors = f.errors[1:]
+
+	return err
+}
+
+func (f *failingSource) Lookup(key string) (string, bool, error) {
+	if err := f.fail(); err != nil {
+		return "", false, err
+	}
+
+	return "FOO", true, nil
+}
+
+func (f *failingSource) Keys(prefix string) ([]string, error) {
+	if err := f.fail(); err != nil {
+		return nil, err
+	}
+
+	return []string{prefix}, nil
+}
+
+func TestRetrier(t *testing.T) {
+	testCases := []struct {
+		Label         string
+		Errors        []error
+		Expectation   error
+		ExpectedCalls int
+	}{
+		{"WithoutError", nil, nil, 1},
+		{"WithTransientErrors", []error{errTransient, errTransient}, nil, 3},
+		{"WithTooManyErrors", []error{errTransient, errTransient, errTransient, errTransient}, errTransient, 3},
+		{"WithFatalError", []error{errFatal}, errFatal, 1},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			source := &failingSource{errors: testCase.Errors}
+			subject := Retry(
+				source,
+				WithAttempts(3),
+				WithBackoff(0, 0),
+				WithRetryIf(func(err error) bool { return err == errTransient }),
+			)
+
+			value, _, err := subject.Lookup("APP_FOO")
+
+			if err != testCase.Expectation {
+				t.Logf("Expected error [%v] got [%v]", testCase.Expectation, err)
+				t.Fail()
+			}
+
+			if err == nil && value != "FOO" {
+				t.Logf("Expected value [FOO] got [%s]", value)
+				t.Fail()
+			}
+
+			if source.calls != testCase.ExpectedCalls {
+				t.Logf("Expected %d calls got %d", testCase.ExpectedCalls, source.calls)
+				t.Fail()
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package sources
+
+// Snapshotter is implemented by sources which are costly to read key by key,
+// but can capture their whole content at once, like the Viper source. The
+// loader takes a snapshot before each load, then looks keys up from it.
+type Snapshotter interface {
+	Source
+
+	// Snapshot returns a source serving the current content of the source
+	Snapshot() Source
+}
+
+// Snapshot returns a snapshot of given source if it implements Snapshotter,
+// or the source itself.
+func Snapshot(source Source) Source {
+	if s, ok := source.(Snapshotter); ok {
+		return s.Snapshot()
+	}
+
+	return source
+}
@@ -0,0 +1,12 @@
+package sources
+
+// Source represents any kind of key/value store configuration values can be
+// looked up from, for instance the process environment.
+type Source interface {
+	// Lookup retrieves the value stored at given key, the returned boolean
+	// reports if the key is defined.
+	Lookup(key string) (string, bool, error)
+
+	// Keys returns every defined key starting with given prefix.
+	Keys(prefix string) ([]string, error)
+}
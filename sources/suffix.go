@@ -0,0 +1,57 @@
+package sources
+
+import (
+	"context"
+	"strings"
+)
+
+// Suffixed returns a Source serving keys of given source ending with suffix,
+// as if suffix was trimmed: looking APP_PORT up returns the value of
+// APP_PORT_PROD when suffix is "_PROD". It suits profile specific overrides
+// living next to base values, for instance in the process environment.
+func Suffixed(source Source, suffix string) Source {
+	return &suffixedSource{source, suffix}
+}
+
+type suffixedSource struct {
+	source Source
+	suffix string
+}
+
+func (s *suffixedSource) Lookup(key string) (string, bool, error) {
+	return s.LookupContext(context.Background(), key)
+}
+
+func (s *suffixedSource) LookupContext(ctx context.Context, key string) (string, bool, error) {
+	return WithContext(ctx, s.source).Lookup(key + s.suffix)
+}
+
+func (s *suffixedSource) Keys(prefix string) ([]string, error) {
+	return s.KeysContext(context.Background(), prefix)
+}
+
+func (s *suffixedSource) KeysContext(ctx context.Context, prefix string) ([]string, error) {
+	keys, err := WithContext(ctx, s.source).Keys(prefix)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]string, 0, len(keys))
+
+	for _, key := range keys {
+		if trimmed := strings.TrimSuffix(key, s.suffix); trimmed != key && len(trimmed) >= len(prefix) {
+			res = append(res, trimmed)
+		}
+	}
+
+	return res, nil
+}
+
+func (s *suffixedSource) Unwrap() Source {
+	return s.source
+}
+
+func (s *suffixedSource) Health(ctx context.Context) error {
+	return CheckHealth(ctx, s.source)
+}
@@ -0,0 +1,59 @@
+package sources
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestSuffixed(t *testing.T) {
+	source := Suffixed(
+		mapSource{
+			"APP_PORT":            "8080",
+			"APP_PORT_PROD":       "80",
+			"APP_ROUTES_FOO_PROD": "foo",
+			"APP_PROD":            "bar",
+		},
+		"_PROD",
+	)
+
+	testCases := []struct {
+		Label       string
+		Key         string
+		Expectation string
+		Found       bool
+	}{
+		{"Suffixed", "APP_PORT", "80", true},
+		{"NotSuffixed", "APP_HOST", "", false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			value, found, err := source.Lookup(testCase.Key)
+
+			if err != nil {
+				t.Logf("Wasn't expecting an error, got [%v]", err)
+				t.FailNow()
+			}
+
+			if value != testCase.Expectation || found != testCase.Found {
+				t.Logf("Expected %q %t, got %q %t", testCase.Expectation, testCase.Found, value, found)
+				t.Fail()
+			}
+		})
+	}
+
+	keys, err := source.Keys("APP_")
+
+	if err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	sort.Strings(keys)
+
+	if expectation := []string{"APP_PORT", "APP_ROUTES_FOO"}; !reflect.DeepEqual(keys, expectation) {
+		t.Logf("Expected keys %v, got %v", expectation, keys)
+		t.Fail()
+	}
+}
@@ -0,0 +1,39 @@
+package sources
+
+// Wrapper is implemented by sources decorating another source, like Retrier,
+// CircuitBreaker, CachingSource or Poller. It lets capabilities of the
+// decorated source, like Notifier, be found through decorators.
+type Wrapper interface {
+	Source
+
+	// Unwrap returns the decorated source
+	Unwrap() Source
+}
+
+// Unwrap returns the source decorated by given source if it implements
+// Wrapper, nil otherwise.
+func Unwrap(source Source) Source {
+	w, ok := source.(Wrapper)
+
+	if !ok {
+		return nil
+	}
+
+	return w.Unwrap()
+}
+
+// AsNotifier returns the first source of the chain of decorators starting at
+// given source which implements Notifier. Changes reported by a decorated
+// source go through its decorators on reload, so a CachingSource serves cached
+// values until they expire.
+func AsNotifier(source Source) (Notifier, bool) {
+	for source != nil {
+		if notifier, ok := source.(Notifier); ok {
+			return notifier, true
+		}
+
+		source = Unwrap(source)
+	}
+
+	return nil, false
+}
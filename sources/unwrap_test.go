@@ -0,0 +1,32 @@
+package sources
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAsNotifier(t *testing.T) {
+	poller := Poll(Map(map[string]string{}), time.Minute)
+
+	testCases := []struct {
+		Label       string
+		Source      Source
+		Expectation Notifier
+	}{
+		{"WithNotifier", poller, poller},
+		{"WithDecorators", Cache(Breaker(Retry(poller))), poller},
+		{"WithSuffixedSource", Suffixed(poller, "_PROD"), poller},
+		{"WithoutNotifier", Retry(Map(map[string]string{})), nil},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			notifier, ok := AsNotifier(testCase.Source)
+
+			if ok != (testCase.Expectation != nil) || notifier != testCase.Expectation {
+				t.Logf("Expected notifier %v, got %v", testCase.Expectation, notifier)
+				t.Fail()
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package sources
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ViperReader is the subset of *viper.Viper used by the Viper source.
+type ViperReader interface {
+	AllKeys() []string
+	Get(key string) interface{}
+}
+
+// Viper returns a Source serving values held by given Viper instance, easing
+// migration of Viper based services. Dots of keys become the separator, so
+// the database.host key is served as PREFIX_DATABASE_HOST, and list items
+// are indexed: PREFIX_REPOS_0, PREFIX_REPOS_1.
+// Loaders read a snapshot of the Viper instance once per load, so changes made
+// by viper.WatchConfig are visible on next load. Lookups made outside of a
+// load read the Viper instance each time.
+func Viper(v ViperReader, prefix, separator string) Source {
+	return &viperSource{v, prefix, separator}
+}
+
+type viperSource struct {
+	viper     ViperReader
+	prefix    string
+	separator string
+}
+
+func (s *viperSource) Lookup(key string) (string, bool, error) {
+	return s.snapshot().Lookup(key)
+}
+
+func (s *viperSource) Keys(prefix string) ([]string, error) {
+	return s.snapshot().Keys(prefix)
+}
+
+// Snapshot returns the values currently held by the Viper instance
+func (s *viperSource) Snapshot() Source {
+	return s.snapshot()
+}
+
+func (s *viperSource) snapshot() mapSource {
+	res := mapSource{}
+
+	for _, key := range s.viper.AllKeys() {
+		keyPath := strings.Split(key, ".")
+
+		if s.prefix != "" {
+			keyPath = append([]string{s.prefix}, keyPath...)
+		}
+
+		flattenNode(res, keyPath, s.separator, viperNode(s.viper.Get(key)))
+	}
+
+	return res
+}
+
+// viperNode converts a value held by Viper into a tree flattenNode understands
+func viperNode(value interface{}) interface{} {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		return v
+	case []string:
+		res := make([]interface{}, len(v))
+
+		for i, item := range v {
+			res[i] = item
+		}
+
+		return res
+	case []interface{}:
+		res := make([]interface{}, len(v))
+
+		for i, item := range v {
+			res[i] = viperNode(item)
+		}
+
+		return res
+	case map[string]interface{}:
+		res := make(map[string]interface{}, len(v))
+
+		for key, item := range v {
+			res[key] = viperNode(item)
+		}
+
+		return res
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,127 @@
+package sources
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeViper mimics *viper.Viper, which flattens nested keys using dots
+type fakeViper map[string]interface{}
+
+func (v fakeViper) AllKeys() []string {
+	res := []string{}
+
+	for key := range v {
+		res = append(res, key)
+	}
+
+	return res
+}
+
+func (v fakeViper) Get(key string) interface{} {
+	return v[strings.ToLower(key)]
+}
+
+func TestViper(t *testing.T) {
+	v := fakeViper{
+		"lateralizer.mode": "extended",
+		"real":             true,
+		"ratio":            543,
+		"timeout":          5 * time.Second,
+		"repos":            []string{"foo", "bar"},
+		"spliners":         []interface{}{map[string]interface{}{"red": 3.2}},
+	}
+
+	expectation := mapSource{
+		"GROOT_LATERALIZER_MODE": "extended",
+		"GROOT_REAL":             "true",
+		"GROOT_RATIO":            "543",
+		"GROOT_TIMEOUT":          "5s",
+		"GROOT_REPOS_0":          "foo",
+		"GROOT_REPOS_1":          "bar",
+		"GROOT_SPLINERS_0_RED":   "3.2",
+	}
+
+	subject := Viper(v, "GROOT", "_")
+
+	for key, expected := range expectation {
+		value, ok, err := subject.Lookup(key)
+
+		if err != nil || !ok || value != expected {
+			t.Logf("Expected %s to be %s, got [%s, %v, %v]", key, expected, value, ok, err)
+			t.Fail()
+		}
+	}
+
+	keys, err := subject.Keys("GROOT_REPOS")
+
+	if err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	sort.Strings(keys)
+
+	if !reflect.DeepEqual(keys, []string{"GROOT_REPOS_0", "GROOT_REPOS_1"}) {
+		t.Logf("Unexpected keys %v", keys)
+		t.Fail()
+	}
+
+	// Changes are visible on next lookup
+	v["lateralizer.mode"] = "basic"
+
+	if value, _, _ := subject.Lookup("GROOT_LATERALIZER_MODE"); value != "basic" {
+		t.Logf("Expected updated value, got %s", value)
+		t.Fail()
+	}
+}
+
+// countingViper counts how many times the whole Viper instance is read
+type countingViper struct {
+	fakeViper
+	reads int
+}
+
+func (v *countingViper) AllKeys() []string {
+	v.reads++
+	return v.fakeViper.AllKeys()
+}
+
+func TestViperSnapshot(t *testing.T) {
+	v := &countingViper{fakeViper: fakeViper{"database.host": "localhost", "database.port": 5432}}
+
+	snapshot := Snapshot(Viper(v, "GROOT", "_"))
+
+	for _, key := range []string{"GROOT_DATABASE_HOST", "GROOT_DATABASE_PORT", "GROOT_DATABASE_NAME"} {
+		if _, _, err := snapshot.Lookup(key); err != nil {
+			t.Logf("Wasn't expecting an error, got [%v]", err)
+			t.Fail()
+		}
+	}
+
+	if _, err := snapshot.Keys("GROOT_DATABASE"); err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.Fail()
+	}
+
+	if v.reads != 1 {
+		t.Logf("Expected Viper to be read once, got %d reads", v.reads)
+		t.Fail()
+	}
+
+	// Changes are visible on next snapshot only
+	v.fakeViper["database.host"] = "db.local"
+
+	if value, _, _ := snapshot.Lookup("GROOT_DATABASE_HOST"); value != "localhost" {
+		t.Logf("Expected snapshot value, got %s", value)
+		t.Fail()
+	}
+
+	if value, _, _ := Snapshot(Viper(v, "GROOT", "_")).Lookup("GROOT_DATABASE_HOST"); value != "db.local" {
+		t.Logf("Expected updated value, got %s", value)
+		t.Fail()
+	}
+}
@@ -0,0 +1,137 @@
+package sources
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// volumeDataDir is the symlink kubelet points to the current revision of a
+// ConfigMap or Secret volume, swapping it atomically on updates.
+const volumeDataDir = "..data"
+
+// VolumeSource is a Source serving the keys of a ConfigMap or Secret mounted
+// as a volume, one file per key. Files are read from the revision currently
+// pointed by the ..data symlink, so a lookup never mixes two revisions.
+// Directories which aren't laid out by kubelet are read like Dir.
+type VolumeSource struct {
+	dir     string
+	changes chan struct{}
+
+	mu       sync.Mutex
+	revision string
+}
+
+// Volume returns a VolumeSource serving the volume mounted at given dir
+func Volume(dir string) *VolumeSource {
+	v := &VolumeSource{
+		dir:     dir,
+		changes: make(chan struct{}, 1),
+	}
+
+	v.revision, _ = v.currentRevision()
+
+	return v
+}
+
+// Lookup reads the file named after given key in the current revision
+func (v *VolumeSource) Lookup(key string) (string, bool, error) {
+	dir, err := v.dataDir()
+
+	if err != nil {
+		return "", false, err
+	}
+
+	return Dir(dir).Lookup(key)
+}
+
+// Keys lists files starting with given prefix in the current revision
+func (v *VolumeSource) Keys(prefix string) ([]string, error) {
+	dir, err := v.dataDir()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return Dir(dir).Keys(prefix)
+}
+
+// Run checks the volume for updates on every interval until ctx is done.
+// Kubelet usually updates mounted volumes within a minute.
+func (v *VolumeSource) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			v.Refresh()
+		}
+	}
+}
+
+// Refresh reports a change if kubelet swapped the revision of the volume
+// since the previous refresh.
+func (v *VolumeSource) Refresh() error {
+	revision, err := v.currentRevision()
+
+	if err != nil {
+		return err
+	}
+
+	v.mu.Lock()
+	changed := revision != v.revision
+	v.revision = revision
+	v.mu.Unlock()
+
+	if changed {
+		// Do not block if a change is already pending
+		select {
+		case v.changes <- struct{}{}:
+		default:
+		}
+	}
+
+	return nil
+}
+
+// Changes reports revision changes detected by Refresh
+func (v *VolumeSource) Changes() <-chan struct{} {
+	return v.changes
+}
+
+// currentRevision returns the target of the ..data symlink, empty if the
+// directory isn't laid out by kubelet.
+func (v *VolumeSource) currentRevision() (string, error) {
+	revision, err := os.Readlink(filepath.Join(v.dir, volumeDataDir))
+
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+
+	return revision, err
+}
+
+// dataDir returns the directory holding the files of the current revision
+func (v *VolumeSource) dataDir() (string, error) {
+	revision, err := v.currentRevision()
+
+	if err != nil {
+		return "", err
+	}
+
+	if revision == "" {
+		return v.dir, nil
+	}
+
+	if filepath.IsAbs(revision) {
+		return revision, nil
+	}
+
+	return filepath.Join(v.dir, revision), nil
+}
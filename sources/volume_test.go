@@ -0,0 +1,122 @@
+package sources
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// writeRevision lays given values out the way kubelet does: files are written
+// into a new revision directory, then the ..data symlink is atomically swapped
+// to point to it.
+func writeRevision(t *testing.T, dir, revision string, values map[string]string) {
+	t.Helper()
+
+	if err := os.Mkdir(filepath.Join(dir, revision), 0700); err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	for key, value := range values {
+		if err := os.WriteFile(filepath.Join(dir, revision, key), []byte(value), 0600); err != nil {
+			t.Logf("Wasn't expecting an error, got [%v]", err)
+			t.FailNow()
+		}
+
+		// Keys are symlinks through ..data, created once
+		if err := os.Symlink(filepath.Join(volumeDataDir, key), filepath.Join(dir, key)); err != nil && !os.IsExist(err) {
+			t.Logf("Wasn't expecting an error, got [%v]", err)
+			t.FailNow()
+		}
+	}
+
+	if err := os.Symlink(revision, filepath.Join(dir, "..data_tmp")); err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	if err := os.Rename(filepath.Join(dir, "..data_tmp"), filepath.Join(dir, volumeDataDir)); err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+}
+
+func TestVolume(t *testing.T) {
+	dir := t.TempDir()
+
+	writeRevision(t, dir, "..2024_01_01", map[string]string{
+		"APP_DB_HOST": "localhost",
+		"APP_DB_PORT": "5432",
+	})
+
+	source := Volume(dir)
+
+	if value, found, err := source.Lookup("APP_DB_HOST"); value != "localhost" || !found || err != nil {
+		t.Logf("Expected [localhost, true, nil] got [%s, %t, %v]", value, found, err)
+		t.Fail()
+	}
+
+	keys, err := source.Keys("APP_")
+
+	if err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	sort.Strings(keys)
+
+	if expectation := []string{"APP_DB_HOST", "APP_DB_PORT"}; !reflect.DeepEqual(keys, expectation) {
+		t.Logf("Expected %v got %v", expectation, keys)
+		t.Fail()
+	}
+
+	if err := source.Refresh(); err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	select {
+	case <-source.Changes():
+		t.Log("Wasn't expecting a change")
+		t.Fail()
+	default:
+	}
+
+	writeRevision(t, dir, "..2024_01_02", map[string]string{
+		"APP_DB_HOST": "db.internal",
+		"APP_DB_PORT": "5432",
+	})
+
+	if err := source.Refresh(); err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	select {
+	case <-source.Changes():
+	default:
+		t.Log("Expected a change")
+		t.Fail()
+	}
+
+	if value, found, err := source.Lookup("APP_DB_HOST"); value != "db.internal" || !found || err != nil {
+		t.Logf("Expected [db.internal, true, nil] got [%s, %t, %v]", value, found, err)
+		t.Fail()
+	}
+}
+
+func TestVolumeWithoutKubeletLayout(t *testing.T) {
+	dir := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(dir, "APP_PORT"), []byte("8080\n"), 0600); err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	if value, found, err := Volume(dir).Lookup("APP_PORT"); value != "8080" || !found || err != nil {
+		t.Logf("Expected [8080, true, nil] got [%s, %t, %v]", value, found, err)
+		t.Fail()
+	}
+}
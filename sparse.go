@@ -0,0 +1,152 @@
+package envconfig
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SparsePolicy defines how gaps between indexes of slice entries, like
+// APP_ITEMS_0 and APP_ITEMS_3 without APP_ITEMS_1 and APP_ITEMS_2, are handled.
+type SparsePolicy int
+
+const (
+	// FillGaps grows slices up to the highest index, gaps holding zero values
+	FillGaps SparsePolicy = iota
+	// RejectGaps fails the load with CodeInvalidKey, naming the variables of
+	// missing entries
+	RejectGaps
+)
+
+// WithSparsePolicy sets how gaps between indexes of slice entries are
+// handled, default is FillGaps. Gaps are checked once every source is
+// applied, entries set by WithDefaults aren't gaps.
+func WithSparsePolicy(policy SparsePolicy) Option {
+	return func(e *envConfig) {
+		e.sparsePolicy = policy
+	}
+}
+
+// checkGaps fails if a slice of configVal has zero entries below the highest
+// index assigned, which weren't assigned themselves.
+func (e *envConfig) checkGaps(configVal reflect.Value, configType reflect.Type, assigned map[string]ReportEntry) error {
+	slices := map[string]map[int]struct{}{}
+
+	for key := range assigned {
+		valuePath := path(strings.Split(key, "\x00"))
+
+		for slicePath, index := range sliceIndexes(configType, valuePath) {
+			if slices[slicePath] == nil {
+				slices[slicePath] = map[int]struct{}{}
+			}
+
+			slices[slicePath][index] = struct{}{}
+		}
+	}
+
+	keys := make([]string, 0, len(slices))
+
+	for key := range slices {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		slicePath := path(strings.Split(key, "\x00"))
+
+		if key == "" {
+			slicePath = path{}
+		}
+
+		if missing := e.missingEntries(configVal, configType, slicePath, slices[key]); len(missing) > 0 {
+			return withCode(
+				CodeInvalidKey,
+				fmt.Errorf(
+					"Variables [%s] are missing, entries of [%s] must have contiguous indexes",
+					strings.Join(missing, ", "),
+					e.variableName(configType, slicePath),
+				),
+			)
+		}
+	}
+
+	return nil
+}
+
+// missingEntries returns variables of the zero entries of the slice at given
+// path which are below the highest assigned index, without being assigned.
+func (e *envConfig) missingEntries(configVal reflect.Value, configType reflect.Type, slicePath path, indexes map[int]struct{}) []string {
+	val, ok := e.valueAtPath(configVal, slicePath)
+
+	for ok && val.Kind() == reflect.Ptr {
+		ok = !val.IsNil()
+
+		if ok {
+			val = val.Elem()
+		}
+	}
+
+	if !ok || val.Kind() != reflect.Slice {
+		return nil
+	}
+
+	highest := -1
+
+	for index := range indexes {
+		if index > highest {
+			highest = index
+		}
+	}
+
+	var res []string
+
+	for i := 0; i <= highest && i < val.Len(); i++ {
+		if _, ok := indexes[i]; ok || !val.Index(i).IsZero() {
+			continue
+		}
+
+		res = append(res, e.variableName(configType, append(slicePath.clone(), strconv.Itoa(i))))
+	}
+
+	return res
+}
+
+// sliceIndexes returns the indexes of slice entries given path goes through,
+// keyed by the path of their slice.
+func sliceIndexes(configType reflect.Type, valuePath path) map[string]int {
+	res := map[string]int{}
+	valType := configType
+
+	for i, key := range valuePath {
+		valType = indirectedType(valType)
+
+		switch valType.Kind() {
+		case reflect.Struct:
+			field, ok := valType.FieldByName(key)
+
+			if !ok {
+				return res
+			}
+
+			valType = field.Type
+		case reflect.Slice:
+			index, err := strconv.Atoi(key)
+
+			if err != nil {
+				return res
+			}
+
+			res[valuePath[:i].key()] = index
+			valType = valType.Elem()
+		case reflect.Array, reflect.Map:
+			valType = valType.Elem()
+		default:
+			return res
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,80 @@
+package envconfig
+
+import (
+	"strings"
+	"testing"
+)
+
+type sparseConfig struct {
+	Items  []string
+	Groups []struct {
+		Hosts []string
+	}
+}
+
+func TestLoadWithSparsePolicy(t *testing.T) {
+	testCases := []struct {
+		Label    string
+		Env      mapSource
+		Options  []Option
+		Error    ErrorCode
+		Missing  string
+		Defaults *sparseConfig
+	}{
+		{"WithGapsFilled", mapSource{"APP_ITEMS_0": "a", "APP_ITEMS_3": "d"}, nil, "", "", nil},
+		{
+			"WithGapsRejected",
+			mapSource{"APP_ITEMS_0": "a", "APP_ITEMS_3": "d"},
+			[]Option{WithSparsePolicy(RejectGaps)},
+			CodeInvalidKey,
+			"APP_ITEMS_1, APP_ITEMS_2",
+			nil,
+		},
+		{
+			"WithNestedGapsRejected",
+			mapSource{"APP_GROUPS_0_HOSTS_1": "b"},
+			[]Option{WithSparsePolicy(RejectGaps)},
+			CodeInvalidKey,
+			"APP_GROUPS_0_HOSTS_0",
+			nil,
+		},
+		{
+			"WithContiguousIndexes",
+			mapSource{"APP_ITEMS_1": "b", "APP_ITEMS_0": "a"},
+			[]Option{WithSparsePolicy(RejectGaps)},
+			"",
+			"",
+			nil,
+		},
+		{
+			"WithGapsFilledByDefaults",
+			mapSource{"APP_ITEMS_1": "b"},
+			[]Option{WithSparsePolicy(RejectGaps)},
+			"",
+			"",
+			&sparseConfig{Items: []string{"a"}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			opts := append(testCase.Options, WithSource(testCase.Env))
+
+			if testCase.Defaults != nil {
+				opts = append(opts, WithDefaults(testCase.Defaults))
+			}
+
+			err := New("APP", "_", opts...).Load(&sparseConfig{})
+
+			if code := CodeOf(err); code != testCase.Error {
+				t.Logf("Expected code %q, got [%v]", testCase.Error, err)
+				t.FailNow()
+			}
+
+			if err != nil && !strings.Contains(err.Error(), testCase.Missing) {
+				t.Logf("Expected the error to name %s, got [%v]", testCase.Missing, err)
+				t.Fail()
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package envconfig
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/jlevesy/envconfig/setter"
+)
+
+// setSplit splits the value using given separator, then assigns each trimmed
+// item to an element of given slice or array using the element type's setter.
+func (e *envConfig) setSplit(val reflect.Value, sep string, sc setter.SetContext) error {
+	var items []string
+
+	if strings.TrimSpace(sc.RawValue) != "" {
+		items = strings.Split(sc.RawValue, sep)
+	}
+
+	switch val.Kind() {
+	case reflect.Slice:
+		val.Set(reflect.MakeSlice(val.Type(), len(items), len(items)))
+	case reflect.Array:
+		if len(items) > val.Len() {
+			return fmt.Errorf("Got %d items, array holds at most %d", len(items), val.Len())
+		}
+
+		val.Set(reflect.Zero(val.Type()))
+	default:
+		return withCode(CodeInvalidConfig, fmt.Errorf("The split option only applies to slices and arrays, got a %v", val.Type()))
+	}
+
+	for i, item := range items {
+		itemContext := sc
+		itemContext.RawValue = strings.TrimSpace(item)
+
+		if err := e.assignValue(val.Index(i), val.Type().Elem(), path{}, itemContext); err != nil {
+			return fmt.Errorf("Invalid item %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
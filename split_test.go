@@ -0,0 +1,96 @@
+package envconfig
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type splitConfig struct {
+	Hosts    []string        `envconfig:"split=,"`
+	Ports    []int           `envconfig:"split=;"`
+	Backoffs []time.Duration `envconfig:"split=,,default=1s,5s"`
+	Weights  [3]float64      `envconfig:"split=|"`
+	Servers  *[]string       `envconfig:"split= "`
+}
+
+type separatorConfig struct {
+	Hosts []string `envconfig:"separator=;"`
+}
+
+type invalidSplitConfig struct {
+	Port int `envconfig:"split=,"`
+}
+
+func TestLoadWithSplit(t *testing.T) {
+	servers := []string{"a", "b"}
+
+	testCases := []struct {
+		Label       string
+		Env         mapSource
+		Config      interface{}
+		Expectation interface{}
+		Error       ErrorCode
+	}{
+		{
+			"WithValues",
+			mapSource{
+				"APP_HOSTS":   "foo, bar,baz",
+				"APP_PORTS":   "80;443",
+				"APP_WEIGHTS": "0.5|0.25",
+				"APP_SERVERS": "a b",
+			},
+			&splitConfig{},
+			&splitConfig{
+				Hosts:    []string{"foo", "bar", "baz"},
+				Ports:    []int{80, 443},
+				Backoffs: []time.Duration{time.Second, 5 * time.Second},
+				Weights:  [3]float64{0.5, 0.25},
+				Servers:  &servers,
+			},
+			"",
+		},
+		{
+			"WithEmptyValue",
+			mapSource{"APP_HOSTS": "", "APP_BACKOFFS": "2s"},
+			&splitConfig{},
+			&splitConfig{Hosts: []string{}, Backoffs: []time.Duration{2 * time.Second}},
+			"",
+		},
+		{
+			"WithSeparator",
+			mapSource{"APP_HOSTS": "foo;bar"},
+			&separatorConfig{},
+			&separatorConfig{Hosts: []string{"foo", "bar"}},
+			"",
+		},
+		{"WithInvalidItem", mapSource{"APP_PORTS": "80;http"}, &splitConfig{}, nil, CodeParseFailure},
+		{"WithTooManyItems", mapSource{"APP_WEIGHTS": "1|2|3|4"}, &splitConfig{}, nil, CodeParseFailure},
+		{"WithNonSliceField", mapSource{"APP_PORT": "80"}, &invalidSplitConfig{}, nil, CodeInvalidConfig},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			err := New("APP", "_", WithSource(testCase.Env)).Load(testCase.Config)
+
+			if testCase.Error != "" {
+				if code := CodeOf(err); code != testCase.Error {
+					t.Logf("Expected code %s, got [%v]", testCase.Error, err)
+					t.Fail()
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Logf("Wasn't expecting an error, got [%v]", err)
+				t.FailNow()
+			}
+
+			if !reflect.DeepEqual(testCase.Config, testCase.Expectation) {
+				t.Logf("Expected %+v got %+v", testCase.Expectation, testCase.Config)
+				t.Fail()
+			}
+		})
+	}
+}
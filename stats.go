@@ -0,0 +1,51 @@
+package envconfig
+
+import (
+	"time"
+
+	"github.com/jlevesy/envconfig/sources"
+)
+
+// LoadStats are statistics about a Load, summed over all sources
+type LoadStats struct {
+	// Scanned is the count of variables looked up
+	Scanned int
+	// Matched is the count of variables found
+	Matched int
+	// Assigned is the count of values set, a value overwritten by a later
+	// source is counted once
+	Assigned int
+	// Defaulted is the count of variables listed by Describe which weren't
+	// found in any source, their field keeping its default value
+	Defaulted int
+	// AnalysisDuration is the time spent looking variables up
+	AnalysisDuration time.Duration
+	// AssignmentDuration is the time spent assigning values
+	AssignmentDuration time.Duration
+}
+
+// loadState holds what is collected during a Load
+type loadState struct {
+	// assigned holds entries of assigned values, keyed by path
+	assigned map[string]ReportEntry
+	// matched holds names of variables found in sources
+	matched  map[string]struct{}
+	unused   []string
+	warnings []string
+	stats    LoadStats
+}
+
+func newLoadState() *loadState {
+	return &loadState{assigned: map[string]ReportEntry{}, matched: map[string]struct{}{}}
+}
+
+// countingSource counts lookups made on a source
+type countingSource struct {
+	sources.Source
+	count *int
+}
+
+func (c *countingSource) Lookup(key string) (string, bool, error) {
+	*c.count++
+	return c.Source.Lookup(key)
+}
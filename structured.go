@@ -0,0 +1,104 @@
+package envconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/jlevesy/envconfig/setter"
+)
+
+// WithStructVariables lets nested structs be defined as a whole by a single
+// variable named after the field, holding a JSON object or a YAML document.
+// Expanded variables of the struct's fields take precedence over it.
+func WithStructVariables() Option {
+	return func(e *envConfig) {
+		e.structVariables = true
+	}
+}
+
+// setStruct assigns a whole struct from a single variable.
+// If a setter is registered for the struct type, it is used, otherwise the
+// value is decoded as a JSON object if it starts with a curly brace, or as a
+// YAML document.
+func (e *envConfig) setStruct(structValue reflect.Value, sc setter.SetContext) error {
+	if _, ok := e.setterOf(structValue.Type()); ok {
+		return e.setValueWithContext(structValue, sc)
+	}
+
+	strValue := sc.RawValue
+
+	if !structValue.CanAddr() {
+		return fmt.Errorf("Value [%v] cannot be set", structValue)
+	}
+
+	if !strings.HasPrefix(strings.TrimSpace(strValue), "{") {
+		node, err := parseYAML(strValue)
+
+		if err != nil {
+			return err
+		}
+
+		return e.setFromYAML(structValue, node)
+	}
+
+	if err := json.Unmarshal([]byte(strValue), structValue.Addr().Interface()); err != nil {
+		return fmt.Errorf("Failed to decode struct [%v]: %v", structValue.Type(), err)
+	}
+
+	return nil
+}
+
+// setFromJSON assigns a value tagged with the json option. Entries of JSON
+// objects assigned to maps are assigned one by one, keys and values being
+// parsed using their type's setter: JSON strings are passed unquoted, other
+// values as is. Other types are decoded using encoding/json.
+func (e *envConfig) setFromJSON(val reflect.Value, sc setter.SetContext) error {
+	if val.Kind() != reflect.Map {
+		if err := json.Unmarshal([]byte(sc.RawValue), val.Addr().Interface()); err != nil {
+			return fmt.Errorf("Failed to decode JSON value [%v]: %v", val.Type(), err)
+		}
+
+		return nil
+	}
+
+	var entries map[string]json.RawMessage
+
+	if err := json.Unmarshal([]byte(sc.RawValue), &entries); err != nil {
+		return fmt.Errorf("Failed to decode JSON object [%v]: %v", val.Type(), err)
+	}
+
+	mapType := val.Type()
+	res := reflect.MakeMapWithSize(mapType, len(entries))
+
+	for key, raw := range entries {
+		keyVal := reflect.New(mapType.Key()).Elem()
+
+		if err := e.setValue(keyVal, key); err != nil {
+			return fmt.Errorf("Invalid key %q: %w", key, err)
+		}
+
+		strValue := string(raw)
+
+		if strings.HasPrefix(strValue, `"`) {
+			if err := json.Unmarshal(raw, &strValue); err != nil {
+				return fmt.Errorf("Invalid value of key %q: %v", key, err)
+			}
+		}
+
+		entryContext := sc
+		entryContext.RawValue = strValue
+		elemVal := reflect.New(mapType.Elem()).Elem()
+
+		if err := e.assignValue(elemVal, mapType.Elem(), path{}, entryContext); err != nil {
+			return fmt.Errorf("Invalid value of key %q: %w", key, err)
+		}
+
+		res.SetMapIndex(keyVal, elemVal)
+	}
+
+	val.Set(res)
+
+	return nil
+}
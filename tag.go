@@ -0,0 +1,144 @@
+package envconfig
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/jlevesy/envconfig/internal/tags"
+)
+
+// Options of the envconfig struct tag, shared with envconfig-gen
+const (
+	noExpand       = tags.NoExpand
+	nameOption     = tags.Name
+	defaultOption  = tags.Default
+	requiredOption = tags.Required
+	setterOption   = tags.Setter
+	splitOption    = tags.Split
+	jsonOption     = tags.JSON
+	layoutOption   = tags.Layout
+	oneOfOption    = tags.OneOf
+	encodingOption = tags.Encoding
+
+	// ignoreTag excludes a field from loads, like envconfig:"-"
+	ignoreTag = tags.Ignore
+
+	// encodingTag was the tag of the encoding option, it is rejected
+	encodingTag = "encoding"
+)
+
+// tagOptions holds the options of an envconfig struct tag, flags being mapped
+// to an empty value.
+type tagOptions = tags.Options
+
+// parseTagOptions parses an envconfig struct tag, see tags.Parse.
+func parseTagOptions(tag string) (tagOptions, error) {
+	return tags.Parse(tag)
+}
+
+// skipsField reports if given field is left out of loads, either because it
+// is tagged with envconfig:"-", or because it is unexported and
+// WithSkipUnexported is set.
+func (e *envConfig) skipsField(field reflect.StructField) bool {
+	if field.Tag.Get(envConfigTag) == ignoreTag {
+		return true
+	}
+
+	return e.skipUnexported && field.PkgPath != "" && !field.Anonymous
+}
+
+// fieldOptions returns the options of the envconfig tag of given field. Tags
+// which can't be parsed are reported by checkTags before fields are read.
+func fieldOptions(field reflect.StructField) tagOptions {
+	t, ok := field.Tag.Lookup(envConfigTag)
+
+	if !ok || t == ignoreTag {
+		return tagOptions{}
+	}
+
+	options, err := parseTagOptions(t)
+
+	if err != nil {
+		return tagOptions{}
+	}
+
+	return options
+}
+
+// checkTags returns an error for each field of configType, nested ones
+// included, whose envconfig tag can't be parsed.
+func (e *envConfig) checkTags(configType reflect.Type) error {
+	var errs []error
+
+	e.collectTagErrors(configType, configType, path{}, true, map[reflect.Type]struct{}{}, &errs)
+
+	return joinErrors(errs)
+}
+
+// collectTagErrors appends to errs errors of tags found in valType, found at
+// given path of configType. Fields of types already visited are skipped, so
+// recursive types are checked once. Variables are only named for fields
+// outside of slices, arrays and maps, whose keys are unknown.
+func (e *envConfig) collectTagErrors(configType, valType reflect.Type, valuePath path, named bool, visited map[reflect.Type]struct{}, errs *[]error) {
+	valType = indirectedType(valType)
+
+	switch valType.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		e.collectTagErrors(configType, valType.Elem(), valuePath, false, visited, errs)
+	case reflect.Struct:
+		if _, ok := visited[valType]; ok {
+			return
+		}
+
+		visited[valType] = struct{}{}
+
+		for i := 0; i < valType.NumField(); i++ {
+			field := valType.Field(i)
+
+			if e.skipsField(field) {
+				continue
+			}
+
+			fieldPath := valuePath
+
+			if !field.Anonymous {
+				fieldPath = append(valuePath.clone(), field.Name)
+			}
+
+			if err := checkFieldTag(field); err != nil {
+				variable := ""
+
+				if named {
+					variable = e.variableName(configType, fieldPath)
+				}
+
+				err = withCode(CodeInvalidTag, fmt.Errorf("Invalid envconfig tag of field [%s]: %v", strings.Join(fieldPath, "."), err))
+				*errs = append(*errs, newLoadError(configType, variable, fieldPath, "", err))
+
+				continue
+			}
+
+			e.collectTagErrors(configType, field.Type, fieldPath, named, visited, errs)
+		}
+	}
+}
+
+// checkFieldTag returns an error if the envconfig tag of given field can't be
+// parsed, or if the field still uses the encoding tag, now an option of the
+// envconfig tag.
+func checkFieldTag(field reflect.StructField) error {
+	if enc, ok := field.Tag.Lookup(encodingTag); ok {
+		return fmt.Errorf("encoding:%q is replaced by envconfig:\"encoding=%s\"", enc, enc)
+	}
+
+	t, ok := field.Tag.Lookup(envConfigTag)
+
+	if !ok || t == ignoreTag {
+		return nil
+	}
+
+	_, err := parseTagOptions(t)
+
+	return err
+}
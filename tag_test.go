@@ -0,0 +1,306 @@
+package envconfig
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jlevesy/envconfig/setter"
+)
+
+func TestParseTagOptions(t *testing.T) {
+	testCases := []struct {
+		Label       string
+		Tag         string
+		Expectation tagOptions
+		Error       bool
+	}{
+		{"WithFlag", "noexpand", tagOptions{noExpand: ""}, false},
+		{"WithName", "name=HTTP_PROXY", tagOptions{nameOption: "HTTP_PROXY"}, false},
+		{
+			"WithSeveralOptions",
+			"name=PORT,default=8080,required",
+			tagOptions{nameOption: "PORT", defaultOption: "8080", requiredOption: ""},
+			false,
+		},
+		{
+			"WithCommaInValue",
+			"default=a,b,noexpand",
+			tagOptions{defaultOption: "a,b", noExpand: ""},
+			false,
+		},
+		{"WithEmptyDefault", "default=", tagOptions{defaultOption: ""}, false},
+		{"WithUnknownOption", "groot", nil, true},
+		{"WithIgnoreTag", "-", nil, true},
+		{"WithDuplicateOption", "required,required", nil, true},
+		{"WithMissingValue", "name", nil, true},
+		{"WithEmptyName", "name=", nil, true},
+		{"WithSetter", "setter=csv,required", tagOptions{setterOption: "csv", requiredOption: ""}, false},
+		{"WithEmptySetter", "setter=", nil, true},
+		{"WithCommaSplit", "split=,", tagOptions{splitOption: ","}, false},
+		{"WithSplitAndDefault", "split=,,default=a,b", tagOptions{splitOption: ",", defaultOption: "a,b"}, false},
+		{"WithSeparator", "separator=;,required", tagOptions{splitOption: ";", requiredOption: ""}, false},
+		{"WithEmptySeparator", "separator=", nil, true},
+		{"WithSeparatorAndSplit", "separator=;,split=,", nil, true},
+		{"WithFlagValue", "noexpand=true", nil, true},
+		{"WithEncoding", "encoding=hex,default=cafe", tagOptions{encodingOption: "hex", defaultOption: "cafe"}, false},
+		{"WithUnknownEncoding", "encoding=base32", nil, true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			result, err := parseTagOptions(testCase.Tag)
+
+			if testCase.Error {
+				if err == nil {
+					t.Logf("Expected an error, got %v", result)
+					t.Fail()
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Logf("Wasn't expecting an error, got [%v]", err)
+				t.FailNow()
+			}
+
+			if !reflect.DeepEqual(result, testCase.Expectation) {
+				t.Logf("Expected %v got %v", testCase.Expectation, result)
+				t.Fail()
+			}
+		})
+	}
+}
+
+type taggedConfig struct {
+	Port    int           `envconfig:"default=8080"`
+	Timeout time.Duration `envconfig:"default=5s"`
+	Hosts   []string      `envconfig:"default=a,b,noexpand"`
+	Token   string        `envconfig:"required"`
+}
+
+type invalidDefaultConfig struct {
+	Hosts []string `envconfig:"default=a,b"`
+}
+
+func TestLoadWithTagOptions(t *testing.T) {
+	setters := setter.LoadBasicTypes()
+	setters[reflect.TypeOf([]string{})] = setter.SetterFunc(sliceOfStringSetter)
+
+	testCases := []struct {
+		Label       string
+		Env         mapSource
+		Config      interface{}
+		Expectation interface{}
+		Error       ErrorCode
+	}{
+		{
+			"WithDefaults",
+			mapSource{"APP_TOKEN": "secret"},
+			&taggedConfig{},
+			&taggedConfig{Port: 8080, Timeout: 5 * time.Second, Hosts: []string{"a", "b"}, Token: "secret"},
+			"",
+		},
+		{
+			"WithVariablesSet",
+			mapSource{"APP_TOKEN": "secret", "APP_PORT": "9090", "APP_HOSTS": "c"},
+			&taggedConfig{},
+			&taggedConfig{Port: 9090, Timeout: 5 * time.Second, Hosts: []string{"c"}, Token: "secret"},
+			"",
+		},
+		{
+			"WithValueAlreadySet",
+			mapSource{"APP_TOKEN": "secret"},
+			&taggedConfig{Port: 1234},
+			&taggedConfig{Port: 1234, Timeout: 5 * time.Second, Hosts: []string{"a", "b"}, Token: "secret"},
+			"",
+		},
+		{"WithMissingRequired", mapSource{}, &taggedConfig{}, nil, CodeMissingRequired},
+		{"WithExpandedDefault", mapSource{}, &invalidDefaultConfig{}, nil, CodeInvalidConfig},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			err := NewWithSettersAndDepth(
+				"APP",
+				"_",
+				setters,
+				DefaultDepth,
+				WithSource(testCase.Env),
+			).Load(testCase.Config)
+
+			if testCase.Error != "" {
+				if code := CodeOf(err); code != testCase.Error {
+					t.Logf("Expected code %s, got [%v]", testCase.Error, err)
+					t.Fail()
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Logf("Wasn't expecting an error, got [%v]", err)
+				t.FailNow()
+			}
+
+			if !reflect.DeepEqual(testCase.Config, testCase.Expectation) {
+				t.Logf("Expected %+v got %+v", testCase.Expectation, testCase.Config)
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestReportDefaultTagOption(t *testing.T) {
+	var report *Report
+
+	err := New(
+		"APP",
+		"_",
+		WithSource(mapSource{"APP_TOKEN": "secret"}),
+		WithReport(func(r *Report) { report = r }),
+	).Load(&struct {
+		Port  int    `envconfig:"default=8080"`
+		Token string `envconfig:"required"`
+	}{})
+
+	if err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	entry, ok := report.Entry("Port")
+
+	if !ok || entry.Source != defaultOption || entry.RawValue != "8080" || entry.Value != 8080 {
+		t.Logf("Unexpected report entry %+v", entry)
+		t.Fail()
+	}
+}
+
+type ignoredRuntime struct {
+	Name string
+}
+
+type ignoredFieldsConfig struct {
+	ignoredRuntime `envconfig:"-"`
+	Host           string
+	Client         *http.Client   `envconfig:"-"`
+	Mu             sync.Mutex     `envconfig:"-"`
+	Hook           func()         `envconfig:"-"`
+	Token          Lazy[string]   `envconfig:"-"`
+	Runtime        ignoredRuntime `envconfig:"-"`
+}
+
+func TestLoadWithIgnoreTag(t *testing.T) {
+	env := mapSource{
+		"APP_HOST":         "localhost",
+		"APP_NAME":         "embedded",
+		"APP_CLIENT":       "client",
+		"APP_TOKEN":        "secret",
+		"APP_RUNTIME_NAME": "runtime",
+	}
+
+	var report *Report
+
+	loader := New("APP", "_", WithSource(env), WithReport(func(r *Report) { report = r }))
+
+	var result ignoredFieldsConfig
+
+	if err := loader.Load(&result); err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	if result.Host != "localhost" || result.Name != "" || result.Client != nil || result.Runtime.Name != "" {
+		t.Logf("Expected ignored fields to be left alone, got %+v", &result)
+		t.Fail()
+	}
+
+	if len(report.Entries) != 1 {
+		t.Logf("Expected a single value to be assigned, got %v", report.Entries)
+		t.Fail()
+	}
+
+	if _, err := result.Token.Get(context.Background()); err == nil {
+		t.Log("Expected the ignored Lazy field not to be bound")
+		t.Fail()
+	}
+
+	vars, err := loader.(Describer).Describe(&ignoredFieldsConfig{})
+
+	if err != nil || len(vars) != 1 || vars[0].Name != "APP_HOST" {
+		t.Logf("Expected only APP_HOST to be described, got %v, %v", vars, err)
+		t.Fail()
+	}
+
+	dumped, err := loader.(Dumper).Dump(&result)
+
+	if err != nil || len(dumped) != 1 {
+		t.Logf("Expected only APP_HOST to be dumped, got %v, %v", dumped, err)
+		t.Fail()
+	}
+}
+
+func TestLoadWithInvalidTag(t *testing.T) {
+	type invalidTagConfig struct {
+		Host   string `envconfig:"requird"`
+		Nested struct {
+			Port int `envconfig:"default"`
+		}
+		Routes map[string]struct {
+			URL string `envconfig:"name="`
+		}
+	}
+
+	loader := New("APP", "_", WithSource(mapSource{"APP_HOST": "localhost"}), WithAllErrors())
+
+	var (
+		result invalidTagConfig
+		errs   []*LoadError
+	)
+
+	err := loader.Load(&result)
+
+	for _, err := range flattenErrors(err) {
+		if loadErr, ok := err.(*LoadError); ok && loadErr.Code() == CodeInvalidTag {
+			errs = append(errs, loadErr)
+		}
+	}
+
+	expectation := []LoadError{
+		{Variable: "APP_HOST", Path: "Host"},
+		{Variable: "APP_NESTED_PORT", Path: "Nested.Port"},
+		{Variable: "", Path: "Routes.URL"},
+	}
+
+	if len(errs) != len(expectation) {
+		t.Logf("Expected %d invalid tag errors, got [%v]", len(expectation), err)
+		t.FailNow()
+	}
+
+	for i, loadErr := range errs {
+		if loadErr.Variable != expectation[i].Variable || loadErr.Path != expectation[i].Path {
+			t.Logf("Expected %s at %s, got %+v", expectation[i].Variable, expectation[i].Path, loadErr)
+			t.Fail()
+		}
+	}
+
+	if result.Host != "" {
+		t.Logf("Expected nothing to be loaded, got %+v", result)
+		t.Fail()
+	}
+
+	if _, err := loader.(Describer).Describe(&invalidTagConfig{}); CodeOf(err) != CodeInvalidTag {
+		t.Logf("Expected Describe to fail with %s, got [%v]", CodeInvalidTag, err)
+		t.Fail()
+	}
+
+	if _, err := loader.(Dumper).Dump(&invalidTagConfig{}); CodeOf(err) != CodeInvalidTag {
+		t.Logf("Expected Dump to fail with %s, got [%v]", CodeInvalidTag, err)
+		t.Fail()
+	}
+}
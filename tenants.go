@@ -0,0 +1,15 @@
+package envconfig
+
+// LoadTenants loads one configuration per tenant, tenants being discovered
+// from variables named after the loader's prefix: with the GROOT_TENANTS
+// prefix, GROOT_TENANTS_ACME_HOST sets the Host field of the "acme" tenant.
+// Tenant names are lower cased, and can't contain the separator.
+func LoadTenants[T any](loader ConfigLoader) (map[string]T, error) {
+	res := map[string]T{}
+
+	if err := loader.Load(&res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
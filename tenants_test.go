@@ -0,0 +1,35 @@
+package envconfig
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLoadTenants(t *testing.T) {
+	env := map[string]string{
+		"GROOT_TENANTS_ACME_STRING_VALUE":   "FOO",
+		"GROOT_TENANTS_ACME_INT_VALUE":      "10",
+		"GROOT_TENANTS_GLOBEX_STRING_VALUE": "BAR",
+		"GROOT_TENANTS_GLOBEX_BOOL_VALUE":   "true",
+	}
+
+	setupEnv(env)
+	defer cleanupEnv(env)
+
+	result, err := LoadTenants[basicAppConfig](New("GROOT_TENANTS", "_"))
+
+	if err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	expectation := map[string]basicAppConfig{
+		"acme":   {StringValue: "FOO", IntValue: 10},
+		"globex": {StringValue: "BAR", BoolValue: true},
+	}
+
+	if !reflect.DeepEqual(result, expectation) {
+		t.Logf("Invalid assignation, expected %v got %v", expectation, result)
+		t.Fail()
+	}
+}
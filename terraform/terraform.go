@@ -0,0 +1,145 @@
+// Package terraform generates Terraform files from the variables a
+// configuration struct is loaded from, so infrastructure code injecting them
+// into containers stays in sync with the application.
+//
+// Terraform variables are named after environment variables in lower case:
+// APP_DATABASE_MAX_CONNS maps to the app_database_max_conns variable.
+package terraform
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jlevesy/envconfig"
+)
+
+// Variables writes a variables.tf document declaring a variable for each
+// given variable. defaults maps variable names to their default value, as
+// returned by Dump, variables without default are required.
+func Variables(w io.Writer, vars []envconfig.VarInfo, defaults map[string]string) error {
+	for i, v := range vars {
+		if i > 0 {
+			if _, err := io.WriteString(w, "\n"); err != nil {
+				return err
+			}
+		}
+
+		_, err := fmt.Fprintf(
+			w,
+			"variable %q {\n  type        = %s\n  description = %s\n",
+			variableName(v),
+			variableType(v.Type),
+			quote("Sets "+v.Name),
+		)
+
+		if err != nil {
+			return err
+		}
+
+		if value, ok := defaults[v.Name]; ok {
+			if _, err := fmt.Fprintf(w, "  default     = %s\n", literal(v.Type, value)); err != nil {
+				return err
+			}
+		}
+
+		if _, err := io.WriteString(w, "}\n"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// TFVars writes a tfvars skeleton setting each given variable, to values
+// found in given map, or to the zero value of its type.
+func TFVars(w io.Writer, vars []envconfig.VarInfo, values map[string]string) error {
+	for _, v := range vars {
+		value, ok := values[v.Name]
+
+		if !ok {
+			value = zeroValue(v.Type)
+		}
+
+		if _, err := fmt.Fprintf(w, "%s = %s\n", variableName(v), literal(v.Type, value)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Env writes the entries of an object mapping each environment variable to
+// its Terraform variable, to be used as the environment of a container.
+func Env(w io.Writer, vars []envconfig.VarInfo) error {
+	for _, v := range vars {
+		if _, err := fmt.Fprintf(w, "%s = tostring(var.%s)\n", v.Name, variableName(v)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func variableName(v envconfig.VarInfo) string {
+	return strings.ToLower(v.Name)
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+func variableType(valType reflect.Type) string {
+	if valType == durationType {
+		return "string"
+	}
+
+	switch valType.Kind() {
+	case reflect.Bool:
+		return "bool"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	default:
+		return "string"
+	}
+}
+
+func zeroValue(valType reflect.Type) string {
+	switch variableType(valType) {
+	case "bool":
+		return "false"
+	case "number":
+		return "0"
+	default:
+		return ""
+	}
+}
+
+// literal formats value as a literal of the variable type, values which
+// aren't valid literals of this type are written as strings.
+func literal(valType reflect.Type, value string) string {
+	switch variableType(valType) {
+	case "bool":
+		if b, err := strconv.ParseBool(value); err == nil {
+			return strconv.FormatBool(b)
+		}
+	case "number":
+		if _, err := strconv.ParseFloat(value, 64); err == nil {
+			return value
+		}
+	}
+
+	return quote(value)
+}
+
+// quote quotes s as a HCL string, escaping template sequences
+func quote(s string) string {
+	s = strconv.Quote(s)
+	s = strings.ReplaceAll(s, "${", "$${")
+	s = strings.ReplaceAll(s, "%{", "%%{")
+
+	return s
+}
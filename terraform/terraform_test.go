@@ -0,0 +1,118 @@
+package terraform
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/jlevesy/envconfig"
+)
+
+type appConfig struct {
+	Database struct {
+		Host     string
+		MaxConns int
+	}
+	Timeout time.Duration
+	Debug   bool
+}
+
+func describe(t *testing.T) []envconfig.VarInfo {
+	vars, err := envconfig.New("APP", "_").(envconfig.Describer).Describe(&appConfig{})
+
+	if err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	return vars
+}
+
+func TestVariables(t *testing.T) {
+	var defaults appConfig
+
+	defaults.Database.Host = "${host}"
+	defaults.Database.MaxConns = 10
+	defaults.Timeout = 5 * time.Second
+
+	values, err := envconfig.New("APP", "_").(envconfig.Dumper).Dump(&defaults)
+
+	if err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	delete(values, "APP_DEBUG")
+
+	var buf bytes.Buffer
+
+	if err := Variables(&buf, describe(t), values); err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	expectation := `variable "app_database_host" {
+  type        = string
+  description = "Sets APP_DATABASE_HOST"
+  default     = "$${host}"
+}
+
+variable "app_database_max_conns" {
+  type        = number
+  description = "Sets APP_DATABASE_MAX_CONNS"
+  default     = 10
+}
+
+variable "app_timeout" {
+  type        = string
+  description = "Sets APP_TIMEOUT"
+  default     = "5s"
+}
+
+variable "app_debug" {
+  type        = bool
+  description = "Sets APP_DEBUG"
+}
+`
+
+	if buf.String() != expectation {
+		t.Logf("Expected\n%s\ngot\n%s", expectation, buf.String())
+		t.Fail()
+	}
+}
+
+func TestTFVars(t *testing.T) {
+	var buf bytes.Buffer
+
+	if err := TFVars(&buf, describe(t), map[string]string{"APP_DEBUG": "true"}); err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	expectation := `app_database_host = ""
+app_database_max_conns = 0
+app_timeout = ""
+app_debug = true
+`
+
+	if buf.String() != expectation {
+		t.Logf("Expected\n%s\ngot\n%s", expectation, buf.String())
+		t.Fail()
+	}
+}
+
+func TestEnv(t *testing.T) {
+	var buf bytes.Buffer
+
+	if err := Env(&buf, describe(t)[:1]); err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	expectation := "APP_DATABASE_HOST = tostring(var.app_database_host)\n"
+
+	if buf.String() != expectation {
+		t.Logf("Expected\n%s\ngot\n%s", expectation, buf.String())
+		t.Fail()
+	}
+}
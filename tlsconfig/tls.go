@@ -0,0 +1,169 @@
+// Package tlsconfig provides a configuration struct describing TLS material,
+// meant to be embedded in envconfig structs, and able to build a *tls.Config.
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+
+	"github.com/jlevesy/envconfig/setter"
+)
+
+// Version is a TLS protocol version, set from strings like "1.2"
+type Version uint16
+
+var versions = map[string]Version{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+func setVersion(strValue string, value reflect.Value) error {
+	v, ok := versions[strValue]
+
+	if !ok {
+		return fmt.Errorf("Unsupported TLS version [%s], expected one of 1.0, 1.1, 1.2, 1.3", strValue)
+	}
+
+	value.SetUint(uint64(v))
+
+	return nil
+}
+
+// RegisterSetters adds setters for types of this package into given setter
+// collection.
+func RegisterSetters(setters map[reflect.Type]setter.Setter) {
+	setters[reflect.TypeOf(Version(0))] = setter.SetterFunc(setVersion)
+}
+
+// Config describes TLS material.
+// Certificate, key and CA can either be given inline as PEM or as file paths,
+// inline values take precedence.
+type Config struct {
+	Cert     string
+	CertFile string
+	Key      string `secret:"true"`
+	KeyFile  string
+	CA       string
+	CAFile   string
+
+	// MinVersion defaults to 1.2
+	MinVersion Version
+
+	// ServerName is used to verify the hostname of the server
+	ServerName string
+
+	// VerifyClient requires clients to present a certificate signed by CA
+	VerifyClient bool
+
+	InsecureSkipVerify bool
+}
+
+// Build returns a *tls.Config according to the configuration
+func (c *Config) Build() (*tls.Config, error) {
+	res := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         c.ServerName,
+		InsecureSkipVerify: c.InsecureSkipVerify,
+	}
+
+	if c.MinVersion != 0 {
+		res.MinVersion = uint16(c.MinVersion)
+	}
+
+	cert, err := c.certificate()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if cert != nil {
+		res.Certificates = []tls.Certificate{*cert}
+	}
+
+	pool, err := c.certPool()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if pool != nil {
+		res.RootCAs = pool
+
+		if c.VerifyClient {
+			res.ClientCAs = pool
+			res.ClientAuth = tls.RequireAndVerifyClientCert
+		}
+	}
+
+	if c.VerifyClient && pool == nil {
+		return nil, errors.New("Client verification requires a CA")
+	}
+
+	return res, nil
+}
+
+func (c *Config) certificate() (*tls.Certificate, error) {
+	certPEM, err := readPEM(c.Cert, c.CertFile)
+
+	if err != nil {
+		return nil, err
+	}
+
+	keyPEM, err := readPEM(c.Key, c.KeyFile)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if certPEM == nil && keyPEM == nil {
+		return nil, nil
+	}
+
+	if certPEM == nil || keyPEM == nil {
+		return nil, errors.New("Both certificate and key must be provided")
+	}
+
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &cert, nil
+}
+
+func (c *Config) certPool() (*x509.CertPool, error) {
+	caPEM, err := readPEM(c.CA, c.CAFile)
+
+	if err != nil || caPEM == nil {
+		return nil, err
+	}
+
+	pool := x509.NewCertPool()
+
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return nil, errors.New("No valid certificate found in CA")
+	}
+
+	return pool, nil
+}
+
+// readPEM returns inline PEM content if defined, otherwise content of file if
+// defined, nil otherwise.
+func readPEM(inline, file string) ([]byte, error) {
+	if inline != "" {
+		return []byte(inline), nil
+	}
+
+	if file == "" {
+		return nil, nil
+	}
+
+	return ioutil.ReadFile(file)
+}
@@ -0,0 +1,183 @@
+package tlsconfig
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jlevesy/envconfig"
+	"github.com/jlevesy/envconfig/setter"
+)
+
+func selfSignedPEM(t *testing.T) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "groot"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+
+	if err != nil {
+		t.Fatalf("Failed to generate certificate: %v", err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+
+	if err != nil {
+		t.Fatalf("Failed to marshal key: %v", err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+}
+
+func TestBuild(t *testing.T) {
+	certPEM, keyPEM := selfSignedPEM(t)
+
+	dir, err := ioutil.TempDir("", "tlsconfig")
+
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	ioutil.WriteFile(certFile, []byte(certPEM), 0600)
+	ioutil.WriteFile(keyFile, []byte(keyPEM), 0600)
+
+	testCases := []struct {
+		Label        string
+		Config       Config
+		ExpectsError bool
+		Then         func(t *testing.T, result *tls.Config)
+	}{
+		{
+			"WithDefaults",
+			Config{},
+			false,
+			func(t *testing.T, result *tls.Config) {
+				if result.MinVersion != tls.VersionTLS12 || len(result.Certificates) != 0 || result.RootCAs != nil {
+					t.Logf("Unexpected config %v", result)
+					t.Fail()
+				}
+			},
+		},
+		{
+			"WithInlineMaterial",
+			Config{Cert: certPEM, Key: keyPEM, CA: certPEM, MinVersion: tls.VersionTLS13},
+			false,
+			func(t *testing.T, result *tls.Config) {
+				if result.MinVersion != tls.VersionTLS13 || len(result.Certificates) != 1 || result.RootCAs == nil {
+					t.Logf("Unexpected config %v", result)
+					t.Fail()
+				}
+			},
+		},
+		{
+			"WithFiles",
+			Config{CertFile: certFile, KeyFile: keyFile, CAFile: certFile, VerifyClient: true},
+			false,
+			func(t *testing.T, result *tls.Config) {
+				if len(result.Certificates) != 1 ||
+					result.ClientCAs == nil ||
+					result.ClientAuth != tls.RequireAndVerifyClientCert {
+					t.Logf("Unexpected config %v", result)
+					t.Fail()
+				}
+			},
+		},
+		{"WithMissingKey", Config{Cert: certPEM}, true, nil},
+		{"WithMissingFile", Config{CertFile: certFile, KeyFile: filepath.Join(dir, "nope.pem")}, true, nil},
+		{"WithInvalidCA", Config{CA: "I AM GROOT"}, true, nil},
+		{"WithClientVerificationWithoutCA", Config{VerifyClient: true}, true, nil},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			result, err := testCase.Config.Build()
+
+			if testCase.ExpectsError {
+				if err == nil {
+					t.Log("Expected an error, got nothing")
+					t.Fail()
+				}
+				return
+			}
+
+			if err != nil {
+				t.Logf("Wasn't expecting an error, got [%v]", err)
+				t.FailNow()
+			}
+
+			testCase.Then(t, result)
+		})
+	}
+}
+
+func TestLoadVersion(t *testing.T) {
+	os.Setenv("APP_TLS_MIN_VERSION", "1.3")
+	defer os.Unsetenv("APP_TLS_MIN_VERSION")
+
+	setters := setter.LoadBasicTypes()
+	RegisterSetters(setters)
+
+	config := struct {
+		TLS Config
+	}{}
+
+	if err := envconfig.NewWithSettersAndDepth("APP", "_", setters, envconfig.DefaultDepth).Load(&config); err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	if config.TLS.MinVersion != tls.VersionTLS13 {
+		t.Logf("Expected version %d got %d", tls.VersionTLS13, config.TLS.MinVersion)
+		t.Fail()
+	}
+}
+
+func TestKeyIsSecret(t *testing.T) {
+	var report *envconfig.Report
+
+	_, key := selfSignedPEM(t)
+	os.Setenv("APP_TLS_KEY", key)
+	defer os.Unsetenv("APP_TLS_KEY")
+
+	config := struct {
+		TLS Config
+	}{}
+
+	loader := envconfig.New("APP", "_", envconfig.WithReport(func(r *envconfig.Report) { report = r }))
+
+	if err := loader.Load(&config); err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	if entry, ok := report.Entry("TLS.Key"); !ok || entry.RawValue == key || entry.Value == key {
+		t.Logf("Expected the key to be redacted, got %+v", entry)
+		t.Fail()
+	}
+}
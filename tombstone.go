@@ -0,0 +1,150 @@
+package envconfig
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// WithMapTombstone makes variables naming a map entry delete it when their
+// value is sentinel: with the "<delete>" sentinel, APP_LABELS_TEAM="<delete>"
+// deletes the "team" entry of the Labels map, set by a previous source or by
+// defaults. Entries holding structs are deleted by their whole struct variable,
+// like APP_ROUTES_FOO="<delete>".
+func WithMapTombstone(sentinel string) Option {
+	return func(e *envConfig) {
+		e.mapTombstone = sentinel
+	}
+}
+
+// splitTombstones separates values deleting a map entry from values to assign
+func (e *envConfig) splitTombstones(configType reflect.Type, values []*envValue) ([]*envValue, []*envValue) {
+	if e.mapTombstone == "" {
+		return values, nil
+	}
+
+	var (
+		assigned   = make([]*envValue, 0, len(values))
+		tombstones []*envValue
+	)
+
+	for _, v := range values {
+		if v.StrValue == e.mapTombstone && isMapEntryPath(configType, v.Path) {
+			tombstones = append(tombstones, v)
+			continue
+		}
+
+		assigned = append(assigned, v)
+	}
+
+	return assigned, tombstones
+}
+
+// deleteMapEntries deletes map entries named by given tombstones, and forgets
+// values previously assigned into them.
+func (e *envConfig) deleteMapEntries(configVal reflect.Value, configType reflect.Type, tombstones []*envValue, assigned map[string]ReportEntry) error {
+	for _, v := range tombstones {
+		if err := e.deleteAtPath(configVal, configType, v.Path); err != nil {
+			return err
+		}
+
+		prefix := v.Path.key()
+
+		for key := range assigned {
+			if key == prefix || strings.HasPrefix(key, prefix+"\x00") {
+				delete(assigned, key)
+			}
+		}
+	}
+
+	return nil
+}
+
+// isMapEntryPath reports if given path ends with a map key
+func isMapEntryPath(configType reflect.Type, valPath path) bool {
+	valType := configType
+
+	for i, key := range valPath {
+		valType = indirectedType(valType)
+
+		switch valType.Kind() {
+		case reflect.Struct:
+			field, ok := valType.FieldByName(key)
+
+			if !ok {
+				return false
+			}
+
+			valType = field.Type
+		case reflect.Map:
+			if i == len(valPath)-1 {
+				return true
+			}
+
+			valType = valType.Elem()
+		case reflect.Slice, reflect.Array:
+			valType = valType.Elem()
+		default:
+			return false
+		}
+	}
+
+	return false
+}
+
+// deleteAtPath deletes the map entry at given path, missing values on the way
+// are left untouched.
+func (e *envConfig) deleteAtPath(val reflect.Value, valType reflect.Type, currentPath path) error {
+	switch valType.Kind() {
+	case reflect.Ptr:
+		if val.IsNil() {
+			return nil
+		}
+
+		return e.deleteAtPath(val.Elem(), valType.Elem(), currentPath)
+	case reflect.Struct:
+		fieldName, currentPath := currentPath.popBack()
+		structField, _ := valType.FieldByName(fieldName)
+
+		return e.deleteAtPath(fieldByIndex(val, structField.Index), structField.Type, currentPath)
+	case reflect.Slice, reflect.Array:
+		key, currentPath := currentPath.popBack()
+		index, err := strconv.Atoi(key)
+
+		if err != nil || index >= val.Len() {
+			return nil
+		}
+
+		return e.deleteAtPath(val.Index(index), valType.Elem(), currentPath)
+	case reflect.Map:
+		keyString, currentPath := currentPath.popBack()
+		keyValue := reflect.New(valType.Key()).Elem()
+
+		if err := e.setValue(keyValue, keyString); err != nil {
+			return err
+		}
+
+		if len(currentPath) == 0 {
+			val.SetMapIndex(keyValue, reflect.Value{})
+			return nil
+		}
+
+		existing := val.MapIndex(keyValue)
+
+		if !existing.IsValid() {
+			return nil
+		}
+
+		// Map elements aren't addressable, work on a copy of the existing one
+		elemValue := reflect.New(valType.Elem()).Elem()
+		elemValue.Set(existing)
+
+		if err := e.deleteAtPath(elemValue, valType.Elem(), currentPath); err != nil {
+			return err
+		}
+
+		val.SetMapIndex(keyValue, elemValue)
+	}
+
+	return nil
+}
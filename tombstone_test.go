@@ -0,0 +1,89 @@
+package envconfig
+
+import (
+	"reflect"
+	"testing"
+)
+
+type tombstoneRoute struct {
+	Port int
+}
+
+type tombstoneConfig struct {
+	Labels map[string]string
+	Routes map[string]*tombstoneRoute
+	Groups []map[int]string
+}
+
+func TestLoadWithMapTombstone(t *testing.T) {
+	defaults := tombstoneConfig{
+		Labels: map[string]string{"team": "groot", "tier": "gold"},
+		Routes: map[string]*tombstoneRoute{"foo": {80}, "bar": {81}},
+		Groups: []map[int]string{{1: "a", 2: "b"}},
+	}
+
+	base := mapSource{
+		"APP_LABELS_ENV":      "prod",
+		"APP_ROUTES_BIZ_PORT": "82",
+		"APP_ROUTES_BAR_PORT": "83",
+	}
+
+	override := mapSource{
+		"APP_LABELS_TEAM":     "<delete>",
+		"APP_LABELS_ENV":      "<delete>",
+		"APP_ROUTES_BAR":      "<delete>",
+		"APP_ROUTES_BIZ_PORT": "<delete>",
+		"APP_GROUPS_0_2":      "<delete>",
+	}
+
+	var report *Report
+
+	loader := New(
+		"APP",
+		"_",
+		WithDefaults(&defaults),
+		WithMapTombstone("<delete>"),
+		WithSource(base),
+		WithSource(override),
+		WithReport(func(r *Report) { report = r }),
+	)
+
+	var result tombstoneConfig
+
+	err := loader.Load(&result)
+
+	// Ports are ints, a tombstone on a struct field isn't a deletion
+	if err == nil {
+		t.Log("Expected an error, got nothing")
+		t.FailNow()
+	}
+
+	delete(override, "APP_ROUTES_BIZ_PORT")
+	result = tombstoneConfig{}
+
+	if err := loader.Load(&result); err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	expectation := tombstoneConfig{
+		Labels: map[string]string{"tier": "gold"},
+		Routes: map[string]*tombstoneRoute{"foo": {80}, "biz": {82}},
+		Groups: []map[int]string{{1: "a"}},
+	}
+
+	if !reflect.DeepEqual(result, expectation) {
+		t.Logf("Expected %+v got %+v", expectation, result)
+		t.Fail()
+	}
+
+	if _, ok := report.Entry("Routes.bar.Port"); ok {
+		t.Log("Expected deleted entry to be left out of the report")
+		t.Fail()
+	}
+
+	if _, ok := report.Entry("Labels.env"); ok {
+		t.Log("Expected deleted entry to be left out of the report")
+		t.Fail()
+	}
+}
@@ -0,0 +1,37 @@
+package envconfig
+
+import "context"
+
+// Tracer starts spans around loader operations.
+// It is deliberately small so any tracing library, like OpenTelemetry, can be
+// plugged without envconfig depending on it.
+type Tracer interface {
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span represents an ongoing traced operation
+type Span interface {
+	SetAttribute(key, value string)
+	End(err error)
+}
+
+// WithTracer traces Load calls and environment lookups using given Tracer
+func WithTracer(tracer Tracer) Option {
+	return func(e *envConfig) {
+		e.tracer = tracer
+	}
+}
+
+func (e *envConfig) startSpan(ctx context.Context, name string) (context.Context, Span) {
+	if e.tracer == nil {
+		return ctx, noopSpan{}
+	}
+
+	return e.tracer.Start(ctx, name)
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttribute(key, value string) {}
+
+func (noopSpan) End(err error) {}
@@ -0,0 +1,93 @@
+package envconfig
+
+import (
+	"context"
+	"testing"
+)
+
+type recordedSpan struct {
+	name       string
+	attributes map[string]string
+	ended      bool
+	err        error
+}
+
+func (s *recordedSpan) SetAttribute(key, value string) {
+	s.attributes[key] = value
+}
+
+func (s *recordedSpan) End(err error) {
+	s.ended = true
+	s.err = err
+}
+
+type recordingTracer struct {
+	spans []*recordedSpan
+}
+
+func (r *recordingTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	span := &recordedSpan{name: name, attributes: map[string]string{}}
+	r.spans = append(r.spans, span)
+	return ctx, span
+}
+
+func TestLoadWithTracer(t *testing.T) {
+	testCases := []struct {
+		Label        string
+		Env          map[string]string
+		Expectation  []string
+		ExpectsError bool
+	}{
+		{
+			"WithValidEnv",
+			map[string]string{"TRACED_INT_VALUE": "10"},
+			[]string{"envconfig.Load", "envconfig.lookup", "envconfig.assign"},
+			false,
+		},
+		{
+			"WithInvalidValue",
+			map[string]string{"TRACED_INT_VALUE": "NOT_AN_INT"},
+			[]string{"envconfig.Load", "envconfig.lookup", "envconfig.assign"},
+			true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			setupEnv(testCase.Env)
+			defer cleanupEnv(testCase.Env)
+
+			tracer := &recordingTracer{}
+			err := New("TRACED", "_", WithTracer(tracer)).Load(&basicAppConfig{})
+
+			if len(tracer.spans) != len(testCase.Expectation) {
+				t.Logf("Expected %d spans got %d", len(testCase.Expectation), len(tracer.spans))
+				t.FailNow()
+			}
+
+			for i, name := range testCase.Expectation {
+				span := tracer.spans[i]
+
+				if span.name != name {
+					t.Logf("Expected span [%s] got [%s]", name, span.name)
+					t.Fail()
+				}
+
+				if !span.ended {
+					t.Logf("Span [%s] hasn't been ended", span.name)
+					t.Fail()
+				}
+			}
+
+			if loadErr := tracer.spans[0].err; loadErr != err {
+				t.Logf("Expected Load span to end with [%v] got [%v]", err, loadErr)
+				t.Fail()
+			}
+
+			if (err != nil) != testCase.ExpectsError {
+				t.Logf("Unexpected error [%v]", err)
+				t.Fail()
+			}
+		})
+	}
+}
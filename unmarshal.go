@@ -0,0 +1,210 @@
+package envconfig
+
+import (
+	"encoding"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/jlevesy/envconfig/internal/tags"
+	"github.com/jlevesy/envconfig/setter"
+)
+
+var (
+	binaryUnmarshalerType = reflect.TypeOf((*encoding.BinaryUnmarshaler)(nil)).Elem()
+	flagValueType         = reflect.TypeOf((*flag.Value)(nil)).Elem()
+	jsonUnmarshalerType   = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+)
+
+// setterOf returns the setter of given type: the registered one, or else the
+// one registered for an interface it implements, or else one relying on an
+// interface implemented by the type, like encoding.BinaryUnmarshaler or
+// flag.Value.
+func (e *envConfig) setterOf(valType reflect.Type) (setter.Setter, bool) {
+	if s, ok := e.currentSetters()[valType]; ok {
+		return s, true
+	}
+
+	if s, ok := e.interfaceSetter(valType); ok {
+		return s, true
+	}
+
+	return unmarshalerSetter(valType)
+}
+
+// interfaceSetter returns the setter registered for an interface implemented
+// by given type, or by a pointer to it. If several match, the interface with
+// the smallest name wins, so the choice is stable.
+func (e *envConfig) interfaceSetter(valType reflect.Type) (setter.Setter, bool) {
+	if valType.Kind() == reflect.Ptr || valType.Kind() == reflect.Interface {
+		return nil, false
+	}
+
+	var matching []reflect.Type
+
+	setters := e.currentSetters()
+
+	for t := range setters {
+		if t.Kind() == reflect.Interface && reflect.PtrTo(valType).Implements(t) {
+			matching = append(matching, t)
+		}
+	}
+
+	if len(matching) == 0 {
+		return nil, false
+	}
+
+	sort.Slice(matching, func(i, j int) bool {
+		return matching[i].String() < matching[j].String()
+	})
+
+	return setters[matching[0]], true
+}
+
+// decodesItself reports if values of given type are assigned from a single
+// variable because of an interface they implement, or because a setter is
+// registered for this named slice, array or map type, like net.IP. Setters of
+// unnamed ones, like []string, only apply to noexpand fields.
+func (e *envConfig) decodesItself(valType reflect.Type) bool {
+	if _, ok := e.interfaceSetter(valType); ok {
+		return true
+	}
+
+	switch valType.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		if _, ok := e.currentSetters()[valType]; ok && valType.Name() != "" {
+			return true
+		}
+	}
+
+	return isUnmarshaler(valType)
+}
+
+// unmarshalerSetter returns a setter for types decoding themselves from a
+// value. Such types are always assigned from a single variable.
+func unmarshalerSetter(valType reflect.Type) (setter.Setter, bool) {
+	if valType.Kind() == reflect.Ptr || valType.Kind() == reflect.Interface {
+		return nil, false
+	}
+
+	if reflect.PtrTo(valType).Implements(binaryUnmarshalerType) {
+		return setter.ContextSetterFunc(setBinary), true
+	}
+
+	if reflect.PtrTo(valType).Implements(flagValueType) {
+		return setter.SetterFunc(setFlagValue), true
+	}
+
+	return nil, false
+}
+
+// isUnmarshaler reports if given type decodes itself from a value
+func isUnmarshaler(valType reflect.Type) bool {
+	_, ok := unmarshalerSetter(valType)
+	return ok
+}
+
+// setBinary decodes the value using encoding.BinaryUnmarshaler, after
+// decoding it according to the encoding option of the field.
+func setBinary(sc setter.SetContext, val reflect.Value) error {
+	data, err := decodeBytes(sc)
+
+	if err != nil {
+		return err
+	}
+
+	return val.Addr().Interface().(encoding.BinaryUnmarshaler).UnmarshalBinary(data)
+}
+
+// setBytes assigns the value decoded according to the encoding option of the
+// field to given byte slice.
+func setBytes(sc setter.SetContext, val reflect.Value) error {
+	data, err := decodeBytes(sc)
+
+	if err != nil {
+		return err
+	}
+
+	val.SetBytes(data)
+
+	return nil
+}
+
+// decodeBytes decodes the value from base64 or hex if the field is tagged
+// with envconfig:"encoding=base64" or envconfig:"encoding=hex", values of
+// other fields are taken as is.
+func decodeBytes(sc setter.SetContext) ([]byte, error) {
+	enc, _ := fieldOptions(reflect.StructField{Tag: sc.Tag}).Get(encodingOption)
+
+	switch enc {
+	case "":
+		return []byte(sc.RawValue), nil
+	case tags.Base64:
+		data, err := base64.StdEncoding.DecodeString(sc.RawValue)
+
+		if err != nil {
+			return nil, fmt.Errorf("Invalid base64 value: %v", err)
+		}
+
+		return data, nil
+	case tags.Hex:
+		data, err := hex.DecodeString(sc.RawValue)
+
+		if err != nil {
+			return nil, fmt.Errorf("Invalid hex value: %v", err)
+		}
+
+		return data, nil
+	default:
+		return nil, withCode(CodeInvalidConfig, fmt.Errorf("Unknown encoding [%s]", enc))
+	}
+}
+
+// encodeBytes encodes given bytes according to the encoding option of the
+// field
+func encodeBytes(data []byte, options tagOptions) string {
+	enc, _ := options.Get(encodingOption)
+
+	switch enc {
+	case tags.Base64:
+		return base64.StdEncoding.EncodeToString(data)
+	case tags.Hex:
+		return hex.EncodeToString(data)
+	default:
+		return string(data)
+	}
+}
+
+// isEncodedBytes reports if given field is a byte slice tagged with the
+// encoding option, which is assigned from a single variable.
+func isEncodedBytes(field reflect.StructField, options tagOptions) bool {
+	valType := indirectedType(field.Type)
+
+	return valType.Kind() == reflect.Slice &&
+		valType.Elem().Kind() == reflect.Uint8 &&
+		options.Has(encodingOption)
+}
+
+// setFlagValue parses the value using flag.Value, easing migrations from flag
+// based configurations.
+func setFlagValue(strValue string, val reflect.Value) error {
+	return val.Addr().Interface().(flag.Value).Set(strValue)
+}
+
+// isJSONUnmarshaler reports if given type implements json.Unmarshaler
+func isJSONUnmarshaler(valType reflect.Type) bool {
+	return valType.Kind() != reflect.Ptr && reflect.PtrTo(valType).Implements(jsonUnmarshalerType)
+}
+
+// setJSON decodes given JSON value using json.Unmarshaler
+func setJSON(strValue string, val reflect.Value) error {
+	if err := json.Unmarshal([]byte(strValue), val.Addr().Interface()); err != nil {
+		return fmt.Errorf("Failed to decode JSON value [%v]: %v", val.Type(), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,358 @@
+package envconfig
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jlevesy/envconfig/setter"
+)
+
+// keyPair decodes itself from a "public:private" binary value
+type keyPair struct {
+	public  []byte
+	private []byte
+}
+
+func (k *keyPair) UnmarshalBinary(data []byte) error {
+	for i, b := range data {
+		if b == ':' {
+			k.public, k.private = data[:i], data[i+1:]
+			return nil
+		}
+	}
+
+	return errors.New("missing separator")
+}
+
+func (k keyPair) MarshalBinary() ([]byte, error) {
+	return append(append(append([]byte{}, k.public...), ':'), k.private...), nil
+}
+
+// token is a slice type decoding itself, it isn't expanded
+type token []byte
+
+func (t *token) UnmarshalBinary(data []byte) error {
+	*t = append(token{}, data...)
+	return nil
+}
+
+type binaryConfig struct {
+	Keys    keyPair
+	Signing *keyPair `envconfig:"encoding=base64"`
+	Token   token
+	Tokens  map[string]token
+}
+
+func TestLoadWithBinaryUnmarshaler(t *testing.T) {
+	source := mapSource{
+		"APP_KEYS":         "pub:priv",
+		"APP_SIGNING":      base64.StdEncoding.EncodeToString([]byte("spub:\x00spriv")),
+		"APP_TOKEN":        "groot",
+		"APP_TOKENS_ADMIN": "root",
+	}
+
+	var result binaryConfig
+
+	if err := New("APP", "_", WithSource(source)).Load(&result); err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	expectation := binaryConfig{
+		Keys:    keyPair{[]byte("pub"), []byte("priv")},
+		Signing: &keyPair{[]byte("spub"), []byte("\x00spriv")},
+		Token:   token("groot"),
+		Tokens:  map[string]token{"admin": token("root")},
+	}
+
+	if !reflect.DeepEqual(result, expectation) {
+		t.Logf("Expected %+v got %+v", expectation, result)
+		t.Fail()
+	}
+}
+
+func TestLoadWithInvalidBinaryValue(t *testing.T) {
+	testCases := []struct {
+		Label string
+		Env   mapSource
+	}{
+		{"WithUnmarshalerError", mapSource{"APP_KEYS": "groot"}},
+		{"WithInvalidBase64", mapSource{"APP_SIGNING": "not base64!"}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			err := New("APP", "_", WithSource(testCase.Env)).Load(&binaryConfig{})
+
+			if code := CodeOf(err); code != CodeParseFailure {
+				t.Logf("Expected code %s, got [%v]", CodeParseFailure, err)
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestDescribeBinaryUnmarshaler(t *testing.T) {
+	vars, err := New("APP", "_").(Describer).Describe(&binaryConfig{})
+
+	if err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	names := make([]string, 0, len(vars))
+
+	for _, v := range vars {
+		names = append(names, v.Name)
+	}
+
+	if expectation := []string{"APP_KEYS", "APP_SIGNING", "APP_TOKEN"}; !reflect.DeepEqual(names, expectation) {
+		t.Logf("Expected %v got %v", expectation, names)
+		t.Fail()
+	}
+}
+
+// levels is a flag.Value accumulating comma separated levels
+type levels []string
+
+func (l *levels) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *levels) Set(value string) error {
+	if value == "" {
+		return errors.New("empty level")
+	}
+
+	*l = append(*l, strings.Split(value, ",")...)
+	return nil
+}
+
+// mode is a flag.Value restricting its values
+type mode struct {
+	name string
+}
+
+func (m *mode) String() string {
+	return m.name
+}
+
+func (m *mode) Set(value string) error {
+	if value != "fast" && value != "safe" {
+		return fmt.Errorf("unknown mode %s", value)
+	}
+
+	m.name = value
+	return nil
+}
+
+type flagValueConfig struct {
+	Levels levels
+	Mode   *mode
+}
+
+func TestLoadWithFlagValue(t *testing.T) {
+	testCases := []struct {
+		Label       string
+		Env         mapSource
+		Expectation flagValueConfig
+		Error       ErrorCode
+	}{
+		{
+			"WithValidValues",
+			mapSource{"APP_LEVELS": "info,debug", "APP_MODE": "fast"},
+			flagValueConfig{Levels: levels{"info", "debug"}, Mode: &mode{"fast"}},
+			"",
+		},
+		{"WithInvalidValue", mapSource{"APP_MODE": "groot"}, flagValueConfig{}, CodeParseFailure},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			var result flagValueConfig
+
+			err := New("APP", "_", WithSource(testCase.Env)).Load(&result)
+
+			if testCase.Error != "" {
+				if code := CodeOf(err); code != testCase.Error {
+					t.Logf("Expected code %s, got [%v]", testCase.Error, err)
+					t.Fail()
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Logf("Wasn't expecting an error, got [%v]", err)
+				t.FailNow()
+			}
+
+			if !reflect.DeepEqual(result, testCase.Expectation) {
+				t.Logf("Expected %+v got %+v", testCase.Expectation, result)
+				t.Fail()
+			}
+		})
+	}
+}
+
+// rules decodes itself from JSON, accepting a single object or a list
+type rules []rule
+
+type rule struct {
+	Path  string `json:"path"`
+	Allow bool   `json:"allow"`
+}
+
+func (r *rules) UnmarshalJSON(data []byte) error {
+	var single rule
+
+	if err := json.Unmarshal(data, &single); err == nil {
+		*r = rules{single}
+		return nil
+	}
+
+	return json.Unmarshal(data, (*[]rule)(r))
+}
+
+// limits decodes itself from a JSON object, scaling its values
+type limits struct {
+	CPU    int
+	Memory int
+}
+
+func (l *limits) UnmarshalJSON(data []byte) error {
+	var raw map[string]int
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	l.CPU, l.Memory = raw["cpu"]*1000, raw["memory"]*1024
+	return nil
+}
+
+type jsonConfig struct {
+	Rules  rules   `envconfig:"noexpand"`
+	Limits *limits `envconfig:"noexpand"`
+}
+
+func TestLoadWithJSONUnmarshaler(t *testing.T) {
+	testCases := []struct {
+		Label       string
+		Env         mapSource
+		Expectation jsonConfig
+		Error       ErrorCode
+	}{
+		{
+			"WithSingleRule",
+			mapSource{"APP_RULES": `{"path": "/", "allow": true}`, "APP_LIMITS": `{"cpu": 2, "memory": 512}`},
+			jsonConfig{Rules: rules{{"/", true}}, Limits: &limits{2000, 524288}},
+			"",
+		},
+		{
+			"WithRuleList",
+			mapSource{"APP_RULES": `[{"path": "/"}, {"path": "/admin", "allow": true}]`},
+			jsonConfig{Rules: rules{{"/", false}, {"/admin", true}}},
+			"",
+		},
+		{"WithInvalidJSON", mapSource{"APP_LIMITS": "cpu=2"}, jsonConfig{}, CodeParseFailure},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			var result jsonConfig
+
+			err := New("APP", "_", WithSource(testCase.Env)).Load(&result)
+
+			if testCase.Error != "" {
+				if code := CodeOf(err); code != testCase.Error {
+					t.Logf("Expected code %s, got [%v]", testCase.Error, err)
+					t.Fail()
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Logf("Wasn't expecting an error, got [%v]", err)
+				t.FailNow()
+			}
+
+			if !reflect.DeepEqual(result, testCase.Expectation) {
+				t.Logf("Expected %+v got %+v", testCase.Expectation, result)
+				t.Fail()
+			}
+		})
+	}
+}
+
+// decoder is implemented by types decoding themselves from a string
+type decoder interface {
+	Decode(value string) error
+}
+
+// color decodes itself from a #rrggbb value
+type color struct {
+	R, G, B uint8
+}
+
+func (c *color) Decode(value string) error {
+	_, err := fmt.Sscanf(value, "#%02x%02x%02x", &c.R, &c.G, &c.B)
+	return err
+}
+
+// labels decodes itself from a k=v;k=v value, it isn't expanded
+type labels map[string]string
+
+func (l *labels) Decode(value string) error {
+	*l = labels{}
+
+	for _, pair := range strings.Split(value, ";") {
+		k, v, _ := strings.Cut(pair, "=")
+		(*l)[k] = v
+	}
+
+	return nil
+}
+
+type decoderConfig struct {
+	Background color
+	Foreground *color
+	Labels     labels
+}
+
+func TestLoadWithInterfaceSetter(t *testing.T) {
+	setters := setter.LoadBasicTypes()
+	setters[reflect.TypeOf((*decoder)(nil)).Elem()] = setter.SetterFunc(func(value string, val reflect.Value) error {
+		return val.Addr().Interface().(decoder).Decode(value)
+	})
+
+	source := mapSource{
+		"APP_BACKGROUND": "#ff8000",
+		"APP_FOREGROUND": "#000000",
+		"APP_LABELS":     "team=core;tier=1",
+	}
+
+	var result decoderConfig
+
+	if err := NewWithSettersAndDepth("APP", "_", setters, DefaultDepth, WithSource(source)).Load(&result); err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	expectation := decoderConfig{
+		Background: color{255, 128, 0},
+		Foreground: &color{},
+		Labels:     labels{"team": "core", "tier": "1"},
+	}
+
+	if !reflect.DeepEqual(result, expectation) {
+		t.Logf("Expected %+v got %+v", expectation, result)
+		t.Fail()
+	}
+}
@@ -0,0 +1,30 @@
+package envconfig
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// WithSkipUnsupported makes the loader skip fields it can't assign instead of
+// failing the load, letting configuration structs embed runtime types which
+// aren't configurable, like funcs, channels or types lacking a setter.
+// Each skipped field is reported as a warning in the Report.
+func WithSkipUnsupported() Option {
+	return func(e *envConfig) {
+		e.skipUnsupported = true
+	}
+}
+
+// unsupportedTypeError is returned when no setter is registered for a type
+type unsupportedTypeError struct {
+	valType reflect.Type
+}
+
+func (u *unsupportedTypeError) Error() string {
+	return fmt.Sprintf("Unsupported type [%s], please consider adding custom setter", u.valType.String())
+}
+
+// Code returns CodeUnsupportedType
+func (u *unsupportedTypeError) Code() ErrorCode {
+	return CodeUnsupportedType
+}
@@ -0,0 +1,64 @@
+package envconfig
+
+import (
+	"testing"
+)
+
+type opaque uintptr
+
+type runtimeConfig struct {
+	StringValue string
+	Handler     func()
+	Events      chan string
+	Lateralizer Yoloer
+	Opaque      opaque
+}
+
+func TestLoadWithSkipUnsupported(t *testing.T) {
+	env := map[string]string{
+		"APP_STRING_VALUE": "FOO",
+		"APP_OPAQUE":       "42",
+	}
+
+	setupEnv(env)
+	defer cleanupEnv(env)
+
+	if err := New("APP", "_").Load(&runtimeConfig{}); err == nil {
+		t.Log("Expected an error without WithSkipUnsupported, got nothing")
+		t.Fail()
+	}
+
+	var report *Report
+
+	result := runtimeConfig{}
+	subject := New("APP", "_", WithSkipUnsupported(), WithReport(func(r *Report) { report = r }))
+
+	if err := subject.Load(&result); err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	if result.StringValue != "FOO" {
+		t.Logf("Expected FOO, got %s", result.StringValue)
+		t.Fail()
+	}
+
+	expectation := []string{
+		"Skipped field [Handler]: type func() is not supported",
+		"Skipped field [Events]: type chan string is not supported",
+		"Skipped field [Lateralizer]: type envconfig.Yoloer is not supported",
+		"Skipped variable [APP_OPAQUE]: Unsupported type [envconfig.opaque], please consider adding custom setter",
+	}
+
+	if len(report.Warnings) != len(expectation) {
+		t.Logf("Expected warnings %v, got %v", expectation, report.Warnings)
+		t.FailNow()
+	}
+
+	for i, warning := range expectation {
+		if report.Warnings[i] != warning {
+			t.Logf("Expected warning [%s], got [%s]", warning, report.Warnings[i])
+			t.Fail()
+		}
+	}
+}
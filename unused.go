@@ -0,0 +1,130 @@
+package envconfig
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/jlevesy/envconfig/sources"
+)
+
+// WithStrict makes loads fail with CodeUnknownVariable when variables defined
+// under the prefix don't map to any field, guaranteeing every deployed
+// variable is actually consumed. See Report.Unused.
+func WithStrict() Option {
+	return func(e *envConfig) {
+		e.strict = true
+	}
+}
+
+// checkUnused records unused variables into state. Failing to list them, or
+// finding some, only fails the load in strict mode.
+func (e *envConfig) checkUnused(ctx context.Context, layers []layer, configType reflect.Type, state *loadState) error {
+	unused, err := e.unusedVariables(ctx, layers, configType, state)
+
+	if err != nil {
+		if e.strict {
+			return err
+		}
+
+		state.warnings = append(state.warnings, fmt.Sprintf("Failed to list unused variables: %v", err))
+
+		return nil
+	}
+
+	state.unused = unused
+
+	if e.strict && len(unused) > 0 {
+		return withCode(
+			CodeUnknownVariable,
+			fmt.Errorf("Variables don't match any field: [%s]", strings.Join(unused, ", ")),
+		)
+	}
+
+	return nil
+}
+
+// unusedVariables lists variables defined by given layers under the prefix,
+// which don't map to any value of the configuration struct, like typos.
+// Variables named by requiredIf conditions, and the include variable, are
+// consumed too. Loaders without prefix have no unused variable: every
+// variable of the environment would be listed.
+func (e *envConfig) unusedVariables(ctx context.Context, layers []layer, configType reflect.Type, state *loadState) ([]string, error) {
+	root := e.envVarFromPath(path{})
+
+	if root == "" {
+		return nil, nil
+	}
+
+	known := map[string]struct{}{}
+
+	for name := range state.matched {
+		known[name] = struct{}{}
+	}
+
+	// Lazy fields aren't looked up while loading
+	if vars, err := e.Describe(reflect.New(configType).Interface()); err == nil {
+		for _, v := range vars {
+			known[v.Name] = struct{}{}
+		}
+	}
+
+	if e.openInclude != nil {
+		known[e.envVarFromPath(path{"Include"})] = struct{}{}
+	}
+
+	e.conditionVariables(configType, known, 0)
+
+	unused := map[string]struct{}{}
+
+	for _, l := range layers {
+		keys, err := sources.WithContext(ctx, l.source).Keys(root + e.separator)
+
+		if err != nil {
+			return nil, sourceError(err)
+		}
+
+		for _, key := range keys {
+			if _, ok := known[key]; !ok {
+				unused[key] = struct{}{}
+			}
+		}
+	}
+
+	res := make([]string, 0, len(unused))
+
+	for key := range unused {
+		res = append(res, key)
+	}
+
+	sort.Strings(res)
+
+	return res, nil
+}
+
+// conditionVariables adds references of requiredIf tags found in given type
+// to known. References to fields are added too, which is harmless.
+func (e *envConfig) conditionVariables(valType reflect.Type, known map[string]struct{}, depth int) {
+	valType = indirectedType(valType)
+
+	if depth > e.maxDepth {
+		return
+	}
+
+	switch valType.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		e.conditionVariables(valType.Elem(), known, depth+1)
+	case reflect.Struct:
+		for i := 0; i < valType.NumField(); i++ {
+			field := valType.Field(i)
+
+			if ref, ok := field.Tag.Lookup(requiredIfTag); ok {
+				known[ref] = struct{}{}
+			}
+
+			e.conditionVariables(field.Type, known, depth+1)
+		}
+	}
+}
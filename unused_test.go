@@ -0,0 +1,80 @@
+package envconfig
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type unusedConfig struct {
+	Timeout time.Duration
+	Ports   []int
+	Token   Lazy[string]
+	Cert    string `requiredIf:"APP_TLS"`
+}
+
+func TestReportUnusedVariables(t *testing.T) {
+	testCases := []struct {
+		Label       string
+		Prefix      string
+		Env         mapSource
+		Expectation []string
+	}{
+		{
+			"WithTypos",
+			"APP",
+			mapSource{
+				"APP_TIMEOUT":  "5s",
+				"APP_TIMEOUTT": "10s",
+				"APP_PORTS_0":  "80",
+				"APP_PORT":     "8080",
+				"APP_TOKEN":    "secret",
+				"APP_TLS":      "false",
+				"OTHER_VAR":    "foo",
+			},
+			[]string{"APP_PORT", "APP_TIMEOUTT"},
+		},
+		{"WithoutUnusedVariables", "APP", mapSource{"APP_TIMEOUT": "5s"}, []string{}},
+		{"WithoutPrefix", "", mapSource{"TIMEOUT": "5s", "OTHER_VAR": "foo"}, nil},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			var report *Report
+
+			loader := New(testCase.Prefix, "_", WithSource(testCase.Env), WithReport(func(r *Report) { report = r }))
+
+			if err := loader.Load(&unusedConfig{}); err != nil {
+				t.Logf("Wasn't expecting an error, got [%v]", err)
+				t.FailNow()
+			}
+
+			if !reflect.DeepEqual(report.Unused, testCase.Expectation) {
+				t.Logf("Expected unused variables %v, got %v", testCase.Expectation, report.Unused)
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestLoadWithStrict(t *testing.T) {
+	testCases := []struct {
+		Label string
+		Env   mapSource
+		Error ErrorCode
+	}{
+		{"WithKnownVariables", mapSource{"APP_TIMEOUT": "5s", "APP_PORTS_0": "80", "OTHER_VAR": "foo"}, ""},
+		{"WithUnknownVariable", mapSource{"APP_TIMEOUTT": "5s"}, CodeUnknownVariable},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			err := New("APP", "_", WithSource(testCase.Env), WithStrict()).Load(&unusedConfig{})
+
+			if code := CodeOf(err); code != testCase.Error {
+				t.Logf("Expected code %q, got [%v]", testCase.Error, err)
+				t.Fail()
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package envconfig
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// UsagePrinter is implemented by loaders able to print the variables a
+// configuration struct is loaded from, like loaders returned by New.
+type UsagePrinter interface {
+	Usage(w io.Writer, config interface{}) error
+}
+
+// Usage writes the variables given configuration struct is loaded from to w,
+// the way flag.PrintDefaults does for flags: each variable along with its type,
+// followed by its description, whether it is required, and its default value.
+// Defaults of secret fields are masked. It suits --help output of CLIs
+// configured by their environment.
+func (e *envConfig) Usage(w io.Writer, config interface{}) error {
+	vars, err := e.Describe(config)
+
+	if err != nil {
+		return err
+	}
+
+	for _, v := range vars {
+		if _, err := fmt.Fprintf(w, "  %s %v\n", v.Name, v.Type); err != nil {
+			return err
+		}
+
+		if line := usageLine(v); line != "" {
+			if _, err := fmt.Fprintf(w, "    \t%s\n", line); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// usageLine describes given variable on a single line
+func usageLine(v VarInfo) string {
+	details := []string{}
+
+	if description := strings.Join(strings.Fields(v.Description), " "); description != "" {
+		details = append(details, description)
+	}
+
+	if v.Required {
+		details = append(details, "(required)")
+	}
+
+	switch {
+	case v.HasDefault && v.Secret:
+		details = append(details, "(default "+redacted+")")
+	case v.HasDefault:
+		details = append(details, fmt.Sprintf("(default %q)", v.Default))
+	}
+
+	return strings.Join(details, " ")
+}
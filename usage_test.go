@@ -0,0 +1,47 @@
+package envconfig
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+type usageConfig struct {
+	Database struct {
+		Host string `envconfig:"required" desc:"Database host"`
+	}
+	Timeout time.Duration `envconfig:"default=5s" desc:"Request timeout"`
+	Token   string        `envconfig:"default=changeme" secret:"true"`
+	Debug   bool
+}
+
+func TestUsage(t *testing.T) {
+	var buf bytes.Buffer
+
+	if err := New("APP", "_").(UsagePrinter).Usage(&buf, &usageConfig{}); err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.FailNow()
+	}
+
+	expectation := "  APP_DATABASE_HOST string\n" +
+		"    \tDatabase host (required)\n" +
+		"  APP_TIMEOUT time.Duration\n" +
+		"    \tRequest timeout (default \"5s\")\n" +
+		"  APP_TOKEN string\n" +
+		"    \t(default ******)\n" +
+		"  APP_DEBUG bool\n"
+
+	if result := buf.String(); result != expectation {
+		t.Logf("Expected:\n%s\ngot:\n%s", expectation, result)
+		t.Fail()
+	}
+}
+
+func TestUsageInvalidConfig(t *testing.T) {
+	var buf bytes.Buffer
+
+	if err := New("APP", "_").(UsagePrinter).Usage(&buf, usageConfig{}); err == nil {
+		t.Log("Expected an error, got nothing")
+		t.Fail()
+	}
+}
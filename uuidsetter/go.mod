@@ -0,0 +1,12 @@
+module github.com/jlevesy/envconfig/uuidsetter
+
+go 1.19
+
+require (
+	github.com/google/uuid v1.6.0
+	github.com/jlevesy/envconfig v0.0.0
+)
+
+require github.com/fatih/camelcase v1.0.0 // indirect
+
+replace github.com/jlevesy/envconfig => ../
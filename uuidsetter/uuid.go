@@ -0,0 +1,30 @@
+// Package uuidsetter provides a setter for github.com/google/uuid UUIDs, kept
+// out of the core packages so they don't depend on it.
+package uuidsetter
+
+import (
+	"reflect"
+
+	"github.com/google/uuid"
+
+	"github.com/jlevesy/envconfig"
+	"github.com/jlevesy/envconfig/setter"
+)
+
+// Type is the type of uuid.UUID, the key Setter is registered with
+var Type = reflect.TypeOf(uuid.UUID{})
+
+// Setter returns a Setter parsing UUIDs in their canonical form, like
+// "f47ac10b-58cc-4372-a567-0e02b2c3d479", as well as the other forms
+// uuid.Parse accepts.
+// It takes precedence over the UnmarshalBinary method of uuid.UUID, which
+// expects raw bytes.
+func Setter() setter.Setter {
+	return setter.Of(uuid.Parse)
+}
+
+// Register registers Setter on given loader, for uuid.UUID fields and
+// pointers to them.
+func Register(loader envconfig.SetterRegistry) {
+	loader.RegisterSetter(Type, Setter())
+}
@@ -0,0 +1,66 @@
+package uuidsetter
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/jlevesy/envconfig"
+	"github.com/jlevesy/envconfig/sources"
+)
+
+type appConfig struct {
+	InstanceID uuid.UUID
+	TenantID   *uuid.UUID
+}
+
+func TestSetter(t *testing.T) {
+	testCases := []struct {
+		Label        string
+		Value        string
+		ExpectsError bool
+	}{
+		{"WithCanonicalUUID", "f47ac10b-58cc-4372-a567-0e02b2c3d479", false},
+		{"WithInvalidUUID", "f47ac10b", true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			var result appConfig
+
+			loader := envconfig.New(
+				"APP",
+				"_",
+				envconfig.WithSource(sources.Map(map[string]string{
+					"APP_INSTANCE_ID": testCase.Value,
+					"APP_TENANT_ID":   testCase.Value,
+				})),
+			)
+
+			Register(loader.(envconfig.SetterRegistry))
+
+			err := loader.Load(&result)
+
+			if testCase.ExpectsError {
+				if envconfig.CodeOf(err) != envconfig.CodeParseFailure {
+					t.Logf("Expected a parse failure, got [%v]", err)
+					t.Fail()
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Logf("Wasn't expecting an error, got [%v]", err)
+				t.FailNow()
+			}
+
+			expected := uuid.MustParse(testCase.Value)
+
+			if result.InstanceID != expected || result.TenantID == nil || *result.TenantID != expected {
+				t.Logf("Expected %v, got %v and %v", expected, result.InstanceID, result.TenantID)
+				t.Fail()
+			}
+		})
+	}
+}
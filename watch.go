@@ -0,0 +1,101 @@
+package envconfig
+
+import (
+	"context"
+	"errors"
+	"reflect"
+
+	"github.com/jlevesy/envconfig/sources"
+)
+
+// ErrNotWatchable is returned by Watch when none of the loader sources is able to
+// report changes.
+var ErrNotWatchable = errors.New("None of the loader sources is able to report changes")
+
+// Watcher is implemented by loaders able to reload a configuration when their
+// sources change, like loaders returned by New.
+type Watcher interface {
+	Watch(ctx context.Context, config interface{}, onReload func(config interface{}, err error)) error
+}
+
+// Watch loads given configuration structure, then reloads configuration each time
+// one of the loader sources reports a change, until ctx is done. Sources are
+// watched through their decorators, see sources.AsNotifier, once the initial
+// load succeeded.
+// Reloads are performed into a fresh instance of config's type, which is passed
+// to onReload along with the reload error. After a successful reload, rotation
+// callbacks of changed secret fields are called before onReload.
+func (e *envConfig) Watch(ctx context.Context, config interface{}, onReload func(config interface{}, err error)) error {
+	notifiers := e.notifiers()
+
+	if len(notifiers) == 0 {
+		return ErrNotWatchable
+	}
+
+	if err := e.LoadContext(ctx, config); err != nil {
+		return err
+	}
+
+	configType := reflect.TypeOf(config).Elem()
+
+	if err := e.checkRotationCallbacks(configType); err != nil {
+		return err
+	}
+
+	changes := watchSources(ctx, notifiers)
+	current := config
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-changes:
+			reloaded := reflect.New(configType).Interface()
+			err := e.LoadContext(ctx, reloaded)
+
+			if err == nil {
+				e.notifyRotations(current, reloaded)
+				current = reloaded
+			}
+
+			onReload(reloaded, err)
+		}
+	}
+}
+
+// notifiers returns sources of the loader reporting changes
+func (e *envConfig) notifiers() []sources.Notifier {
+	var res []sources.Notifier
+
+	for _, l := range e.sourceLayers() {
+		if notifier, ok := sources.AsNotifier(l.source); ok {
+			res = append(res, notifier)
+		}
+	}
+
+	return res
+}
+
+// watchSources merges changes reported by given notifiers into a single
+// channel, until ctx is done.
+func watchSources(ctx context.Context, notifiers []sources.Notifier) <-chan struct{} {
+	changes := make(chan struct{}, 1)
+
+	for _, notifier := range notifiers {
+		go func(notifier sources.Notifier) {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-notifier.Changes():
+					select {
+					case changes <- struct{}{}:
+					default:
+					}
+				}
+			}
+		}(notifier)
+	}
+
+	return changes
+}
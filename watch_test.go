@@ -0,0 +1,218 @@
+package envconfig
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jlevesy/envconfig/sources"
+)
+
+// notifyingSource is a mapSource reporting changes on demand
+type notifyingSource struct {
+	mapSource
+	changes chan struct{}
+}
+
+func (n *notifyingSource) Changes() <-chan struct{} {
+	return n.changes
+}
+
+func TestWatch(t *testing.T) {
+	source := &notifyingSource{
+		mapSource: mapSource{"APP_STRING_VALUE": "FOO"},
+		changes:   make(chan struct{}),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	reloads := make(chan *basicAppConfig)
+	done := make(chan error)
+
+	config := &basicAppConfig{}
+	subject := New("APP", "_", WithSource(source)).(Watcher)
+
+	go func() {
+		done <- subject.Watch(ctx, config, func(reloaded interface{}, err error) {
+			if err != nil {
+				t.Logf("Wasn't expecting an error, got [%v]", err)
+				t.Fail()
+			}
+
+			reloads <- reloaded.(*basicAppConfig)
+		})
+	}()
+
+	source.changes <- struct{}{}
+	<-reloads
+
+	if config.StringValue != "FOO" {
+		t.Logf("Expected initial load to set [FOO] got [%s]", config.StringValue)
+		t.Fail()
+	}
+
+	source.mapSource["APP_STRING_VALUE"] = "BAR"
+	source.changes <- struct{}{}
+
+	if reloaded := <-reloads; reloaded.StringValue != "BAR" {
+		t.Logf("Expected reload to set [BAR] got [%s]", reloaded.StringValue)
+		t.Fail()
+	}
+
+	cancel()
+
+	if err := <-done; err != nil {
+		t.Logf("Wasn't expecting an error, got [%v]", err)
+		t.Fail()
+	}
+}
+
+func TestWatchNotWatchable(t *testing.T) {
+	err := New("APP", "_").(Watcher).Watch(context.Background(), &basicAppConfig{}, nil)
+
+	if err != ErrNotWatchable {
+		t.Logf("Expected [%v] got [%v]", ErrNotWatchable, err)
+		t.Fail()
+	}
+}
+
+type rotatingConfig struct {
+	Database *struct {
+		Host     string
+		Password string `secret:"true"`
+	}
+}
+
+func TestWatchRotationCallbacks(t *testing.T) {
+	source := &notifyingSource{
+		mapSource: mapSource{
+			"APP_DATABASE_HOST":     "localhost",
+			"APP_DATABASE_PASSWORD": "FOO",
+		},
+		changes: make(chan struct{}),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reloads := make(chan struct{})
+	rotations := make(chan SecretRotation, 2)
+
+	subject := New(
+		"APP",
+		"_",
+		WithSource(source),
+		WithRotationCallback("Database.Password", func(r SecretRotation) {
+			rotations <- r
+		}),
+	)
+
+	go subject.(Watcher).Watch(ctx, &rotatingConfig{}, func(interface{}, error) {
+		reloads <- struct{}{}
+	})
+
+	// Reload without change on the secret
+	source.changes <- struct{}{}
+	<-reloads
+
+	source.mapSource["APP_DATABASE_PASSWORD"] = "BAR"
+	source.changes <- struct{}{}
+	<-reloads
+
+	cancel()
+
+	if len(rotations) != 1 {
+		t.Logf("Expected 1 rotation, got %d", len(rotations))
+		t.FailNow()
+	}
+
+	rotation := <-rotations
+
+	if rotation.Path != "Database.Password" || rotation.Old != "FOO" || rotation.New != "BAR" {
+		t.Logf("Unexpected rotation %v", rotation)
+		t.Fail()
+	}
+}
+
+func TestWatchRotationCallbackOnNonSecretField(t *testing.T) {
+	source := &notifyingSource{mapSource: mapSource{}, changes: make(chan struct{})}
+
+	err := New(
+		"APP",
+		"_",
+		WithSource(source),
+		WithRotationCallback("Database.Host", func(SecretRotation) {}),
+	).(Watcher).Watch(context.Background(), &rotatingConfig{}, nil)
+
+	if err == nil {
+		t.Log("Expected an error, got nothing")
+		t.Fail()
+	}
+}
+
+func TestWatchThroughDecorators(t *testing.T) {
+	source := &notifyingSource{
+		mapSource: mapSource{"APP_STRING_VALUE": "FOO"},
+		changes:   make(chan struct{}),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reloads := make(chan *basicAppConfig)
+	subject := New("APP", "_", WithSource(sources.Breaker(sources.Retry(source)))).(Watcher)
+
+	go subject.Watch(ctx, &basicAppConfig{}, func(reloaded interface{}, err error) {
+		reloads <- reloaded.(*basicAppConfig)
+	})
+
+	// Changes are watched once the initial load is done, so the source can be
+	// updated after this first notification is handled.
+	source.changes <- struct{}{}
+
+	if reloaded := <-reloads; reloaded.StringValue != "FOO" {
+		t.Logf("Expected reload to set [FOO] got [%s]", reloaded.StringValue)
+		t.FailNow()
+	}
+
+	source.mapSource["APP_STRING_VALUE"] = "BAR"
+
+	select {
+	case reloaded := <-reloads:
+		t.Logf("Wasn't expecting a reload without notification, got %+v", reloaded)
+		t.FailNow()
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	source.changes <- struct{}{}
+
+	if reloaded := <-reloads; reloaded.StringValue != "BAR" {
+		t.Logf("Expected reload to set [BAR] got [%s]", reloaded.StringValue)
+		t.Fail()
+	}
+}
+
+func TestWatchWithFailedInitialLoad(t *testing.T) {
+	source := &notifyingSource{
+		mapSource: mapSource{"APP_PORT": "groot"},
+		changes:   make(chan struct{}),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var config struct {
+		Port int
+	}
+
+	if err := New("APP", "_", WithSource(source)).(Watcher).Watch(ctx, &config, nil); err == nil {
+		t.Log("Expected an error, got nothing")
+		t.FailNow()
+	}
+
+	select {
+	case source.changes <- struct{}{}:
+		t.Log("Wasn't expecting changes to be watched after a failed load")
+		t.Fail()
+	case <-time.After(50 * time.Millisecond):
+	}
+}
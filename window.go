@@ -0,0 +1,7 @@
+package envconfig
+
+import "github.com/jlevesy/envconfig/setter"
+
+// Window is a field type holding a time window, like a maintenance window
+// "22:00-06:00", or a band of durations like "5m-1h". See setter.Window.
+type Window = setter.Window
@@ -0,0 +1,100 @@
+package envconfig
+
+import (
+	"testing"
+	"time"
+)
+
+type windowConfig struct {
+	Maintenance Window
+}
+
+func TestLoadWindow(t *testing.T) {
+	testCases := []struct {
+		Label        string
+		Value        string
+		Expectation  Window
+		ExpectsError bool
+	}{
+		{"WithTimesOfDay", "09:00-17:30", Window{Start: 9 * time.Hour, End: 17*time.Hour + 30*time.Minute}, false},
+		{"WithMidnight", "22:00-24:00", Window{Start: 22 * time.Hour, End: 24 * time.Hour}, false},
+		{"OverMidnight", "22:00-06:00", Window{Start: 22 * time.Hour, End: 6 * time.Hour}, false},
+		{"WithDurations", "5m-1h", Window{Start: 5 * time.Minute, End: time.Hour}, false},
+		{"WithReversedDurations", "1h-5m", Window{}, true},
+		{"WithMixedBounds", "09:00-1h", Window{}, true},
+		{"WithInvalidTime", "25:00-26:00", Window{}, true},
+		{"WithoutEnd", "09:00", Window{}, true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			var result windowConfig
+
+			err := New("APP", "_", WithSource(mapSource{"APP_MAINTENANCE": testCase.Value})).Load(&result)
+
+			if testCase.ExpectsError {
+				if err == nil {
+					t.Log("Expected an error, got nothing")
+					t.Fail()
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Logf("Wasn't expecting an error, got [%v]", err)
+				t.FailNow()
+			}
+
+			if result.Maintenance != testCase.Expectation {
+				t.Logf("Expected %v, got %v", testCase.Expectation, result.Maintenance)
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestWindowString(t *testing.T) {
+	for _, value := range []string{"09:00-17:00", "22:00-06:00", "5m-1h"} {
+		var loaded, reloaded windowConfig
+
+		if err := New("APP", "_", WithSource(mapSource{"APP_MAINTENANCE": value})).Load(&loaded); err != nil {
+			t.Logf("Wasn't expecting an error, got [%v]", err)
+			t.FailNow()
+		}
+
+		if err := New("APP", "_", WithSource(mapSource{"APP_MAINTENANCE": loaded.Maintenance.String()})).Load(&reloaded); err != nil {
+			t.Logf("Wasn't expecting an error, got [%v]", err)
+			t.FailNow()
+		}
+
+		if reloaded != loaded {
+			t.Logf("Expected %v to be parsed back, got %v", loaded, reloaded)
+			t.Fail()
+		}
+	}
+}
+
+func TestWindowContains(t *testing.T) {
+	testCases := []struct {
+		Label       string
+		Window      Window
+		Time        time.Time
+		Expectation bool
+	}{
+		{"Within", Window{Start: 9 * time.Hour, End: 17 * time.Hour}, time.Date(2019, 1, 1, 12, 0, 0, 0, time.UTC), true},
+		{"Outside", Window{Start: 9 * time.Hour, End: 17 * time.Hour}, time.Date(2019, 1, 1, 18, 0, 0, 0, time.UTC), false},
+		{"OverMidnightLate", Window{Start: 22 * time.Hour, End: 6 * time.Hour}, time.Date(2019, 1, 1, 23, 0, 0, 0, time.UTC), true},
+		{"OverMidnightEarly", Window{Start: 22 * time.Hour, End: 6 * time.Hour}, time.Date(2019, 1, 1, 5, 59, 0, 0, time.UTC), true},
+		{"OverMidnightOutside", Window{Start: 22 * time.Hour, End: 6 * time.Hour}, time.Date(2019, 1, 1, 12, 0, 0, 0, time.UTC), false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Label, func(t *testing.T) {
+			if result := testCase.Window.ContainsTime(testCase.Time); result != testCase.Expectation {
+				t.Logf("Expected %t, got %t", testCase.Expectation, result)
+				t.Fail()
+			}
+		})
+	}
+}
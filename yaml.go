@@ -0,0 +1,518 @@
+package envconfig
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// yamlMapping is a YAML mapping, entries are kept in document order
+type yamlMapping []yamlEntry
+
+type yamlEntry struct {
+	Key   string
+	Value interface{}
+}
+
+type yamlLine struct {
+	Number int
+	Indent int
+	Text   string
+}
+
+// parseYAML parses given YAML document into a tree made of yamlMapping,
+// []interface{}, string and nil nodes.
+// Only a subset of YAML is supported: block mappings and sequences, flow
+// sequences of scalars, plain and quoted scalars, literal (|) and folded (>)
+// block scalars, and comments. Anchors, tags and multiple documents are not.
+// Like YAML requires, blocks are indented using spaces, tabs are only allowed
+// in values and as separators.
+func parseYAML(doc string) (interface{}, error) {
+	p := &yamlParser{}
+
+	for i, raw := range strings.Split(doc, "\n") {
+		trimmed := strings.TrimLeft(raw, " ")
+		text := strings.TrimRight(trimmed, " \t\r")
+
+		// Lines holding only whitespace are blank, even within block scalars
+		if strings.TrimLeft(text, "\t") == "" {
+			text = ""
+		}
+
+		p.lines = append(p.lines, yamlLine{
+			Number: i + 1,
+			Indent: len(raw) - len(trimmed),
+			Text:   text,
+		})
+	}
+
+	p.skipBlank()
+
+	if err := p.indentError(); err != nil {
+		return nil, err
+	}
+
+	if !p.eof() && p.current().Text == "---" {
+		p.pos++
+		p.skipBlank()
+	}
+
+	if p.eof() {
+		return nil, nil
+	}
+
+	node, err := p.parseNode(p.current().Indent)
+
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipBlank()
+
+	if err := p.indentError(); err != nil {
+		return nil, err
+	}
+
+	if !p.eof() {
+		return nil, p.errorf("unexpected content")
+	}
+
+	return node, nil
+}
+
+type yamlParser struct {
+	lines []yamlLine
+	pos   int
+}
+
+func (p *yamlParser) errorf(format string, args ...interface{}) error {
+	line := len(p.lines)
+
+	if !p.eof() {
+		line = p.current().Number
+	}
+
+	return fmt.Errorf("Invalid YAML at line %d: %s", line, fmt.Sprintf(format, args...))
+}
+
+func (p *yamlParser) eof() bool {
+	return p.pos >= len(p.lines)
+}
+
+func (p *yamlParser) current() yamlLine {
+	return p.lines[p.pos]
+}
+
+// indentError returns an error if current line is indented using tabs
+func (p *yamlParser) indentError() error {
+	if !p.eof() && strings.HasPrefix(p.current().Text, "\t") {
+		return p.errorf("tabs can't be used for indentation")
+	}
+
+	return nil
+}
+
+// skipBlank moves forward to the next line holding something else than a comment
+func (p *yamlParser) skipBlank() {
+	for !p.eof() {
+		if text := stripYAMLComment(p.current().Text); text != "" {
+			return
+		}
+
+		p.pos++
+	}
+}
+
+// parseNode parses the block starting at current line, which is indented by
+// given indent.
+func (p *yamlParser) parseNode(indent int) (interface{}, error) {
+	if isYAMLSequenceItem(stripYAMLComment(p.current().Text)) {
+		return p.parseSequence(indent)
+	}
+
+	return p.parseMapping(indent)
+}
+
+func (p *yamlParser) parseSequence(indent int) (interface{}, error) {
+	res := []interface{}{}
+
+	for p.skipBlank(); !p.eof() && p.current().Indent == indent; p.skipBlank() {
+		if err := p.indentError(); err != nil {
+			return nil, err
+		}
+
+		line := p.current()
+		text := stripYAMLComment(line.Text)
+
+		// Sequences nested at the same indentation than their key end there
+		if !isYAMLSequenceItem(text) {
+			break
+		}
+
+		content := strings.TrimLeft(text[1:], " \t")
+
+		if content == "" {
+			p.pos++
+			item, err := p.parseChild(indent)
+
+			if err != nil {
+				return nil, err
+			}
+
+			res = append(res, item)
+			continue
+		}
+
+		// Item content is a block of its own, indented after the dash
+		p.lines[p.pos] = yamlLine{
+			Number: line.Number,
+			Indent: line.Indent + len(line.Text) - len(strings.TrimLeft(line.Text[1:], " \t")),
+			Text:   strings.TrimLeft(line.Text[1:], " \t"),
+		}
+
+		if isYAMLSequenceItem(content) || isYAMLMappingEntry(content) {
+			item, err := p.parseNode(p.current().Indent)
+
+			if err != nil {
+				return nil, err
+			}
+
+			res = append(res, item)
+			continue
+		}
+
+		item, err := p.parseScalar(content, indent)
+
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, item)
+	}
+
+	return res, nil
+}
+
+func (p *yamlParser) parseMapping(indent int) (interface{}, error) {
+	res := yamlMapping{}
+
+	for p.skipBlank(); !p.eof() && p.current().Indent == indent; p.skipBlank() {
+		if err := p.indentError(); err != nil {
+			return nil, err
+		}
+
+		text := stripYAMLComment(p.current().Text)
+
+		if !isYAMLMappingEntry(text) {
+			return nil, p.errorf("expected a mapping entry")
+		}
+
+		key, value := splitYAMLEntry(text)
+		key, err := unquoteYAML(key)
+
+		if err != nil {
+			return nil, p.errorf("%v", err)
+		}
+
+		var node interface{}
+
+		if value == "" {
+			p.pos++
+			node, err = p.parseChild(indent)
+		} else {
+			node, err = p.parseScalar(value, indent)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, yamlEntry{key, node})
+	}
+
+	return res, nil
+}
+
+// parseChild parses the block nested under a key or a dash indented by given
+// indent. Sequences are allowed to be at the same indentation than their key.
+func (p *yamlParser) parseChild(indent int) (interface{}, error) {
+	p.skipBlank()
+
+	if p.eof() {
+		return nil, nil
+	}
+
+	if err := p.indentError(); err != nil {
+		return nil, err
+	}
+
+	line := p.current()
+
+	if line.Indent > indent ||
+		(line.Indent == indent && isYAMLSequenceItem(stripYAMLComment(line.Text))) {
+		return p.parseNode(line.Indent)
+	}
+
+	return nil, nil
+}
+
+// parseScalar parses given inline value of current line, then moves to next line.
+func (p *yamlParser) parseScalar(value string, indent int) (interface{}, error) {
+	p.pos++
+
+	switch {
+	case value == "~" || value == "null":
+		return nil, nil
+	case value[0] == '|' || value[0] == '>':
+		return p.parseBlockScalar(value, indent), nil
+	case value[0] == '[':
+		return parseYAMLFlowSequence(value)
+	case value[0] == '{':
+		return nil, fmt.Errorf("Invalid YAML at line %d: flow mappings are not supported", p.lines[p.pos-1].Number)
+	}
+
+	res, err := unquoteYAML(value)
+
+	if err != nil {
+		return nil, fmt.Errorf("Invalid YAML at line %d: %v", p.lines[p.pos-1].Number, err)
+	}
+
+	return res, nil
+}
+
+// parseBlockScalar collects lines indented deeper than given indent.
+func (p *yamlParser) parseBlockScalar(header string, indent int) string {
+	var (
+		lines       []string
+		blockIndent = -1
+	)
+
+	for ; !p.eof(); p.pos++ {
+		line := p.current()
+
+		if line.Text == "" {
+			lines = append(lines, "")
+			continue
+		}
+
+		if line.Indent <= indent {
+			break
+		}
+
+		if blockIndent < 0 {
+			blockIndent = line.Indent
+		}
+
+		lines = append(lines, strings.Repeat(" ", line.Indent-blockIndent)+line.Text)
+	}
+
+	separator := "\n"
+
+	if header[0] == '>' {
+		separator = " "
+	}
+
+	res := strings.TrimRight(strings.Join(lines, separator), separator)
+
+	switch {
+	case strings.HasSuffix(header, "-"):
+		return res
+	case strings.HasSuffix(header, "+"):
+		return strings.Join(lines, separator) + "\n"
+	default:
+		return res + "\n"
+	}
+}
+
+func parseYAMLFlowSequence(value string) (interface{}, error) {
+	if !strings.HasSuffix(value, "]") {
+		return nil, errors.New("Invalid YAML: unterminated flow sequence")
+	}
+
+	res := []interface{}{}
+	content := strings.TrimSpace(value[1 : len(value)-1])
+
+	if content == "" {
+		return res, nil
+	}
+
+	for _, item := range strings.Split(content, ",") {
+		v, err := unquoteYAML(strings.TrimSpace(item))
+
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, v)
+	}
+
+	return res, nil
+}
+
+func isYAMLSequenceItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ") || strings.HasPrefix(text, "-\t")
+}
+
+func isYAMLMappingEntry(text string) bool {
+	key, _ := splitYAMLEntry(text)
+	return key != text
+}
+
+// splitYAMLEntry splits a mapping entry into its key and value.
+// If text isn't a mapping entry, key is the whole text.
+func splitYAMLEntry(text string) (string, string) {
+	var quote byte
+
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case (c == '"' || c == '\'') && i == 0:
+			quote = c
+		case c == ':' && (i == len(text)-1 || text[i+1] == ' ' || text[i+1] == '\t'):
+			return strings.TrimSpace(text[:i]), strings.TrimSpace(text[i+1:])
+		}
+	}
+
+	return text, ""
+}
+
+// stripYAMLComment removes a trailing comment from given line.
+func stripYAMLComment(text string) string {
+	var quote byte
+
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			if i == 0 || text[i-1] == ' ' || text[i-1] == '\t' {
+				quote = c
+			}
+		case c == '#' && (i == 0 || text[i-1] == ' ' || text[i-1] == '\t'):
+			return strings.TrimRight(text[:i], " \t")
+		}
+	}
+
+	return text
+}
+
+func unquoteYAML(value string) (string, error) {
+	if len(value) < 2 {
+		return value, nil
+	}
+
+	switch value[0] {
+	case '"':
+		return strconv.Unquote(value)
+	case '\'':
+		if value[len(value)-1] != '\'' {
+			return "", errors.New("unterminated string")
+		}
+
+		return strings.Replace(value[1:len(value)-1], "''", "'", -1), nil
+	}
+
+	return value, nil
+}
+
+// setFromYAML assigns given YAML node to given value, scalars are assigned
+// using the setter collection.
+func (e *envConfig) setFromYAML(val reflect.Value, node interface{}) error {
+	if node == nil {
+		return nil
+	}
+
+	val, valType, err := e.allocate(val, val.Type())
+
+	if err != nil {
+		return err
+	}
+
+	if scalar, ok := node.(string); ok {
+		return e.setValue(val, scalar)
+	}
+
+	switch node := node.(type) {
+	case yamlMapping:
+		switch valType.Kind() {
+		case reflect.Struct:
+			for _, entry := range node {
+				field, ok := fieldByKey(valType, entry.Key)
+
+				if !ok {
+					return fmt.Errorf("Unknown field [%s] in struct [%v]", entry.Key, valType)
+				}
+
+				if err := e.setFromYAML(val.FieldByIndex(field.Index), entry.Value); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		case reflect.Map:
+			if val.IsNil() {
+				val.Set(reflect.MakeMap(valType))
+			}
+
+			for _, entry := range node {
+				keyValue := reflect.New(valType.Key()).Elem()
+
+				if err := e.setValue(keyValue, entry.Key); err != nil {
+					return err
+				}
+
+				elemValue := reflect.New(valType.Elem()).Elem()
+
+				if err := e.setFromYAML(elemValue, entry.Value); err != nil {
+					return err
+				}
+
+				val.SetMapIndex(keyValue, elemValue)
+			}
+
+			return nil
+		}
+	case []interface{}:
+		switch valType.Kind() {
+		case reflect.Slice:
+			slice := reflect.MakeSlice(valType, len(node), len(node))
+
+			for i, item := range node {
+				if err := e.setFromYAML(slice.Index(i), item); err != nil {
+					return err
+				}
+			}
+
+			val.Set(slice)
+
+			return nil
+		case reflect.Array:
+			if len(node) > valType.Len() {
+				return fmt.Errorf("Sequence of length [%d] is overflowing array of length [%d]", len(node), valType.Len())
+			}
+
+			for i, item := range node {
+				if err := e.setFromYAML(val.Index(i), item); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("Cannot assign YAML value to type [%v]", valType)
+}